@@ -0,0 +1,821 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// An ApplyFunc is invoked by Apply for each node n, even if n is nil,
+// before and/or after the node's children, using a Cursor describing
+// the current node and providing operations on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal.
+// See Apply for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a syntax tree recursively, starting with root,
+// and calling pre and post for each node as described below.
+// Apply returns the syntax tree, possibly modified.
+//
+// If pre is not nil, it is called for each node before the node's
+// children are traversed (pre-order). If pre returns false, no
+// children are traversed, and post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false,
+// post is called for each node after its children are traversed
+// (post-order). If post returns false, traversal is terminated and
+// Apply returns immediately.
+//
+// Only fields that refer to AST nodes are considered children;
+// i.e., string fields such as Alias or Database are not traversed.
+// Children are traversed in the order in which they are declared in
+// the respective node's struct, the same order used by Walk. Optional
+// children that are not set are visited as nil, so pre and post may
+// fill them in with Cursor.Replace. Fields that only repeat nodes held
+// by another field, such as TTLClause.Expression, are not traversed;
+// Apply sets them again from that field once its children are done.
+func Apply(root Node, pre, post ApplyFunc) (result Node) {
+	parent := &struct{ Node }{root}
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+		result = parent.Node
+	}()
+	a := &application{pre: pre, post: post}
+	a.apply(parent, "Node", nil, root)
+	return
+}
+
+var abort = new(int) // singleton, to signal termination of Apply
+
+// A Cursor describes a node encountered during Apply.
+// Information about the node and its parent is available
+// from the Node, Parent, Name, and Index methods.
+//
+// If p is a variable of type and value of the current parent node
+// c.Parent(), and f is the field identifier with name c.Name(),
+// the following invariants hold:
+//
+//	p.f            == c.Node()  if c.Index() <  0
+//	p.f[c.Index()] == c.Node()  if c.Index() >= 0
+//
+// The exceptions are the rows of InsertQuery.Values, where Index
+// refers to the position within the current row, and the elements of
+// a tuple or array Literal, which are stored in its Value field.
+//
+// The methods Replace, Delete, InsertBefore, and InsertAfter
+// can be used to change the AST without disrupting Apply.
+type Cursor struct {
+	parent Node
+	name   string
+	iter   *iterator // valid if non-nil
+	node   Node
+}
+
+// Node returns the current Node.
+func (c *Cursor) Node() Node { return c.node }
+
+// Parent returns the parent of the current Node.
+func (c *Cursor) Parent() Node { return c.parent }
+
+// Name returns the name of the parent Node field that contains the
+// current Node. If the parent is the root wrapper created by Apply,
+// Name returns "Node".
+func (c *Cursor) Name() string { return c.name }
+
+// Index reports the index >= 0 of the current Node in the slice of
+// Nodes that contains it, or a value < 0 if the current Node is not
+// part of a slice. The index of the current node changes if
+// InsertBefore is called while processing the current node.
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+	return -1
+}
+
+// field returns the current node's parent field value.
+func (c *Cursor) field() reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
+}
+
+// Replace replaces the current Node with n. The replacement node is
+// not walked by Apply. Replace panics if n cannot be stored in the
+// parent field, e.g. when replacing an Expression with a Statement.
+func (c *Cursor) Replace(n Node) {
+	v := c.field()
+	if c.iter != nil {
+		v = c.iter.list.Index(c.iter.index)
+	}
+	if n == nil {
+		v.Set(reflect.Zero(v.Type()))
+	} else {
+		v.Set(reflect.ValueOf(n))
+	}
+	c.node = n
+}
+
+// Delete deletes the current Node from its containing slice.
+// If the current Node is not part of a slice, Delete panics.
+func (c *Cursor) Delete() {
+	i := c.Index()
+	if i < 0 {
+		panic("Delete node not contained in slice")
+	}
+	v := c.iter.list
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+	c.iter.step--
+}
+
+// InsertAfter inserts n after the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertAfter panics.
+// Apply does not walk n.
+func (c *Cursor) InsertAfter(n Node) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertAfter node not contained in slice")
+	}
+	v := c.iter.list
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+2, l), v.Slice(i+1, l))
+	v.Index(i + 1).Set(reflect.ValueOf(n))
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertBefore panics.
+// Apply will not walk n.
+func (c *Cursor) InsertBefore(n Node) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertBefore node not contained in slice")
+	}
+	v := c.iter.list
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l))
+	v.Index(i).Set(reflect.ValueOf(n))
+	c.iter.index++
+}
+
+// application carries all the shared data so we can pass it around cheaply.
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+func (a *application) apply(parent Node, name string, iter *iterator, n Node) {
+	// convert typed nil into untyped nil
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Pointer && v.IsNil() {
+		n = nil
+	}
+
+	// avoid heap-allocating a new cursor for each apply call; reuse a.cursor instead
+	saved := a.cursor
+	a.cursor.parent = parent
+	a.cursor.name = name
+	a.cursor.iter = iter
+	a.cursor.node = n
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+
+	// walk children
+	// (the order of the cases matches the order used by Walk)
+	switch n := n.(type) {
+	case nil:
+		// nothing to do
+
+	// Statements
+	case *SelectWithUnionQuery:
+		a.applyList(n, "Selects")
+		a.applyList(n, "Settings")
+		a.apply(n, "Format", nil, n.Format)
+
+	case *SelectIntersectExceptQuery:
+		a.applyList(n, "Selects")
+
+	case *SelectQuery:
+		a.applyList(n, "With")
+		a.applyList(n, "DistinctOn")
+		a.apply(n, "Top", nil, n.Top)
+		a.applyList(n, "Columns")
+		a.apply(n, "From", nil, n.From)
+		a.apply(n, "ArrayJoin", nil, n.ArrayJoin)
+		a.apply(n, "PreWhere", nil, n.PreWhere)
+		a.apply(n, "Where", nil, n.Where)
+		a.applyList(n, "GroupBy")
+		a.apply(n, "Having", nil, n.Having)
+		a.apply(n, "Qualify", nil, n.Qualify)
+		a.applyList(n, "Window")
+		a.applyList(n, "OrderBy")
+		a.applyList(n, "Interpolate")
+		a.apply(n, "Limit", nil, n.Limit)
+		a.applyList(n, "LimitBy")
+		a.apply(n, "LimitByLimit", nil, n.LimitByLimit)
+		a.apply(n, "LimitByOffset", nil, n.LimitByOffset)
+		a.apply(n, "Offset", nil, n.Offset)
+		a.applyList(n, "Settings")
+		a.apply(n, "IntoOutfile", nil, n.IntoOutfile)
+		a.apply(n, "Format", nil, n.Format)
+
+	case *ArrayJoinClause:
+		a.applyList(n, "Columns")
+
+	case *WindowDefinition:
+		a.apply(n, "Spec", nil, n.Spec)
+
+	case *TablesInSelectQuery:
+		a.applyList(n, "Tables")
+
+	case *TablesInSelectQueryElement:
+		a.apply(n, "Table", nil, n.Table)
+		a.apply(n, "Join", nil, n.Join)
+		a.apply(n, "ArrayJoin", nil, n.ArrayJoin)
+
+	case *TableExpression:
+		a.apply(n, "Table", nil, n.Table)
+		a.apply(n, "Sample", nil, n.Sample)
+
+	case *SampleClause:
+		a.apply(n, "Ratio", nil, n.Ratio)
+		a.apply(n, "Offset", nil, n.Offset)
+
+	case *TableJoin:
+		a.apply(n, "On", nil, n.On)
+		a.applyList(n, "Using")
+
+	case *OrderByElement:
+		a.apply(n, "Expression", nil, n.Expression)
+		a.apply(n, "FillFrom", nil, n.FillFrom)
+		a.apply(n, "FillTo", nil, n.FillTo)
+		a.apply(n, "FillStep", nil, n.FillStep)
+		a.apply(n, "FillStaleness", nil, n.FillStaleness)
+
+	case *InterpolateElement:
+		a.apply(n, "Value", nil, n.Value)
+
+	case *SettingExpr:
+		a.apply(n, "Value", nil, n.Value)
+		a.apply(n, "Min", nil, n.Min)
+		a.apply(n, "Max", nil, n.Max)
+
+	case *InsertQuery:
+		a.apply(n, "Function", nil, n.Function)
+		a.applyList(n, "Columns")
+		a.applyList(n, "ColumnExpressions")
+		a.apply(n, "PartitionBy", nil, n.PartitionBy)
+		for i := range n.Values {
+			a.applySlice(n, "Values", reflect.ValueOf(n.Values).Index(i))
+		}
+		a.apply(n, "Select", nil, n.Select)
+		a.applyList(n, "With")
+		a.apply(n, "Format", nil, n.Format)
+		a.applyList(n, "Settings")
+
+	case *CreateTableQuery:
+		a.applyList(n, "Columns")
+		a.applyList(n, "Indexes")
+		a.applyList(n, "Projections")
+		a.applyList(n, "Constraints")
+		a.applyList(n, "ColumnsPrimaryKey")
+		a.apply(n, "Engine", nil, n.Engine)
+		a.applyList(n, "OrderBy")
+		a.apply(n, "PartitionBy", nil, n.PartitionBy)
+		a.applyList(n, "PrimaryKey")
+		a.apply(n, "SampleBy", nil, n.SampleBy)
+		a.apply(n, "TTL", nil, n.TTL)
+		a.applyList(n, "Settings")
+		a.applyList(n, "QuerySettings")
+		a.apply(n, "AsSelect", nil, n.AsSelect)
+		a.apply(n, "AsTableFunction", nil, n.AsTableFunction)
+
+	case *CreateViewQuery:
+		a.applyList(n, "Columns")
+		a.apply(n, "AsSelect", nil, n.AsSelect)
+
+	case *CreateMaterializedViewQuery:
+		a.apply(n, "RefreshInterval", nil, n.RefreshInterval)
+		a.applyList(n, "Columns")
+		a.applyList(n, "Indexes")
+		a.applyList(n, "Projections")
+		a.applyList(n, "ColumnsPrimaryKey")
+		a.apply(n, "Engine", nil, n.Engine)
+		a.applyList(n, "OrderBy")
+		a.apply(n, "PartitionBy", nil, n.PartitionBy)
+		a.applyList(n, "PrimaryKey")
+		a.apply(n, "SampleBy", nil, n.SampleBy)
+		a.apply(n, "TTL", nil, n.TTL)
+		a.applyList(n, "Settings")
+		a.applyList(n, "QuerySettings")
+		a.apply(n, "AsSelect", nil, n.AsSelect)
+
+	case *CreateWindowViewQuery:
+		a.applyList(n, "Columns")
+		a.apply(n, "InnerEngine", nil, n.InnerEngine)
+		a.apply(n, "Engine", nil, n.Engine)
+		a.applyList(n, "OrderBy")
+		a.apply(n, "PartitionBy", nil, n.PartitionBy)
+		a.applyList(n, "PrimaryKey")
+		a.apply(n, "SampleBy", nil, n.SampleBy)
+		a.apply(n, "TTL", nil, n.TTL)
+		a.applyList(n, "Settings")
+		a.applyList(n, "QuerySettings")
+		a.apply(n, "AsSelect", nil, n.AsSelect)
+
+	case *CreateDatabaseQuery:
+		a.apply(n, "Engine", nil, n.Engine)
+		a.applyList(n, "OrderBy")
+		a.applyList(n, "Settings")
+
+	case *CreateFunctionQuery:
+		a.apply(n, "Body", nil, n.Body)
+
+	case *CreateDictionaryQuery:
+		a.applyList(n, "Attributes")
+		a.apply(n, "Definition", nil, n.Definition)
+
+	case *ColumnDeclaration:
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Default", nil, n.Default)
+		a.apply(n, "Codec", nil, n.Codec)
+		a.applyList(n, "Statistics")
+		a.apply(n, "TTL", nil, n.TTL)
+		a.applyList(n, "Settings")
+
+	case *DictionaryAttributeDeclaration:
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Default", nil, n.Default)
+		a.apply(n, "Expression", nil, n.Expression)
+
+	case *DictionaryDefinition:
+		a.applyList(n, "PrimaryKey")
+		a.apply(n, "Source", nil, n.Source)
+		a.apply(n, "Lifetime", nil, n.Lifetime)
+		a.apply(n, "Layout", nil, n.Layout)
+		a.apply(n, "Range", nil, n.Range)
+		a.applyList(n, "Settings")
+
+	case *DictionarySource:
+		a.applyList(n, "Args")
+
+	case *KeyValuePair:
+		a.apply(n, "Value", nil, n.Value)
+
+	case *DictionaryLifetime:
+		a.apply(n, "Min", nil, n.Min)
+		a.apply(n, "Max", nil, n.Max)
+
+	case *DictionaryLayout:
+		a.applyList(n, "Args")
+
+	case *DictionaryRange:
+		a.apply(n, "Min", nil, n.Min)
+		a.apply(n, "Max", nil, n.Max)
+
+	case *DataType:
+		a.applyList(n, "Parameters")
+
+	case *ObjectTypeArgument:
+		a.apply(n, "Expr", nil, n.Expr)
+
+	case *NameTypePair:
+		a.apply(n, "Type", nil, n.Type)
+
+	case *CodecExpr:
+		a.applyList(n, "Codecs")
+
+	case *IndexDefinition:
+		a.apply(n, "Expression", nil, n.Expression)
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Granularity", nil, n.Granularity)
+
+	case *Constraint:
+		a.apply(n, "Expression", nil, n.Expression)
+
+	case *EngineClause:
+		a.applyList(n, "Parameters")
+
+	case *TTLClause:
+		a.applyList(n, "Elements")
+		n.Expression, n.Expressions = ttlExpressions(n.Elements)
+
+	case *TTLElement:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.apply(n, "Codec", nil, n.Codec)
+		a.apply(n, "Where", nil, n.Where)
+		a.applyList(n, "GroupBy")
+		a.applyList(n, "Set")
+
+	case *DropTableQuery:
+		a.applyList(n, "Tables")
+		a.applyList(n, "Settings")
+
+	case *DropViewQuery:
+		a.applyList(n, "Views")
+		a.applyList(n, "Settings")
+
+	case *DropDictionaryQuery:
+		a.applyList(n, "Settings")
+
+	case *DropDatabaseQuery:
+		a.applyList(n, "Settings")
+
+	case *UpdateQuery:
+		a.applyList(n, "Assignments")
+		a.apply(n, "Where", nil, n.Where)
+
+	case *AlterQuery:
+		a.applyList(n, "Commands")
+		a.applyList(n, "Settings")
+
+	case *AlterCommand:
+		a.apply(n, "Column", nil, n.Column)
+		a.apply(n, "IndexDef", nil, n.IndexDef)
+		n.IndexExpr = nil
+		if n.IndexDef != nil {
+			n.IndexExpr = n.IndexDef.Expression
+		}
+		a.apply(n, "Constraint", nil, n.Constraint)
+		a.apply(n, "Partition", nil, n.Partition)
+		a.apply(n, "TTL", nil, n.TTL)
+		a.applyList(n, "Settings")
+		a.apply(n, "Where", nil, n.Where)
+		a.applyList(n, "Assignments")
+		a.apply(n, "Projection", nil, n.Projection)
+		a.applyList(n, "StatisticsTypes")
+		a.applyList(n, "OrderByExpr")
+		a.apply(n, "SampleByExpr", nil, n.SampleByExpr)
+		a.apply(n, "Query", nil, n.Query)
+		a.apply(n, "RefreshInterval", nil, n.RefreshInterval)
+		a.apply(n, "RefreshOffset", nil, n.RefreshOffset)
+		a.apply(n, "RefreshRandomize", nil, n.RefreshRandomize)
+
+	case *Projection:
+		a.apply(n, "Select", nil, n.Select)
+
+	case *ProjectionSelectQuery:
+		a.applyList(n, "With")
+		a.applyList(n, "Columns")
+		a.applyList(n, "GroupBy")
+		a.applyList(n, "OrderBy")
+
+	case *Assignment:
+		a.apply(n, "Value", nil, n.Value)
+
+	case *TruncateQuery:
+		a.applyList(n, "Settings")
+
+	case *DeleteQuery:
+		a.apply(n, "Partition", nil, n.Partition)
+		a.apply(n, "Where", nil, n.Where)
+		a.applyList(n, "Settings")
+
+	case *AttachQuery:
+		a.applyList(n, "Columns")
+		a.applyList(n, "ColumnsPrimaryKey")
+		a.applyList(n, "Indexes")
+		a.apply(n, "Engine", nil, n.Engine)
+		a.applyList(n, "OrderBy")
+		a.applyList(n, "PrimaryKey")
+		a.apply(n, "PartitionBy", nil, n.PartitionBy)
+		a.apply(n, "SelectQuery", nil, n.SelectQuery)
+		a.applyList(n, "Settings")
+
+	case *BackupQuery:
+		a.apply(n, "Target", nil, n.Target)
+		a.applyList(n, "Settings")
+
+	case *RestoreQuery:
+		a.apply(n, "Source", nil, n.Source)
+		a.applyList(n, "Settings")
+
+	case *DescribeQuery:
+		a.apply(n, "TableFunction", nil, n.TableFunction)
+		a.apply(n, "TableExpr", nil, n.TableExpr)
+		a.applyList(n, "Settings")
+
+	case *ShowQuery:
+		a.apply(n, "Where", nil, n.Where)
+		a.apply(n, "Limit", nil, n.Limit)
+
+	case *ExplainQuery:
+		a.apply(n, "Statement", nil, n.Statement)
+
+	case *SetQuery:
+		a.applyList(n, "Settings")
+
+	case *OptimizeQuery:
+		a.apply(n, "Partition", nil, n.Partition)
+		a.applyList(n, "Settings")
+
+	case *CheckQuery:
+		a.apply(n, "Partition", nil, n.Partition)
+		a.apply(n, "Part", nil, n.Part)
+		a.applyList(n, "Settings")
+
+	case *SystemQuery:
+		a.apply(n, "Duration", nil, n.Duration)
+		a.applyList(n, "Settings")
+
+	case *RenameQuery:
+		a.applyList(n, "Pairs")
+		a.applyList(n, "Settings")
+
+	case *ExistsQuery:
+		a.applyList(n, "Settings")
+
+	case *KillQuery:
+		a.apply(n, "Where", nil, n.Where)
+		a.applyList(n, "Settings")
+
+	case *CreateIndexQuery:
+		a.applyList(n, "Columns")
+
+	case *ParallelWithQuery:
+		a.applyList(n, "Statements")
+
+	case *BadStatement:
+		a.apply(n, "Partial", nil, n.Partial)
+
+	case *GrantQuery:
+		a.applyList(n, "Elements")
+		a.apply(n, "Grantees", nil, n.Grantees)
+
+	case *GrantElement:
+		a.applyList(n, "Privileges")
+
+	case *CreateRoleQuery:
+		a.applyList(n, "Settings")
+
+	case *CreateUserQuery:
+		a.applyList(n, "AuthMethods")
+		a.applyList(n, "Hosts")
+		a.applyList(n, "AddHosts")
+		a.applyList(n, "DropHosts")
+		a.apply(n, "ValidUntil", nil, n.ValidUntil)
+		a.apply(n, "DefaultRoles", nil, n.DefaultRoles)
+		a.apply(n, "Grantees", nil, n.Grantees)
+		a.applyList(n, "Settings")
+
+	case *AuthenticationMethod:
+		a.apply(n, "Value", nil, n.Value)
+		a.applyList(n, "SSHKeys")
+		a.apply(n, "ValidUntil", nil, n.ValidUntil)
+
+	case *SetRoleQuery:
+		a.apply(n, "Roles", nil, n.Roles)
+		a.apply(n, "Users", nil, n.Users)
+
+	case *CreateRowPolicyQuery:
+		a.applyList(n, "Policies")
+		a.apply(n, "Using", nil, n.Using)
+		a.apply(n, "Roles", nil, n.Roles)
+
+	case *RowPolicyName:
+		a.applyList(n, "Tables")
+
+	case *DropRowPolicyQuery:
+		a.applyList(n, "Policies")
+
+	case *CreateSettingsProfileQuery:
+		a.applyList(n, "Settings")
+		a.apply(n, "Roles", nil, n.Roles)
+
+	case *AlterSettingsProfileQuery:
+		a.applyList(n, "Settings")
+		a.applyList(n, "AddSettings")
+		a.applyList(n, "ModifySettings")
+		a.apply(n, "Roles", nil, n.Roles)
+
+	case *CreateNamedCollectionQuery:
+		a.applyList(n, "Pairs")
+
+	case *AlterNamedCollectionQuery:
+		a.applyList(n, "Set")
+
+	case *CreateResourceQuery:
+		a.applyList(n, "Operations")
+
+	case *CreateWorkloadQuery:
+		a.applyList(n, "Settings")
+
+	case *CreateQuotaQuery:
+		a.applyList(n, "Intervals")
+		a.apply(n, "Roles", nil, n.Roles)
+
+	case *QuotaInterval:
+		a.apply(n, "Duration", nil, n.Duration)
+		a.applyList(n, "Limits")
+
+	case *QuotaLimit:
+		a.apply(n, "Value", nil, n.Value)
+
+	case *UndropQuery, *UseQuery, *DetachQuery, *TransactionControlQuery,
+		*RenamePair, *ExchangeQuery, *ShowGrantsQuery, *Privilege, *RoleSet,
+		*ShowPrivilegesQuery, *ShowCreateQuotaQuery,
+		*DropSettingsProfileQuery, *DropNamedCollectionQuery,
+		*ShowCreateSettingsProfileQuery, *ShowCreateRowPolicyQuery,
+		*DropRoleQuery, *ShowCreateRoleQuery, *ResourceOperation,
+		*SSHKey, *UserHost,
+		*DropResourceQuery, *DropWorkloadQuery,
+		*DropFunctionQuery, *DropIndexQuery, *DropUserQuery, *DropQuotaQuery,
+		*IntoOutfileClause:
+		// nothing to do
+
+	// Expressions
+	case *Literal:
+		if exprs, ok := n.Value.([]Expression); ok {
+			a.applySlice(n, "Value", reflect.ValueOf(&exprs).Elem())
+			n.Value = exprs
+		}
+
+	case *Asterisk:
+		a.applyList(n, "Transformers")
+		n.Replace = replaceExprs(n.Transformers)
+
+	case *ReplaceExpr:
+		a.apply(n, "Expr", nil, n.Expr)
+
+	case *ColumnTransformer:
+		a.applyList(n, "ApplyParams")
+		a.apply(n, "ApplyLambda", nil, n.ApplyLambda)
+		a.applyList(n, "Replaces")
+
+	case *ColumnsMatcher:
+		a.applyList(n, "Columns")
+		a.applyList(n, "Transformers")
+		n.Replace = replaceExprs(n.Transformers)
+
+	case *FunctionCall:
+		a.applyList(n, "Parameters")
+		a.applyList(n, "Arguments")
+		a.applyList(n, "Settings")
+		a.apply(n, "Filter", nil, n.Filter)
+		a.apply(n, "Over", nil, n.Over)
+
+	case *WindowSpec:
+		a.applyList(n, "PartitionBy")
+		a.applyList(n, "OrderBy")
+		a.apply(n, "Frame", nil, n.Frame)
+
+	case *WindowFrame:
+		a.apply(n, "StartBound", nil, n.StartBound)
+		a.apply(n, "EndBound", nil, n.EndBound)
+
+	case *FrameBound:
+		a.apply(n, "Offset", nil, n.Offset)
+
+	case *BinaryExpr:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+
+	case *UnaryExpr:
+		a.apply(n, "Operand", nil, n.Operand)
+
+	case *TernaryExpr:
+		a.apply(n, "Condition", nil, n.Condition)
+		a.apply(n, "Then", nil, n.Then)
+		a.apply(n, "Else", nil, n.Else)
+
+	case *Subquery:
+		a.apply(n, "Query", nil, n.Query)
+
+	case *WithElement:
+		a.apply(n, "Query", nil, n.Query)
+
+	case *CaseExpr:
+		a.apply(n, "Operand", nil, n.Operand)
+		a.applyList(n, "Whens")
+		a.apply(n, "Else", nil, n.Else)
+
+	case *WhenClause:
+		a.apply(n, "Condition", nil, n.Condition)
+		a.apply(n, "Result", nil, n.Result)
+
+	case *CastExpr:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "TypeExpr", nil, n.TypeExpr)
+
+	case *ExtractExpr:
+		a.apply(n, "From", nil, n.From)
+
+	case *IntervalExpr:
+		a.apply(n, "Value", nil, n.Value)
+
+	case *ArrayAccess:
+		a.apply(n, "Array", nil, n.Array)
+		a.apply(n, "Index", nil, n.Index)
+
+	case *TupleAccess:
+		a.apply(n, "Tuple", nil, n.Tuple)
+		a.apply(n, "Index", nil, n.Index)
+
+	case *Lambda:
+		a.apply(n, "Body", nil, n.Body)
+
+	case *Parameter:
+		a.apply(n, "Type", nil, n.Type)
+
+	case *AliasedExpr:
+		a.apply(n, "Expr", nil, n.Expr)
+
+	case *BetweenExpr:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.apply(n, "Low", nil, n.Low)
+		a.apply(n, "High", nil, n.High)
+
+	case *InExpr:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.applyList(n, "List")
+		a.apply(n, "Query", nil, n.Query)
+
+	case *IsNullExpr:
+		a.apply(n, "Expr", nil, n.Expr)
+
+	case *LikeExpr:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.apply(n, "Pattern", nil, n.Pattern)
+
+	case *ExistsExpr:
+		a.apply(n, "Query", nil, n.Query)
+
+	case *Identifier, *TableIdentifier, *Comment:
+		// nothing to do
+
+	default:
+		panic(fmt.Sprintf("ast.Apply: unexpected node type %T", n))
+	}
+
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+
+	a.cursor = saved
+}
+
+// ttlExpressions returns the expressions of elements as repeated in
+// TTLClause.Expression and TTLClause.Expressions.
+func ttlExpressions(elements []*TTLElement) (first Expression, rest []Expression) {
+	for i, elem := range elements {
+		var expr Expression
+		if elem != nil {
+			expr = elem.Expr
+		}
+		if i == 0 {
+			first = expr
+		} else {
+			rest = append(rest, expr)
+		}
+	}
+	return first, rest
+}
+
+// replaceExprs returns the replacements of the REPLACE transformers, as
+// repeated in Asterisk.Replace and ColumnsMatcher.Replace.
+func replaceExprs(transformers []*ColumnTransformer) []*ReplaceExpr {
+	var replaces []*ReplaceExpr
+	for _, t := range transformers {
+		if t != nil {
+			replaces = append(replaces, t.Replaces...)
+		}
+	}
+	return replaces
+}
+
+// An iterator controls iteration over a slice of nodes.
+type iterator struct {
+	list        reflect.Value // the slice being iterated over; must be settable
+	index, step int
+}
+
+// applyList applies to each element of the slice field name of parent.
+func (a *application) applyList(parent Node, name string) {
+	a.applySlice(parent, name, reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name))
+}
+
+// applySlice applies to each element of list, which is reported to the
+// cursor as the field name of parent.
+func (a *application) applySlice(parent Node, name string, list reflect.Value) {
+	// avoid heap-allocating a new iterator for each applySlice call; reuse a.iter instead
+	saved := a.iter
+	a.iter.list = list
+	a.iter.index = 0
+	for a.iter.index < list.Len() {
+		// elements may be nil, e.g. an empty tuple among function arguments
+		x, _ := list.Index(a.iter.index).Interface().(Node)
+		a.iter.step = 1
+		a.apply(parent, name, &a.iter, x)
+		a.iter.index += a.iter.step
+	}
+	a.iter = saved
+}
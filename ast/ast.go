@@ -2,16 +2,15 @@
 package ast
 
 import (
-	"encoding/json"
-	"math"
-
 	"github.com/sqlc-dev/doubleclick/token"
 )
 
 // Node is the interface implemented by all AST nodes.
+// The Offset fields of Pos and End give the node's byte range in the
+// parsed source; use Text to retrieve the original text.
 type Node interface {
-	Pos() token.Position
-	End() token.Position
+	Pos() token.Position // position of the first character of the node
+	End() token.Position // position immediately after the node
 }
 
 // Statement is the interface implemented by all statement nodes.
@@ -32,153 +31,165 @@ type Expression interface {
 // SelectWithUnionQuery represents a SELECT query possibly with UNION.
 type SelectWithUnionQuery struct {
 	Position             token.Position `json:"-"`
+	EndPosition          token.Position `json:"-"`
 	Selects              []Statement    `json:"selects"`
 	UnionAll             bool           `json:"union_all,omitempty"`
 	UnionModes           []string       `json:"union_modes,omitempty"` // "ALL", "DISTINCT", or "" for each union
 	Settings             []*SettingExpr `json:"settings,omitempty"`    // Union-level SETTINGS
+	Format               *Identifier    `json:"format,omitempty"`      // Union-level FORMAT
 	SettingsAfterFormat  bool           `json:"settings_after_format,omitempty"`
 	SettingsBeforeFormat bool           `json:"settings_before_format,omitempty"`
 }
 
 func (s *SelectWithUnionQuery) Pos() token.Position { return s.Position }
-func (s *SelectWithUnionQuery) End() token.Position { return s.Position }
+func (s *SelectWithUnionQuery) End() token.Position { return s.EndPosition }
 func (s *SelectWithUnionQuery) statementNode()      {}
 
 // SelectIntersectExceptQuery represents SELECT ... INTERSECT/EXCEPT ... queries.
 type SelectIntersectExceptQuery struct {
-	Position  token.Position `json:"-"`
-	Selects   []Statement    `json:"selects"`
-	Operators []string       `json:"operators,omitempty"` // "INTERSECT", "EXCEPT", etc. for each operator between selects
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Selects     []Statement    `json:"selects"`
+	Operators   []string       `json:"operators,omitempty"` // "INTERSECT", "EXCEPT", etc. for each operator between selects
 }
 
 func (s *SelectIntersectExceptQuery) Pos() token.Position { return s.Position }
-func (s *SelectIntersectExceptQuery) End() token.Position { return s.Position }
+func (s *SelectIntersectExceptQuery) End() token.Position { return s.EndPosition }
 func (s *SelectIntersectExceptQuery) statementNode()      {}
 
 // SelectQuery represents a SELECT statement.
 type SelectQuery struct {
-	Position    token.Position        `json:"-"`
-	With        []Expression          `json:"with,omitempty"`
-	Distinct    bool                  `json:"distinct,omitempty"`
-	DistinctOn  []Expression          `json:"distinct_on,omitempty"` // DISTINCT ON (col1, col2, ...) syntax
-	Top         Expression            `json:"top,omitempty"`
-	Columns     []Expression          `json:"columns"`
-	From        *TablesInSelectQuery  `json:"from,omitempty"`
-	ArrayJoin   *ArrayJoinClause      `json:"array_join,omitempty"`
-	PreWhere    Expression            `json:"prewhere,omitempty"`
-	Where       Expression            `json:"where,omitempty"`
-	GroupBy     []Expression          `json:"group_by,omitempty"`
-	GroupByAll  bool                  `json:"group_by_all,omitempty"` // true if GROUP BY ALL was used
-	GroupingSets bool                 `json:"grouping_sets,omitempty"` // true if GROUP BY uses GROUPING SETS
-	WithRollup  bool                  `json:"with_rollup,omitempty"`
-	WithCube    bool                  `json:"with_cube,omitempty"`
-	WithTotals  bool                  `json:"with_totals,omitempty"`
-	Having      Expression            `json:"having,omitempty"`
-	Qualify     Expression            `json:"qualify,omitempty"`
-	Window      []*WindowDefinition   `json:"window,omitempty"`
-	OrderBy      []*OrderByElement      `json:"order_by,omitempty"`
-	Interpolate  []*InterpolateElement  `json:"interpolate,omitempty"`
-	Limit            Expression            `json:"limit,omitempty"`
-	LimitBy          []Expression          `json:"limit_by,omitempty"`
-	LimitByLimit     Expression            `json:"limit_by_limit,omitempty"`     // LIMIT value before BY (e.g., LIMIT 1 BY x LIMIT 3)
-	LimitByOffset    Expression            `json:"limit_by_offset,omitempty"`    // Offset for LIMIT BY (e.g., LIMIT 2, 3 BY x -> offset=2)
-	LimitByHasLimit  bool                  `json:"limit_by_has_limit,omitempty"` // true if LIMIT BY was followed by another LIMIT
-	Offset           Expression            `json:"offset,omitempty"`
-	Settings            []*SettingExpr `json:"settings,omitempty"`
-	SettingsAfterFormat bool           `json:"settings_after_format,omitempty"`  // true if SETTINGS came after FORMAT (at union level)
-	SettingsBeforeFormat bool          `json:"settings_before_format,omitempty"` // true if SETTINGS came before FORMAT (at union level)
-	IntoOutfile *IntoOutfileClause    `json:"into_outfile,omitempty"`
-	Format      *Identifier           `json:"format,omitempty"`
+	Position             token.Position        `json:"-"`
+	EndPosition          token.Position        `json:"-"`
+	With                 []Expression          `json:"with,omitempty"`
+	Distinct             bool                  `json:"distinct,omitempty"`
+	DistinctOn           []Expression          `json:"distinct_on,omitempty"` // DISTINCT ON (col1, col2, ...) syntax
+	Top                  Expression            `json:"top,omitempty"`
+	Columns              []Expression          `json:"columns"`
+	From                 *TablesInSelectQuery  `json:"from,omitempty"`
+	ArrayJoin            *ArrayJoinClause      `json:"array_join,omitempty"`
+	PreWhere             Expression            `json:"prewhere,omitempty"`
+	Where                Expression            `json:"where,omitempty"`
+	GroupBy              []Expression          `json:"group_by,omitempty"`
+	GroupByAll           bool                  `json:"group_by_all,omitempty"`  // true if GROUP BY ALL was used
+	GroupingSets         bool                  `json:"grouping_sets,omitempty"` // true if GROUP BY uses GROUPING SETS
+	WithRollup           bool                  `json:"with_rollup,omitempty"`
+	WithCube             bool                  `json:"with_cube,omitempty"`
+	WithTotals           bool                  `json:"with_totals,omitempty"`
+	Having               Expression            `json:"having,omitempty"`
+	Qualify              Expression            `json:"qualify,omitempty"`
+	Window               []*WindowDefinition   `json:"window,omitempty"`
+	OrderBy              []*OrderByElement     `json:"order_by,omitempty"`
+	Interpolate          []*InterpolateElement `json:"interpolate,omitempty"`
+	Limit                Expression            `json:"limit,omitempty"`
+	LimitBy              []Expression          `json:"limit_by,omitempty"`
+	LimitByLimit         Expression            `json:"limit_by_limit,omitempty"`     // LIMIT value before BY (e.g., LIMIT 1 BY x LIMIT 3)
+	LimitByOffset        Expression            `json:"limit_by_offset,omitempty"`    // Offset for LIMIT BY (e.g., LIMIT 2, 3 BY x -> offset=2)
+	LimitByHasLimit      bool                  `json:"limit_by_has_limit,omitempty"` // true if LIMIT BY was followed by another LIMIT
+	Offset               Expression            `json:"offset,omitempty"`
+	Settings             []*SettingExpr        `json:"settings,omitempty"`
+	SettingsAfterFormat  bool                  `json:"settings_after_format,omitempty"`  // true if SETTINGS came after FORMAT (at union level)
+	SettingsBeforeFormat bool                  `json:"settings_before_format,omitempty"` // true if SETTINGS came before FORMAT (at union level)
+	IntoOutfile          *IntoOutfileClause    `json:"into_outfile,omitempty"`
+	Format               *Identifier           `json:"format,omitempty"`
 }
 
 // ArrayJoinClause represents an ARRAY JOIN clause.
 type ArrayJoinClause struct {
-	Position token.Position `json:"-"`
-	Left     bool           `json:"left,omitempty"`
-	Columns  []Expression   `json:"columns"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Left        bool           `json:"left,omitempty"`
+	Columns     []Expression   `json:"columns"`
 }
 
 func (a *ArrayJoinClause) Pos() token.Position { return a.Position }
-func (a *ArrayJoinClause) End() token.Position { return a.Position }
+func (a *ArrayJoinClause) End() token.Position { return a.EndPosition }
 
 // WindowDefinition represents a named window definition in the WINDOW clause.
 type WindowDefinition struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name"`
-	Spec     *WindowSpec    `json:"spec"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Name        string         `json:"name"`
+	Spec        *WindowSpec    `json:"spec"`
 }
 
 func (w *WindowDefinition) Pos() token.Position { return w.Position }
-func (w *WindowDefinition) End() token.Position { return w.Position }
+func (w *WindowDefinition) End() token.Position { return w.EndPosition }
 
 // IntoOutfileClause represents INTO OUTFILE clause.
 type IntoOutfileClause struct {
-	Position token.Position `json:"-"`
-	Filename string         `json:"filename"`
-	Truncate bool           `json:"truncate,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Filename    string         `json:"filename"`
+	Truncate    bool           `json:"truncate,omitempty"`
 }
 
 func (i *IntoOutfileClause) Pos() token.Position { return i.Position }
-func (i *IntoOutfileClause) End() token.Position { return i.Position }
+func (i *IntoOutfileClause) End() token.Position { return i.EndPosition }
 
 func (s *SelectQuery) Pos() token.Position { return s.Position }
-func (s *SelectQuery) End() token.Position { return s.Position }
+func (s *SelectQuery) End() token.Position { return s.EndPosition }
 func (s *SelectQuery) statementNode()      {}
 
 // TablesInSelectQuery represents the tables in a SELECT query.
 type TablesInSelectQuery struct {
-	Position token.Position              `json:"-"`
-	Tables   []*TablesInSelectQueryElement `json:"tables"`
+	Position    token.Position                `json:"-"`
+	EndPosition token.Position                `json:"-"`
+	Tables      []*TablesInSelectQueryElement `json:"tables"`
 }
 
 func (t *TablesInSelectQuery) Pos() token.Position { return t.Position }
-func (t *TablesInSelectQuery) End() token.Position { return t.Position }
+func (t *TablesInSelectQuery) End() token.Position { return t.EndPosition }
 
 // TablesInSelectQueryElement represents a single table element in a SELECT.
 type TablesInSelectQueryElement struct {
-	Position  token.Position    `json:"-"`
-	Table     *TableExpression  `json:"table,omitempty"`
-	Join      *TableJoin        `json:"join,omitempty"`
-	ArrayJoin *ArrayJoinClause  `json:"array_join,omitempty"` // For ARRAY JOIN as table element
+	Position    token.Position   `json:"-"`
+	EndPosition token.Position   `json:"-"`
+	Table       *TableExpression `json:"table,omitempty"`
+	Join        *TableJoin       `json:"join,omitempty"`
+	ArrayJoin   *ArrayJoinClause `json:"array_join,omitempty"` // For ARRAY JOIN as table element
 }
 
 func (t *TablesInSelectQueryElement) Pos() token.Position { return t.Position }
-func (t *TablesInSelectQueryElement) End() token.Position { return t.Position }
+func (t *TablesInSelectQueryElement) End() token.Position { return t.EndPosition }
 
 // TableExpression represents a table reference.
 type TableExpression struct {
-	Position token.Position `json:"-"`
-	Table    Expression     `json:"table"` // TableIdentifier, Subquery, or Function
-	Alias    string         `json:"alias,omitempty"`
-	Final    bool           `json:"final,omitempty"`
-	Sample   *SampleClause  `json:"sample,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Table       Expression     `json:"table"` // TableIdentifier, Subquery, or Function
+	Alias       string         `json:"alias,omitempty"`
+	Final       bool           `json:"final,omitempty"`
+	Sample      *SampleClause  `json:"sample,omitempty"`
 }
 
 func (t *TableExpression) Pos() token.Position { return t.Position }
-func (t *TableExpression) End() token.Position { return t.Position }
+func (t *TableExpression) End() token.Position { return t.EndPosition }
 
 // SampleClause represents a SAMPLE clause.
 type SampleClause struct {
-	Position token.Position `json:"-"`
-	Ratio    Expression     `json:"ratio"`
-	Offset   Expression     `json:"offset,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Ratio       Expression     `json:"ratio"`
+	Offset      Expression     `json:"offset,omitempty"`
 }
 
 func (s *SampleClause) Pos() token.Position { return s.Position }
-func (s *SampleClause) End() token.Position { return s.Position }
+func (s *SampleClause) End() token.Position { return s.EndPosition }
 
 // TableJoin represents a JOIN clause.
 type TableJoin struct {
-	Position  token.Position `json:"-"`
-	Type      JoinType       `json:"type"`
-	Strictness JoinStrictness `json:"strictness,omitempty"`
-	Global    bool           `json:"global,omitempty"`
-	On        Expression     `json:"on,omitempty"`
-	Using     []Expression   `json:"using,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Type        JoinType       `json:"type"`
+	Strictness  JoinStrictness `json:"strictness,omitempty"`
+	Global      bool           `json:"global,omitempty"`
+	On          Expression     `json:"on,omitempty"`
+	Using       []Expression   `json:"using,omitempty"`
 }
 
 func (t *TableJoin) Pos() token.Position { return t.Position }
-func (t *TableJoin) End() token.Position { return t.Position }
+func (t *TableJoin) End() token.Position { return t.EndPosition }
 
 // JoinType represents the type of join.
 type JoinType string
@@ -206,6 +217,7 @@ const (
 // OrderByElement represents an ORDER BY element.
 type OrderByElement struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	Expression    Expression     `json:"expression"`
 	Descending    bool           `json:"descending,omitempty"`
 	NullsFirst    *bool          `json:"nulls_first,omitempty"`
@@ -218,118 +230,255 @@ type OrderByElement struct {
 }
 
 func (o *OrderByElement) Pos() token.Position { return o.Position }
-func (o *OrderByElement) End() token.Position { return o.Position }
+func (o *OrderByElement) End() token.Position { return o.EndPosition }
 
 // InterpolateElement represents a single column interpolation in INTERPOLATE clause.
 // Example: INTERPOLATE (value AS value + 1)
 type InterpolateElement struct {
-	Position token.Position `json:"-"`
-	Column   string         `json:"column"`
-	Value    Expression     `json:"value,omitempty"` // nil if just column name
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Column      string         `json:"column"`
+	Value       Expression     `json:"value,omitempty"` // nil if just column name
 }
 
 func (i *InterpolateElement) Pos() token.Position { return i.Position }
-func (i *InterpolateElement) End() token.Position { return i.Position }
+func (i *InterpolateElement) End() token.Position { return i.EndPosition }
 
-// SettingExpr represents a setting expression.
+// SettingExpr represents a setting expression. In the SETTINGS clause of
+// CREATE ROLE, CREATE USER or CREATE SETTINGS PROFILE it may have no value
+// and carry constraints, as in max_memory_usage = 5000000 MIN 4000000 MAX
+// 6000000 WRITABLE, or name a profile to inherit from instead of a
+// setting, as in INHERIT 'default'.
 type SettingExpr struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name"`
-	Value    Expression     `json:"value"`
+	Position    token.Position     `json:"-"`
+	EndPosition token.Position     `json:"-"`
+	Name        string             `json:"name"`
+	Value       Expression         `json:"value"`
+	Resource    string             `json:"resource,omitempty"` // FOR resource of a CREATE WORKLOAD setting
+	Profile     string             `json:"profile,omitempty"`  // PROFILE or INHERIT name, in place of a setting
+	Min         Expression         `json:"min,omitempty"`
+	Max         Expression         `json:"max,omitempty"`
+	Writability SettingWritability `json:"writability,omitempty"`
 }
 
 func (s *SettingExpr) Pos() token.Position { return s.Position }
-func (s *SettingExpr) End() token.Position { return s.Position }
+func (s *SettingExpr) End() token.Position { return s.EndPosition }
 
 // InsertQuery represents an INSERT statement.
 type InsertQuery struct {
-	Position          token.Position   `json:"-"`
-	Database          string           `json:"database,omitempty"`
-	Table             string           `json:"table,omitempty"`
-	Function          *FunctionCall    `json:"function,omitempty"` // For INSERT INTO FUNCTION syntax
-	Columns           []*Identifier    `json:"columns,omitempty"`
-	ColumnExpressions []Expression     `json:"column_expressions,omitempty"` // For asterisk/COLUMNS expressions with transformers
-	AllColumns        bool             `json:"all_columns,omitempty"`        // For (*) syntax meaning all columns
-	PartitionBy       Expression       `json:"partition_by,omitempty"`       // For PARTITION BY clause
-	Infile            string           `json:"infile,omitempty"`             // For FROM INFILE clause
-	Compression       string           `json:"compression,omitempty"`        // For COMPRESSION clause
-	Values            [][]Expression   `json:"-"`                            // For VALUES clause (format only, not in AST JSON)
-	Select            Statement        `json:"select,omitempty"`
-	With              []Expression     `json:"with,omitempty"` // For WITH ... INSERT ... SELECT syntax
-	Format            *Identifier      `json:"format,omitempty"`
-	HasSettings       bool             `json:"has_settings,omitempty"` // For SETTINGS clause
-	Settings          []*SettingExpr   `json:"settings,omitempty"`     // For SETTINGS clause in INSERT
+	Position          token.Position `json:"-"`
+	EndPosition       token.Position `json:"-"`
+	Database          string         `json:"database,omitempty"`
+	Table             string         `json:"table,omitempty"`
+	Function          *FunctionCall  `json:"function,omitempty"` // For INSERT INTO FUNCTION syntax
+	Columns           []*Identifier  `json:"columns,omitempty"`
+	ColumnExpressions []Expression   `json:"column_expressions,omitempty"` // For asterisk/COLUMNS expressions with transformers
+	AllColumns        bool           `json:"all_columns,omitempty"`        // For (*) syntax meaning all columns
+	PartitionBy       Expression     `json:"partition_by,omitempty"`       // For PARTITION BY clause
+	Infile            string         `json:"infile,omitempty"`             // For FROM INFILE clause
+	Compression       string         `json:"compression,omitempty"`        // For COMPRESSION clause
+	Values            [][]Expression `json:"values,omitempty"`             // For VALUES clause
+	Select            Statement      `json:"select,omitempty"`
+	With              []Expression   `json:"with,omitempty"` // For WITH ... INSERT ... SELECT syntax
+	Format            *Identifier    `json:"format,omitempty"`
+	Data              string         `json:"data,omitempty"`         // Inline data after FORMAT, exactly as written
+	DataPosition      token.Position `json:"-"`                      // Start of Data
+	DataEndPosition   token.Position `json:"-"`                      // End of Data, which is the end of the input
+	HasSettings       bool           `json:"has_settings,omitempty"` // For SETTINGS clause
+	Settings          []*SettingExpr `json:"settings,omitempty"`     // For SETTINGS clause in INSERT
 }
 
 func (i *InsertQuery) Pos() token.Position { return i.Position }
-func (i *InsertQuery) End() token.Position { return i.Position }
+func (i *InsertQuery) End() token.Position { return i.EndPosition }
 func (i *InsertQuery) statementNode()      {}
 
-// CreateQuery represents a CREATE statement.
-type CreateQuery struct {
-	Position         token.Position       `json:"-"`
-	OrReplace        bool                 `json:"or_replace,omitempty"`
-	IfNotExists      bool                 `json:"if_not_exists,omitempty"`
-	Temporary        bool                 `json:"temporary,omitempty"`
-	Database         string               `json:"database,omitempty"`
-	Table            string               `json:"table,omitempty"`
-	View             string               `json:"view,omitempty"`
-	Materialized     bool                 `json:"materialized,omitempty"`
-	WindowView       bool                 `json:"window_view,omitempty"` // WINDOW VIEW type
-	InnerEngine      *EngineClause        `json:"inner_engine,omitempty"` // INNER ENGINE for window views
-	ToDatabase       string               `json:"to_database,omitempty"` // Target database for materialized views
-	To               string               `json:"to,omitempty"`          // Target table for materialized views
-	Populate         bool                 `json:"populate,omitempty"`    // POPULATE for materialized views
-	HasRefresh       bool                 `json:"has_refresh,omitempty"` // Has REFRESH clause
-	RefreshType      string               `json:"refresh_type,omitempty"` // AFTER or EVERY
-	RefreshInterval  Expression           `json:"refresh_interval,omitempty"` // Interval value
-	RefreshUnit      string               `json:"refresh_unit,omitempty"` // SECOND, MINUTE, etc.
-	RefreshAppend    bool                 `json:"refresh_append,omitempty"` // APPEND TO was specified
-	Empty            bool                 `json:"empty,omitempty"`       // EMPTY keyword was specified
-	Columns          []*ColumnDeclaration `json:"columns,omitempty"`
-	Indexes          []*IndexDefinition   `json:"indexes,omitempty"`
-	Projections      []*Projection        `json:"projections,omitempty"`
-	Constraints         []*Constraint        `json:"constraints,omitempty"`
-	ColumnsPrimaryKey       []Expression         `json:"columns_primary_key,omitempty"`        // PRIMARY KEY in column list
-	HasEmptyColumnsPrimaryKey bool               `json:"has_empty_columns_primary_key,omitempty"` // TRUE if PRIMARY KEY () was seen with empty parens
-	Engine               *EngineClause        `json:"engine,omitempty"`
-	OrderBy              []Expression         `json:"order_by,omitempty"`
-	OrderByHasModifiers  bool                 `json:"order_by_has_modifiers,omitempty"` // True if ORDER BY has ASC/DESC modifiers
-	PartitionBy          Expression           `json:"partition_by,omitempty"`
-	PrimaryKey          []Expression         `json:"primary_key,omitempty"`
-	SampleBy         Expression           `json:"sample_by,omitempty"`
-	TTL              *TTLClause           `json:"ttl,omitempty"`
-	Settings             []*SettingExpr       `json:"settings,omitempty"`
-	QuerySettings        []*SettingExpr       `json:"query_settings,omitempty"`          // Query-level SETTINGS (second SETTINGS clause)
-	SettingsBeforeComment bool                `json:"settings_before_comment,omitempty"` // True if SETTINGS comes before COMMENT
-	AsSelect         Statement            `json:"as_select,omitempty"`
-	AsTableFunction  Expression           `json:"as_table_function,omitempty"` // AS table_function(...) in CREATE TABLE
-	CloneAs          string               `json:"clone_as,omitempty"`          // CLONE AS source_table in CREATE TABLE
-	Comment          string               `json:"comment,omitempty"`
-	OnCluster        string               `json:"on_cluster,omitempty"`
-	CreateDatabase   bool                 `json:"create_database,omitempty"`
-	CreateFunction   bool                 `json:"create_function,omitempty"`
-	CreateUser       bool                 `json:"create_user,omitempty"`
-	AlterUser        bool                 `json:"alter_user,omitempty"`
-	HasAuthenticationData bool            `json:"has_authentication_data,omitempty"`
-	AuthenticationValues []string         `json:"authentication_values,omitempty"` // Password/hash values from IDENTIFIED BY
-	SSHKeyCount          int              `json:"ssh_key_count,omitempty"`         // Number of SSH keys for ssh_key auth
-	CreateDictionary   bool                              `json:"create_dictionary,omitempty"`
-	DictionaryAttrs    []*DictionaryAttributeDeclaration `json:"dictionary_attrs,omitempty"`
-	DictionaryDef      *DictionaryDefinition             `json:"dictionary_def,omitempty"`
-	FunctionName       string                            `json:"function_name,omitempty"`
-	FunctionBody     Expression           `json:"function_body,omitempty"`
-	UserName         string               `json:"user_name,omitempty"`
-	Format           string               `json:"format,omitempty"` // For FORMAT clause
-}
-
-func (c *CreateQuery) Pos() token.Position { return c.Position }
-func (c *CreateQuery) End() token.Position { return c.Position }
-func (c *CreateQuery) statementNode()      {}
+// CreateTableQuery represents a CREATE TABLE statement.
+type CreateTableQuery struct {
+	Position                  token.Position       `json:"-"`
+	EndPosition               token.Position       `json:"-"`
+	OrReplace                 bool                 `json:"or_replace,omitempty"`
+	IfNotExists               bool                 `json:"if_not_exists,omitempty"`
+	Temporary                 bool                 `json:"temporary,omitempty"`
+	Database                  string               `json:"database,omitempty"`
+	Table                     string               `json:"table"`
+	OnCluster                 string               `json:"on_cluster,omitempty"`
+	CloneAs                   string               `json:"clone_as,omitempty"` // CLONE AS source_table
+	Columns                   []*ColumnDeclaration `json:"columns,omitempty"`
+	Indexes                   []*IndexDefinition   `json:"indexes,omitempty"`
+	Projections               []*Projection        `json:"projections,omitempty"`
+	Constraints               []*Constraint        `json:"constraints,omitempty"`
+	ColumnsPrimaryKey         []Expression         `json:"columns_primary_key,omitempty"`           // PRIMARY KEY in column list
+	HasEmptyColumnsPrimaryKey bool                 `json:"has_empty_columns_primary_key,omitempty"` // TRUE if PRIMARY KEY () was seen with empty parens
+	Engine                    *EngineClause        `json:"engine,omitempty"`
+	OrderBy                   []Expression         `json:"order_by,omitempty"`
+	OrderByHasModifiers       bool                 `json:"order_by_has_modifiers,omitempty"` // True if ORDER BY has ASC/DESC modifiers
+	PartitionBy               Expression           `json:"partition_by,omitempty"`
+	PrimaryKey                []Expression         `json:"primary_key,omitempty"`
+	SampleBy                  Expression           `json:"sample_by,omitempty"`
+	TTL                       *TTLClause           `json:"ttl,omitempty"`
+	Settings                  []*SettingExpr       `json:"settings,omitempty"`
+	QuerySettings             []*SettingExpr       `json:"query_settings,omitempty"`          // Query-level SETTINGS (second SETTINGS clause)
+	SettingsBeforeComment     bool                 `json:"settings_before_comment,omitempty"` // True if SETTINGS comes before COMMENT
+	AsSelect                  Statement            `json:"as_select,omitempty"`
+	AsTableFunction           Expression           `json:"as_table_function,omitempty"` // AS table_function(...)
+	Comment                   string               `json:"comment,omitempty"`
+	Format                    string               `json:"format,omitempty"` // For FORMAT clause
+}
+
+func (c *CreateTableQuery) Pos() token.Position { return c.Position }
+func (c *CreateTableQuery) End() token.Position { return c.EndPosition }
+func (c *CreateTableQuery) statementNode()      {}
+
+// CreateViewQuery represents a CREATE VIEW statement.
+type CreateViewQuery struct {
+	Position    token.Position       `json:"-"`
+	EndPosition token.Position       `json:"-"`
+	OrReplace   bool                 `json:"or_replace,omitempty"`
+	IfNotExists bool                 `json:"if_not_exists,omitempty"`
+	Database    string               `json:"database,omitempty"`
+	View        string               `json:"view"`
+	OnCluster   string               `json:"on_cluster,omitempty"`
+	Columns     []*ColumnDeclaration `json:"columns,omitempty"`
+	AsSelect    Statement            `json:"as_select,omitempty"`
+	Comment     string               `json:"comment,omitempty"`
+	Format      string               `json:"format,omitempty"` // For FORMAT clause
+}
+
+func (c *CreateViewQuery) Pos() token.Position { return c.Position }
+func (c *CreateViewQuery) End() token.Position { return c.EndPosition }
+func (c *CreateViewQuery) statementNode()      {}
+
+// CreateMaterializedViewQuery represents a CREATE MATERIALIZED VIEW statement.
+// The storage clauses apply to the inner table, if the view has no TO table.
+type CreateMaterializedViewQuery struct {
+	Position              token.Position       `json:"-"`
+	EndPosition           token.Position       `json:"-"`
+	OrReplace             bool                 `json:"or_replace,omitempty"`
+	IfNotExists           bool                 `json:"if_not_exists,omitempty"`
+	Database              string               `json:"database,omitempty"`
+	View                  string               `json:"view"`
+	OnCluster             string               `json:"on_cluster,omitempty"`
+	HasRefresh            bool                 `json:"has_refresh,omitempty"`      // Has REFRESH clause
+	RefreshType           string               `json:"refresh_type,omitempty"`     // AFTER or EVERY
+	RefreshInterval       Expression           `json:"refresh_interval,omitempty"` // Interval value
+	RefreshUnit           string               `json:"refresh_unit,omitempty"`     // SECOND, MINUTE, etc.
+	RefreshAppend         bool                 `json:"refresh_append,omitempty"`   // APPEND TO was specified
+	Empty                 bool                 `json:"empty,omitempty"`            // EMPTY keyword was specified
+	ToDatabase            string               `json:"to_database,omitempty"`      // Target database
+	To                    string               `json:"to,omitempty"`               // Target table
+	Columns               []*ColumnDeclaration `json:"columns,omitempty"`
+	Indexes               []*IndexDefinition   `json:"indexes,omitempty"`
+	Projections           []*Projection        `json:"projections,omitempty"`
+	ColumnsPrimaryKey     []Expression         `json:"columns_primary_key,omitempty"` // PRIMARY KEY in column list
+	Engine                *EngineClause        `json:"engine,omitempty"`
+	OrderBy               []Expression         `json:"order_by,omitempty"`
+	OrderByHasModifiers   bool                 `json:"order_by_has_modifiers,omitempty"` // True if ORDER BY has ASC/DESC modifiers
+	PartitionBy           Expression           `json:"partition_by,omitempty"`
+	PrimaryKey            []Expression         `json:"primary_key,omitempty"`
+	SampleBy              Expression           `json:"sample_by,omitempty"`
+	TTL                   *TTLClause           `json:"ttl,omitempty"`
+	Settings              []*SettingExpr       `json:"settings,omitempty"`
+	QuerySettings         []*SettingExpr       `json:"query_settings,omitempty"`          // Query-level SETTINGS (second SETTINGS clause)
+	SettingsBeforeComment bool                 `json:"settings_before_comment,omitempty"` // True if SETTINGS comes before COMMENT
+	Populate              bool                 `json:"populate,omitempty"`
+	AsSelect              Statement            `json:"as_select,omitempty"`
+	Comment               string               `json:"comment,omitempty"`
+	Format                string               `json:"format,omitempty"` // For FORMAT clause
+}
+
+func (c *CreateMaterializedViewQuery) Pos() token.Position { return c.Position }
+func (c *CreateMaterializedViewQuery) End() token.Position { return c.EndPosition }
+func (c *CreateMaterializedViewQuery) statementNode()      {}
+
+// CreateWindowViewQuery represents a CREATE WINDOW VIEW statement.
+type CreateWindowViewQuery struct {
+	Position              token.Position       `json:"-"`
+	EndPosition           token.Position       `json:"-"`
+	OrReplace             bool                 `json:"or_replace,omitempty"`
+	IfNotExists           bool                 `json:"if_not_exists,omitempty"`
+	Database              string               `json:"database,omitempty"`
+	View                  string               `json:"view"`
+	OnCluster             string               `json:"on_cluster,omitempty"`
+	ToDatabase            string               `json:"to_database,omitempty"` // Target database
+	To                    string               `json:"to,omitempty"`          // Target table
+	Columns               []*ColumnDeclaration `json:"columns,omitempty"`
+	InnerEngine           *EngineClause        `json:"inner_engine,omitempty"` // INNER ENGINE
+	Engine                *EngineClause        `json:"engine,omitempty"`
+	OrderBy               []Expression         `json:"order_by,omitempty"`
+	OrderByHasModifiers   bool                 `json:"order_by_has_modifiers,omitempty"` // True if ORDER BY has ASC/DESC modifiers
+	PartitionBy           Expression           `json:"partition_by,omitempty"`
+	PrimaryKey            []Expression         `json:"primary_key,omitempty"`
+	SampleBy              Expression           `json:"sample_by,omitempty"`
+	TTL                   *TTLClause           `json:"ttl,omitempty"`
+	Settings              []*SettingExpr       `json:"settings,omitempty"`
+	QuerySettings         []*SettingExpr       `json:"query_settings,omitempty"`          // Query-level SETTINGS (second SETTINGS clause)
+	SettingsBeforeComment bool                 `json:"settings_before_comment,omitempty"` // True if SETTINGS comes before COMMENT
+	Populate              bool                 `json:"populate,omitempty"`
+	AsSelect              Statement            `json:"as_select,omitempty"`
+	Comment               string               `json:"comment,omitempty"`
+	Format                string               `json:"format,omitempty"` // For FORMAT clause
+}
+
+func (c *CreateWindowViewQuery) Pos() token.Position { return c.Position }
+func (c *CreateWindowViewQuery) End() token.Position { return c.EndPosition }
+func (c *CreateWindowViewQuery) statementNode()      {}
+
+// CreateDatabaseQuery represents a CREATE DATABASE statement.
+type CreateDatabaseQuery struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfNotExists bool           `json:"if_not_exists,omitempty"`
+	Database    string         `json:"database"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	Engine      *EngineClause  `json:"engine,omitempty"`
+	OrderBy     []Expression   `json:"order_by,omitempty"`
+	Settings    []*SettingExpr `json:"settings,omitempty"`
+	Format      string         `json:"format,omitempty"` // For FORMAT clause
+}
+
+func (c *CreateDatabaseQuery) Pos() token.Position { return c.Position }
+func (c *CreateDatabaseQuery) End() token.Position { return c.EndPosition }
+func (c *CreateDatabaseQuery) statementNode()      {}
+
+// CreateFunctionQuery represents a CREATE FUNCTION name AS lambda statement.
+type CreateFunctionQuery struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	OrReplace   bool           `json:"or_replace,omitempty"`
+	IfNotExists bool           `json:"if_not_exists,omitempty"`
+	Name        string         `json:"name"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	Body        Expression     `json:"body,omitempty"`
+	Format      string         `json:"format,omitempty"` // For FORMAT clause
+}
+
+func (c *CreateFunctionQuery) Pos() token.Position { return c.Position }
+func (c *CreateFunctionQuery) End() token.Position { return c.EndPosition }
+func (c *CreateFunctionQuery) statementNode()      {}
+
+// CreateDictionaryQuery represents a CREATE DICTIONARY or REPLACE DICTIONARY statement.
+type CreateDictionaryQuery struct {
+	Position    token.Position                    `json:"-"`
+	EndPosition token.Position                    `json:"-"`
+	OrReplace   bool                              `json:"or_replace,omitempty"`
+	IfNotExists bool                              `json:"if_not_exists,omitempty"`
+	Database    string                            `json:"database,omitempty"`
+	Dictionary  string                            `json:"dictionary"`
+	OnCluster   string                            `json:"on_cluster,omitempty"`
+	Attributes  []*DictionaryAttributeDeclaration `json:"attributes,omitempty"`
+	Definition  *DictionaryDefinition             `json:"definition,omitempty"`
+	Comment     string                            `json:"comment,omitempty"`
+	Format      string                            `json:"format,omitempty"` // For FORMAT clause
+}
+
+func (c *CreateDictionaryQuery) Pos() token.Position { return c.Position }
+func (c *CreateDictionaryQuery) End() token.Position { return c.EndPosition }
+func (c *CreateDictionaryQuery) statementNode()      {}
 
 // ColumnDeclaration represents a column definition.
 type ColumnDeclaration struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	Name          string         `json:"name"`
 	Type          *DataType      `json:"type"`
 	Nullable      *bool          `json:"nullable,omitempty"`
@@ -344,133 +493,146 @@ type ColumnDeclaration struct {
 }
 
 func (c *ColumnDeclaration) Pos() token.Position { return c.Position }
-func (c *ColumnDeclaration) End() token.Position { return c.Position }
+func (c *ColumnDeclaration) End() token.Position { return c.EndPosition }
 
 // DictionaryAttributeDeclaration represents a dictionary attribute definition.
 type DictionaryAttributeDeclaration struct {
-	Position    token.Position `json:"-"`
-	Name        string         `json:"name"`
-	Type        *DataType      `json:"type"`
-	Default     Expression     `json:"default,omitempty"`
-	Expression  Expression     `json:"expression,omitempty"`   // EXPRESSION clause
-	Hierarchical bool          `json:"hierarchical,omitempty"` // HIERARCHICAL flag
-	Injective   bool           `json:"injective,omitempty"`    // INJECTIVE flag
-	IsObjectID  bool           `json:"is_object_id,omitempty"` // IS_OBJECT_ID flag
+	Position     token.Position `json:"-"`
+	EndPosition  token.Position `json:"-"`
+	Name         string         `json:"name"`
+	Type         *DataType      `json:"type"`
+	Default      Expression     `json:"default,omitempty"`
+	Expression   Expression     `json:"expression,omitempty"`   // EXPRESSION clause
+	Hierarchical bool           `json:"hierarchical,omitempty"` // HIERARCHICAL flag
+	Injective    bool           `json:"injective,omitempty"`    // INJECTIVE flag
+	IsObjectID   bool           `json:"is_object_id,omitempty"` // IS_OBJECT_ID flag
 }
 
 func (d *DictionaryAttributeDeclaration) Pos() token.Position { return d.Position }
-func (d *DictionaryAttributeDeclaration) End() token.Position { return d.Position }
+func (d *DictionaryAttributeDeclaration) End() token.Position { return d.EndPosition }
 
 // DictionaryDefinition represents the definition part of a dictionary (PRIMARY KEY, SOURCE, LIFETIME, LAYOUT).
 type DictionaryDefinition struct {
-	Position   token.Position        `json:"-"`
-	PrimaryKey []Expression          `json:"primary_key,omitempty"`
-	Source     *DictionarySource     `json:"source,omitempty"`
-	Lifetime   *DictionaryLifetime   `json:"lifetime,omitempty"`
-	Layout     *DictionaryLayout     `json:"layout,omitempty"`
-	Range      *DictionaryRange      `json:"range,omitempty"`
-	Settings   []*SettingExpr        `json:"settings,omitempty"`
+	Position    token.Position      `json:"-"`
+	EndPosition token.Position      `json:"-"`
+	PrimaryKey  []Expression        `json:"primary_key,omitempty"`
+	Source      *DictionarySource   `json:"source,omitempty"`
+	Lifetime    *DictionaryLifetime `json:"lifetime,omitempty"`
+	Layout      *DictionaryLayout   `json:"layout,omitempty"`
+	Range       *DictionaryRange    `json:"range,omitempty"`
+	Settings    []*SettingExpr      `json:"settings,omitempty"`
 }
 
 func (d *DictionaryDefinition) Pos() token.Position { return d.Position }
-func (d *DictionaryDefinition) End() token.Position { return d.Position }
+func (d *DictionaryDefinition) End() token.Position { return d.EndPosition }
 
 // DictionarySource represents the SOURCE clause of a dictionary.
 type DictionarySource struct {
-	Position token.Position   `json:"-"`
-	Type     string           `json:"type"`      // e.g., "CLICKHOUSE", "MYSQL", "FILE"
-	Args     []*KeyValuePair  `json:"args,omitempty"`
+	Position    token.Position  `json:"-"`
+	EndPosition token.Position  `json:"-"`
+	Type        string          `json:"type"` // e.g., "CLICKHOUSE", "MYSQL", "FILE"
+	Args        []*KeyValuePair `json:"args,omitempty"`
 }
 
 func (d *DictionarySource) Pos() token.Position { return d.Position }
-func (d *DictionarySource) End() token.Position { return d.Position }
+func (d *DictionarySource) End() token.Position { return d.EndPosition }
 
 // KeyValuePair represents a key-value pair in dictionary source or other contexts.
 type KeyValuePair struct {
-	Position token.Position `json:"-"`
-	Key      string         `json:"key"`
-	Value    Expression     `json:"value"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Key         string         `json:"key"`
+	Value       Expression     `json:"value"`
+	Overridable *bool          `json:"overridable,omitempty"` // NAMED COLLECTION [NOT] OVERRIDABLE, if given
 }
 
 func (k *KeyValuePair) Pos() token.Position { return k.Position }
-func (k *KeyValuePair) End() token.Position { return k.Position }
+func (k *KeyValuePair) End() token.Position { return k.EndPosition }
 
 // DictionaryLifetime represents the LIFETIME clause of a dictionary.
 type DictionaryLifetime struct {
-	Position token.Position `json:"-"`
-	Min      Expression     `json:"min,omitempty"`
-	Max      Expression     `json:"max,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Min         Expression     `json:"min,omitempty"`
+	Max         Expression     `json:"max,omitempty"`
 }
 
 func (d *DictionaryLifetime) Pos() token.Position { return d.Position }
-func (d *DictionaryLifetime) End() token.Position { return d.Position }
+func (d *DictionaryLifetime) End() token.Position { return d.EndPosition }
 
 // DictionaryLayout represents the LAYOUT clause of a dictionary.
 type DictionaryLayout struct {
-	Position token.Position   `json:"-"`
-	Type     string           `json:"type"` // e.g., "FLAT", "HASHED", "COMPLEX_KEY_HASHED"
-	Args     []*KeyValuePair  `json:"args,omitempty"`
+	Position    token.Position  `json:"-"`
+	EndPosition token.Position  `json:"-"`
+	Type        string          `json:"type"` // e.g., "FLAT", "HASHED", "COMPLEX_KEY_HASHED"
+	Args        []*KeyValuePair `json:"args,omitempty"`
 }
 
 func (d *DictionaryLayout) Pos() token.Position { return d.Position }
-func (d *DictionaryLayout) End() token.Position { return d.Position }
+func (d *DictionaryLayout) End() token.Position { return d.EndPosition }
 
 // DictionaryRange represents the RANGE clause of a dictionary.
 type DictionaryRange struct {
-	Position token.Position `json:"-"`
-	Min      Expression     `json:"min,omitempty"`
-	Max      Expression     `json:"max,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Min         Expression     `json:"min,omitempty"`
+	Max         Expression     `json:"max,omitempty"`
 }
 
 func (d *DictionaryRange) Pos() token.Position { return d.Position }
-func (d *DictionaryRange) End() token.Position { return d.Position }
+func (d *DictionaryRange) End() token.Position { return d.EndPosition }
 
 // DataType represents a data type.
 type DataType struct {
 	Position       token.Position `json:"-"`
+	EndPosition    token.Position `json:"-"`
 	Name           string         `json:"name"`
 	Parameters     []Expression   `json:"parameters,omitempty"`
 	HasParentheses bool           `json:"has_parentheses,omitempty"`
 }
 
 func (d *DataType) Pos() token.Position { return d.Position }
-func (d *DataType) End() token.Position { return d.Position }
+func (d *DataType) End() token.Position { return d.EndPosition }
 func (d *DataType) expressionNode()     {}
 
 // ObjectTypeArgument wraps an expression that is an argument to JSON/OBJECT types.
 // This matches ClickHouse's ASTObjectTypeArgument node structure.
 type ObjectTypeArgument struct {
-	Position token.Position `json:"-"`
-	Expr     Expression     `json:"expr"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Expr        Expression     `json:"expr"`
 }
 
 func (o *ObjectTypeArgument) Pos() token.Position { return o.Position }
-func (o *ObjectTypeArgument) End() token.Position { return o.Position }
+func (o *ObjectTypeArgument) End() token.Position { return o.EndPosition }
 func (o *ObjectTypeArgument) expressionNode()     {}
 
 // NameTypePair represents a named type pair, used in Nested types.
 type NameTypePair struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name"`
-	Type     *DataType      `json:"type"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Name        string         `json:"name"`
+	Type        *DataType      `json:"type"`
 }
 
 func (n *NameTypePair) Pos() token.Position { return n.Position }
-func (n *NameTypePair) End() token.Position { return n.Position }
+func (n *NameTypePair) End() token.Position { return n.EndPosition }
 func (n *NameTypePair) expressionNode()     {}
 
 // CodecExpr represents a CODEC expression.
 type CodecExpr struct {
-	Position token.Position  `json:"-"`
-	Codecs   []*FunctionCall `json:"codecs"`
+	Position    token.Position  `json:"-"`
+	EndPosition token.Position  `json:"-"`
+	Codecs      []*FunctionCall `json:"codecs"`
 }
 
 func (c *CodecExpr) Pos() token.Position { return c.Position }
-func (c *CodecExpr) End() token.Position { return c.Position }
+func (c *CodecExpr) End() token.Position { return c.EndPosition }
 
 // IndexDefinition represents an INDEX definition in CREATE TABLE.
 type IndexDefinition struct {
 	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
 	Name        string         `json:"name"`
 	Expression  Expression     `json:"expression"`
 	Type        *FunctionCall  `json:"type"`
@@ -478,98 +640,218 @@ type IndexDefinition struct {
 }
 
 func (i *IndexDefinition) Pos() token.Position { return i.Position }
-func (i *IndexDefinition) End() token.Position { return i.Position }
+func (i *IndexDefinition) End() token.Position { return i.EndPosition }
 func (i *IndexDefinition) expressionNode()     {}
 
 // Constraint represents a table constraint.
 type Constraint struct {
-	Position   token.Position `json:"-"`
-	Name       string         `json:"name,omitempty"`
-	Expression Expression     `json:"expression"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Name        string         `json:"name,omitempty"`
+	Expression  Expression     `json:"expression"`
 }
 
 func (c *Constraint) Pos() token.Position { return c.Position }
-func (c *Constraint) End() token.Position { return c.Position }
+func (c *Constraint) End() token.Position { return c.EndPosition }
 
 // EngineClause represents an ENGINE clause.
 type EngineClause struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	Name          string         `json:"name"`
 	Parameters    []Expression   `json:"parameters,omitempty"`
 	HasParentheses bool          `json:"has_parentheses,omitempty"` // true if called with ()
 }
 
 func (e *EngineClause) Pos() token.Position { return e.Position }
-func (e *EngineClause) End() token.Position { return e.Position }
+func (e *EngineClause) End() token.Position { return e.EndPosition }
 
 // TTLClause represents a TTL clause.
 type TTLClause struct {
 	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
 	Expression  Expression     `json:"expression"`
 	Expressions []Expression   `json:"expressions,omitempty"` // Additional TTL expressions (for multiple TTL elements)
 	Elements    []*TTLElement  `json:"elements,omitempty"`    // TTL elements with WHERE conditions
 }
 
 func (t *TTLClause) Pos() token.Position { return t.Position }
-func (t *TTLClause) End() token.Position { return t.Position }
+func (t *TTLClause) End() token.Position { return t.EndPosition }
 
-// TTLElement represents a single TTL element with optional WHERE condition.
+// TTLElement represents a single TTL element: an expression, what to do
+// once it is reached, and an optional WHERE condition.
 type TTLElement struct {
-	Position token.Position `json:"-"`
-	Expr     Expression     `json:"expr"`
-	Where    Expression     `json:"where,omitempty"` // WHERE condition for DELETE
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Expr        Expression     `json:"expr"`
+	Action      TTLAction      `json:"action,omitempty"`    // TTLDelete if none is given
+	Disk        string         `json:"disk,omitempty"`      // TO DISK name
+	Volume      string         `json:"volume,omitempty"`    // TO VOLUME name
+	IfExists    bool           `json:"if_exists,omitempty"` // TO DISK IF EXISTS or TO VOLUME IF EXISTS
+	Codec       *CodecExpr     `json:"codec,omitempty"`     // RECOMPRESS CODEC(...)
+	Where       Expression     `json:"where,omitempty"`     // WHERE condition for DELETE
+	GroupBy     []Expression   `json:"group_by,omitempty"`  // GROUP BY keys of a rollup
+	Set         []*Assignment  `json:"set,omitempty"`       // SET assignments of a rollup
 }
 
 func (t *TTLElement) Pos() token.Position { return t.Position }
-func (t *TTLElement) End() token.Position { return t.Position }
+func (t *TTLElement) End() token.Position { return t.EndPosition }
 
-// DropQuery represents a DROP statement.
-type DropQuery struct {
-	Position        token.Position     `json:"-"`
-	IfExists        bool               `json:"if_exists,omitempty"`
-	Database        string             `json:"database,omitempty"`
-	Table           string             `json:"table,omitempty"`
-	Tables          []*TableIdentifier `json:"tables,omitempty"` // For DROP TABLE t1, t2, t3
-	View            string             `json:"view,omitempty"`
-	User            string             `json:"user,omitempty"`
-	Function        string             `json:"function,omitempty"` // For DROP FUNCTION
-	Dictionary      string             `json:"-"`                  // For DROP DICTIONARY (format only, not in AST JSON)
-	Role            string             `json:"role,omitempty"`     // For DROP ROLE
-	Quota           string             `json:"quota,omitempty"`    // For DROP QUOTA
-	Policy          string             `json:"policy,omitempty"`   // For DROP POLICY
-	RowPolicy       string             `json:"row_policy,omitempty"` // For DROP ROW POLICY
-	SettingsProfile string             `json:"settings_profile,omitempty"` // For DROP SETTINGS PROFILE
-	Index           string             `json:"index,omitempty"`            // For DROP INDEX
-	Temporary       bool               `json:"temporary,omitempty"`
-	OnCluster       string             `json:"on_cluster,omitempty"`
-	DropDatabase    bool               `json:"drop_database,omitempty"`
-	Sync            bool               `json:"sync,omitempty"`
-	Format          string             `json:"format,omitempty"`   // For FORMAT clause
-	Settings        []*SettingExpr     `json:"settings,omitempty"` // For SETTINGS clause
-}
-
-func (d *DropQuery) Pos() token.Position { return d.Position }
-func (d *DropQuery) End() token.Position { return d.Position }
-func (d *DropQuery) statementNode()      {}
+// TTLAction is what a TTL element does with expired rows or parts.
+type TTLAction string
+
+const (
+	TTLDelete       TTLAction = "DELETE"
+	TTLMoveToDisk   TTLAction = "TO DISK"
+	TTLMoveToVolume TTLAction = "TO VOLUME"
+	TTLRecompress   TTLAction = "RECOMPRESS"
+	TTLGroupBy      TTLAction = "GROUP BY"
+)
+
+// DropTableQuery represents a DROP [TEMPORARY] TABLE statement.
+type DropTableQuery struct {
+	Position    token.Position     `json:"-"`
+	EndPosition token.Position     `json:"-"`
+	Temporary   bool               `json:"temporary,omitempty"`
+	IfExists    bool               `json:"if_exists,omitempty"`
+	IfEmpty     bool               `json:"if_empty,omitempty"`
+	Tables      []*TableIdentifier `json:"tables"` // For DROP TABLE t1, t2, t3
+	OnCluster   string             `json:"on_cluster,omitempty"`
+	Sync        bool               `json:"sync,omitempty"`
+	Format      string             `json:"format,omitempty"`   // For FORMAT clause
+	Settings    []*SettingExpr     `json:"settings,omitempty"` // For SETTINGS clause
+}
+
+func (d *DropTableQuery) Pos() token.Position { return d.Position }
+func (d *DropTableQuery) End() token.Position { return d.EndPosition }
+func (d *DropTableQuery) statementNode()      {}
+
+// DropViewQuery represents a DROP VIEW statement.
+type DropViewQuery struct {
+	Position    token.Position     `json:"-"`
+	EndPosition token.Position     `json:"-"`
+	IfExists    bool               `json:"if_exists,omitempty"`
+	Views       []*TableIdentifier `json:"views"`
+	OnCluster   string             `json:"on_cluster,omitempty"`
+	Sync        bool               `json:"sync,omitempty"`
+	Format      string             `json:"format,omitempty"`   // For FORMAT clause
+	Settings    []*SettingExpr     `json:"settings,omitempty"` // For SETTINGS clause
+}
+
+func (d *DropViewQuery) Pos() token.Position { return d.Position }
+func (d *DropViewQuery) End() token.Position { return d.EndPosition }
+func (d *DropViewQuery) statementNode()      {}
+
+// DropDictionaryQuery represents a DROP DICTIONARY statement.
+type DropDictionaryQuery struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	Database    string         `json:"database,omitempty"`
+	Dictionary  string         `json:"dictionary"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	Sync        bool           `json:"sync,omitempty"`
+	Format      string         `json:"format,omitempty"`   // For FORMAT clause
+	Settings    []*SettingExpr `json:"settings,omitempty"` // For SETTINGS clause
+}
+
+func (d *DropDictionaryQuery) Pos() token.Position { return d.Position }
+func (d *DropDictionaryQuery) End() token.Position { return d.EndPosition }
+func (d *DropDictionaryQuery) statementNode()      {}
+
+// DropDatabaseQuery represents a DROP DATABASE statement.
+type DropDatabaseQuery struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	IfEmpty     bool           `json:"if_empty,omitempty"`
+	Database    string         `json:"database"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	Sync        bool           `json:"sync,omitempty"`
+	Format      string         `json:"format,omitempty"`   // For FORMAT clause
+	Settings    []*SettingExpr `json:"settings,omitempty"` // For SETTINGS clause
+}
+
+func (d *DropDatabaseQuery) Pos() token.Position { return d.Position }
+func (d *DropDatabaseQuery) End() token.Position { return d.EndPosition }
+func (d *DropDatabaseQuery) statementNode()      {}
+
+// DropFunctionQuery represents a DROP FUNCTION statement.
+type DropFunctionQuery struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	Name        string         `json:"name"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+}
+
+func (d *DropFunctionQuery) Pos() token.Position { return d.Position }
+func (d *DropFunctionQuery) End() token.Position { return d.EndPosition }
+func (d *DropFunctionQuery) statementNode()      {}
+
+// DropIndexQuery represents a DROP INDEX name ON table statement.
+type DropIndexQuery struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	Index       string         `json:"index"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+}
+
+func (d *DropIndexQuery) Pos() token.Position { return d.Position }
+func (d *DropIndexQuery) End() token.Position { return d.EndPosition }
+func (d *DropIndexQuery) statementNode()      {}
+
+// DropUserQuery represents a DROP USER statement.
+type DropUserQuery struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	Names       []string       `json:"names,omitempty"` // As written, including any @host
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	Storage     string         `json:"storage,omitempty"` // FROM access_storage
+}
+
+func (d *DropUserQuery) Pos() token.Position { return d.Position }
+func (d *DropUserQuery) End() token.Position { return d.EndPosition }
+func (d *DropUserQuery) statementNode()      {}
+
+// DropQuotaQuery represents a DROP QUOTA statement.
+type DropQuotaQuery struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	Names       []string       `json:"names,omitempty"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	Storage     string         `json:"storage,omitempty"` // FROM access_storage
+}
+
+func (d *DropQuotaQuery) Pos() token.Position { return d.Position }
+func (d *DropQuotaQuery) End() token.Position { return d.EndPosition }
+func (d *DropQuotaQuery) statementNode()      {}
 
 // UndropQuery represents an UNDROP TABLE statement.
 type UndropQuery struct {
-	Position  token.Position `json:"-"`
-	Database  string         `json:"database,omitempty"`
-	Table     string         `json:"table"`
-	OnCluster string         `json:"on_cluster,omitempty"`
-	UUID      string         `json:"uuid,omitempty"`
-	Format    string         `json:"format,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	UUID        string         `json:"uuid,omitempty"`
+	Format      string         `json:"format,omitempty"`
 }
 
 func (u *UndropQuery) Pos() token.Position { return u.Position }
-func (u *UndropQuery) End() token.Position { return u.Position }
+func (u *UndropQuery) End() token.Position { return u.EndPosition }
 func (u *UndropQuery) statementNode()      {}
 
 // UpdateQuery represents a standalone UPDATE statement.
 // In ClickHouse, UPDATE is syntactic sugar for ALTER TABLE ... UPDATE
 type UpdateQuery struct {
 	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
 	Database    string         `json:"database,omitempty"`
 	Table       string         `json:"table"`
 	Assignments []*Assignment  `json:"assignments"`
@@ -577,95 +859,116 @@ type UpdateQuery struct {
 }
 
 func (u *UpdateQuery) Pos() token.Position { return u.Position }
-func (u *UpdateQuery) End() token.Position { return u.Position }
+func (u *UpdateQuery) End() token.Position { return u.EndPosition }
 func (u *UpdateQuery) statementNode()      {}
 
 // AlterQuery represents an ALTER statement.
 type AlterQuery struct {
-	Position  token.Position  `json:"-"`
-	Database  string          `json:"database,omitempty"`
-	Table     string          `json:"table"`
-	Commands  []*AlterCommand `json:"commands"`
-	OnCluster string          `json:"on_cluster,omitempty"`
-	Settings  []*SettingExpr  `json:"settings,omitempty"`
-	Format    string          `json:"format,omitempty"` // For FORMAT clause
+	Position    token.Position  `json:"-"`
+	EndPosition token.Position  `json:"-"`
+	IsDatabase  bool            `json:"is_database,omitempty"` // ALTER DATABASE, with no Table
+	Database    string          `json:"database,omitempty"`
+	Table       string          `json:"table"`
+	Commands    []*AlterCommand `json:"commands"`
+	OnCluster   string          `json:"on_cluster,omitempty"`
+	Settings    []*SettingExpr  `json:"settings,omitempty"`
+	Format      string          `json:"format,omitempty"` // For FORMAT clause
 }
 
 func (a *AlterQuery) Pos() token.Position { return a.Position }
-func (a *AlterQuery) End() token.Position { return a.Position }
+func (a *AlterQuery) End() token.Position { return a.EndPosition }
 func (a *AlterQuery) statementNode()      {}
 
 // AlterCommand represents an ALTER command.
 type AlterCommand struct {
-	Position       token.Position       `json:"-"`
-	Type           AlterCommandType     `json:"type"`
-	Column         *ColumnDeclaration   `json:"column,omitempty"`
-	ColumnName     string               `json:"column_name,omitempty"`
-	AfterColumn    string               `json:"after_column,omitempty"`
-	NewName        string               `json:"new_name,omitempty"`
-	IfNotExists    bool                 `json:"if_not_exists,omitempty"`
-	IfExists       bool                 `json:"if_exists,omitempty"`
-	Index          string               `json:"index,omitempty"`
-	IndexExpr      Expression           `json:"index_expr,omitempty"`
-	IndexType      string               `json:"index_type,omitempty"`
-	IndexDef       *IndexDefinition     `json:"index_def,omitempty"`       // For ADD INDEX with full definition
-	Granularity    int                  `json:"granularity,omitempty"`
-	AfterIndex     string               `json:"after_index,omitempty"`     // For ADD INDEX ... AFTER name
-	Constraint     *Constraint          `json:"constraint,omitempty"`
-	ConstraintName string               `json:"constraint_name,omitempty"`
-	Partition      Expression           `json:"partition,omitempty"`
-	PartitionIsID  bool                 `json:"partition_is_id,omitempty"` // True when using PARTITION ID 'value' syntax
-	IsPart         bool                 `json:"-"`                         // True for PART (not PARTITION) - output directly without Partition wrapper
-	FromTable      string               `json:"from_table,omitempty"`
-	ToDatabase     string               `json:"to_database,omitempty"` // For MOVE PARTITION TO TABLE
-	ToTable        string               `json:"to_table,omitempty"`    // For MOVE PARTITION TO TABLE
-	FromPath       string               `json:"from_path,omitempty"`   // For FETCH PARTITION FROM
-	TTL            *TTLClause           `json:"ttl,omitempty"`
-	Settings       []*SettingExpr       `json:"settings,omitempty"`
-	Where          Expression           `json:"where,omitempty"`       // For DELETE WHERE
-	Assignments    []*Assignment        `json:"assignments,omitempty"` // For UPDATE
-	Projection     *Projection          `json:"projection,omitempty"`  // For ADD PROJECTION
-	ProjectionName string               `json:"projection_name,omitempty"` // For DROP/MATERIALIZE/CLEAR PROJECTION
-	StatisticsColumns []string          `json:"statistics_columns,omitempty"` // For ADD/DROP/CLEAR/MATERIALIZE STATISTICS
-	StatisticsTypes   []*FunctionCall   `json:"statistics_types,omitempty"`   // For ADD/MODIFY STATISTICS TYPE
-	Comment           string            `json:"comment,omitempty"`            // For COMMENT COLUMN
-	OrderByExpr       []Expression      `json:"order_by_expr,omitempty"`      // For MODIFY ORDER BY
-	SampleByExpr      Expression        `json:"sample_by_expr,omitempty"`     // For MODIFY SAMPLE BY
-	ResetSettings     []string          `json:"reset_settings,omitempty"`     // For MODIFY COLUMN ... RESET SETTING
-	Query             Statement         `json:"query,omitempty"`              // For MODIFY QUERY
+	Position          token.Position     `json:"-"`
+	EndPosition       token.Position     `json:"-"`
+	Type              AlterCommandType   `json:"type"`
+	Column            *ColumnDeclaration `json:"column,omitempty"`
+	ColumnName        string             `json:"column_name,omitempty"`
+	AfterColumn       string             `json:"after_column,omitempty"`
+	NewName           string             `json:"new_name,omitempty"`
+	IfNotExists       bool               `json:"if_not_exists,omitempty"`
+	IfExists          bool               `json:"if_exists,omitempty"`
+	Index             string             `json:"index,omitempty"`
+	IndexExpr         Expression         `json:"index_expr,omitempty"`
+	IndexType         string             `json:"index_type,omitempty"`
+	IndexDef          *IndexDefinition   `json:"index_def,omitempty"` // For ADD INDEX with full definition
+	Granularity       int                `json:"granularity,omitempty"`
+	AfterIndex        string             `json:"after_index,omitempty"` // For ADD INDEX ... AFTER name
+	Constraint        *Constraint        `json:"constraint,omitempty"`
+	ConstraintName    string             `json:"constraint_name,omitempty"`
+	Partition         Expression         `json:"partition,omitempty"`
+	PartitionIsID     bool               `json:"partition_is_id,omitempty"` // True when using PARTITION ID 'value' syntax
+	IsPart            bool               `json:"is_part,omitempty"`         // True for PART (not PARTITION) - output directly without Partition wrapper
+	FromDatabase      string             `json:"from_database,omitempty"`   // For ATTACH/REPLACE PARTITION ... FROM db.table
+	FromTable         string             `json:"from_table,omitempty"`
+	ToDatabase        string             `json:"to_database,omitempty"` // For MOVE PARTITION TO TABLE
+	ToTable           string             `json:"to_table,omitempty"`    // For MOVE PARTITION TO TABLE
+	ToDisk            string             `json:"to_disk,omitempty"`     // For MOVE PARTITION TO DISK
+	ToVolume          string             `json:"to_volume,omitempty"`   // For MOVE PARTITION TO VOLUME
+	FromPath          string             `json:"from_path,omitempty"`   // For FETCH PARTITION FROM
+	WithName          string             `json:"with_name,omitempty"`   // For FREEZE/UNFREEZE ... WITH NAME
+	TTL               *TTLClause         `json:"ttl,omitempty"`
+	Settings          []*SettingExpr     `json:"settings,omitempty"`
+	Where             Expression         `json:"where,omitempty"`              // For DELETE WHERE
+	Assignments       []*Assignment      `json:"assignments,omitempty"`        // For UPDATE
+	Projection        *Projection        `json:"projection,omitempty"`         // For ADD PROJECTION
+	ProjectionName    string             `json:"projection_name,omitempty"`    // For DROP/MATERIALIZE/CLEAR PROJECTION
+	StatisticsColumns []string           `json:"statistics_columns,omitempty"` // For ADD/DROP/CLEAR/MATERIALIZE STATISTICS
+	StatisticsTypes   []*FunctionCall    `json:"statistics_types,omitempty"`   // For ADD/MODIFY STATISTICS TYPE
+	Comment           string             `json:"comment,omitempty"`            // For COMMENT COLUMN
+	OrderByExpr       []Expression       `json:"order_by_expr,omitempty"`      // For MODIFY ORDER BY
+	SampleByExpr      Expression         `json:"sample_by_expr,omitempty"`     // For MODIFY SAMPLE BY
+	ResetSettings     []string           `json:"reset_settings,omitempty"`     // For MODIFY COLUMN ... RESET SETTING
+	RemoveProperty    string             `json:"remove_property,omitempty"`    // For MODIFY COLUMN ... REMOVE, e.g. COMMENT or CODEC
+	Query             Statement          `json:"query,omitempty"`              // For MODIFY QUERY
+	RefreshType       string             `json:"refresh_type,omitempty"`       // For MODIFY REFRESH: AFTER or EVERY
+	RefreshInterval   Expression         `json:"refresh_interval,omitempty"`   // For MODIFY REFRESH
+	RefreshUnit       string             `json:"refresh_unit,omitempty"`       // For MODIFY REFRESH: SECOND, MINUTE, etc.
+	RefreshOffset     *IntervalExpr      `json:"refresh_offset,omitempty"`     // For MODIFY REFRESH ... OFFSET
+	RefreshRandomize  *IntervalExpr      `json:"refresh_randomize,omitempty"`  // For MODIFY REFRESH ... RANDOMIZE FOR
+	RefreshDependsOn  []string           `json:"refresh_depends_on,omitempty"` // For MODIFY REFRESH ... DEPENDS ON, as written with any database
+	RefreshAppend     bool               `json:"refresh_append,omitempty"`     // For MODIFY REFRESH ... APPEND
 }
 
 // Projection represents a projection definition.
 type Projection struct {
-	Position token.Position            `json:"-"`
-	Name     string                    `json:"name"`
-	Select   *ProjectionSelectQuery    `json:"select"`
+	Position    token.Position         `json:"-"`
+	EndPosition token.Position         `json:"-"`
+	Name        string                 `json:"name"`
+	Select      *ProjectionSelectQuery `json:"select"`
 }
 
 func (p *Projection) Pos() token.Position { return p.Position }
-func (p *Projection) End() token.Position { return p.Position }
+func (p *Projection) End() token.Position { return p.EndPosition }
 
 // ProjectionSelectQuery represents the SELECT part of a projection.
 type ProjectionSelectQuery struct {
-	Position token.Position   `json:"-"`
-	With     []Expression     `json:"with,omitempty"` // WITH clause expressions
-	Columns  []Expression     `json:"columns"`
-	GroupBy  []Expression     `json:"group_by,omitempty"`
-	OrderBy  []Expression     `json:"order_by,omitempty"` // ORDER BY columns
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	With        []Expression   `json:"with,omitempty"` // WITH clause expressions
+	Columns     []Expression   `json:"columns"`
+	GroupBy     []Expression   `json:"group_by,omitempty"`
+	OrderBy     []Expression   `json:"order_by,omitempty"` // ORDER BY columns
 }
 
+func (p *ProjectionSelectQuery) Pos() token.Position { return p.Position }
+func (p *ProjectionSelectQuery) End() token.Position { return p.EndPosition }
+
 // Assignment represents a column assignment in UPDATE.
 type Assignment struct {
-	Position token.Position `json:"-"`
-	Column   string         `json:"column"`
-	Value    Expression     `json:"value"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Column      string         `json:"column"`
+	Value       Expression     `json:"value"`
 }
 
 func (a *Assignment) Pos() token.Position { return a.Position }
-func (a *Assignment) End() token.Position { return a.Position }
+func (a *Assignment) End() token.Position { return a.EndPosition }
 
 func (a *AlterCommand) Pos() token.Position { return a.Position }
-func (a *AlterCommand) End() token.Position { return a.Position }
+func (a *AlterCommand) End() token.Position { return a.EndPosition }
 
 // AlterCommandType represents the type of ALTER command.
 type AlterCommandType string
@@ -698,6 +1001,8 @@ const (
 	AlterMovePartition      AlterCommandType = "MOVE_PARTITION"
 	AlterFreezePartition    AlterCommandType = "FREEZE_PARTITION"
 	AlterFreeze             AlterCommandType = "FREEZE"
+	AlterUnfreezePartition  AlterCommandType = "UNFREEZE_PARTITION"
+	AlterUnfreeze           AlterCommandType = "UNFREEZE"
 	AlterApplyPatches       AlterCommandType = "APPLY_PATCHES"
 	AlterDeleteWhere        AlterCommandType = "DELETE_WHERE"
 	AlterUpdate             AlterCommandType = "UPDATE"
@@ -714,6 +1019,9 @@ const (
 	AlterModifyOrderBy         AlterCommandType = "MODIFY_ORDER_BY"
 	AlterModifySampleBy        AlterCommandType = "MODIFY_SAMPLE_BY"
 	AlterModifyQuery           AlterCommandType = "MODIFY_QUERY"
+	AlterModifyRefresh         AlterCommandType = "MODIFY_REFRESH"
+	AlterModifyDatabaseSetting AlterCommandType = "MODIFY_DATABASE_SETTING"
+	AlterModifyDatabaseComment AlterCommandType = "MODIFY_DATABASE_COMMENT"
 	AlterRemoveSampleBy        AlterCommandType = "REMOVE_SAMPLE_BY"
 	AlterApplyDeletedMask      AlterCommandType = "APPLY_DELETED_MASK"
 )
@@ -721,6 +1029,7 @@ const (
 // TruncateQuery represents a TRUNCATE statement.
 type TruncateQuery struct {
 	Position         token.Position `json:"-"`
+	EndPosition      token.Position `json:"-"`
 	Temporary        bool           `json:"temporary,omitempty"`
 	IfExists         bool           `json:"if_exists,omitempty"`
 	TruncateDatabase bool           `json:"truncate_database,omitempty"` // True for TRUNCATE DATABASE
@@ -731,49 +1040,53 @@ type TruncateQuery struct {
 }
 
 func (t *TruncateQuery) Pos() token.Position { return t.Position }
-func (t *TruncateQuery) End() token.Position { return t.Position }
+func (t *TruncateQuery) End() token.Position { return t.EndPosition }
 func (t *TruncateQuery) statementNode()      {}
 
 // DeleteQuery represents a lightweight DELETE statement.
 type DeleteQuery struct {
-	Position  token.Position `json:"-"`
-	Database  string         `json:"database,omitempty"`
-	Table     string         `json:"table"`
-	OnCluster string         `json:"on_cluster,omitempty"` // ON CLUSTER clause
-	Partition Expression     `json:"partition,omitempty"`  // IN PARTITION clause
-	Where     Expression     `json:"where,omitempty"`
-	Settings  []*SettingExpr `json:"settings,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table"`
+	OnCluster   string         `json:"on_cluster,omitempty"` // ON CLUSTER clause
+	Partition   Expression     `json:"partition,omitempty"`  // IN PARTITION clause
+	Where       Expression     `json:"where,omitempty"`
+	Settings    []*SettingExpr `json:"settings,omitempty"`
 }
 
 func (d *DeleteQuery) Pos() token.Position { return d.Position }
-func (d *DeleteQuery) End() token.Position { return d.Position }
+func (d *DeleteQuery) End() token.Position { return d.EndPosition }
 func (d *DeleteQuery) statementNode()      {}
 
 // UseQuery represents a USE statement.
 type UseQuery struct {
-	Position token.Position `json:"-"`
-	Database string         `json:"database"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database    string         `json:"database"`
 }
 
 func (u *UseQuery) Pos() token.Position { return u.Position }
-func (u *UseQuery) End() token.Position { return u.Position }
+func (u *UseQuery) End() token.Position { return u.EndPosition }
 func (u *UseQuery) statementNode()      {}
 
 // DetachQuery represents a DETACH statement.
 type DetachQuery struct {
-	Position   token.Position `json:"-"`
-	Database   string         `json:"database,omitempty"`
-	Table      string         `json:"table,omitempty"`
-	Dictionary string         `json:"dictionary,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table,omitempty"`
+	Dictionary  string         `json:"dictionary,omitempty"`
 }
 
 func (d *DetachQuery) Pos() token.Position { return d.Position }
-func (d *DetachQuery) End() token.Position { return d.Position }
+func (d *DetachQuery) End() token.Position { return d.EndPosition }
 func (d *DetachQuery) statementNode()      {}
 
 // AttachQuery represents an ATTACH statement.
 type AttachQuery struct {
 	Position           token.Position       `json:"-"`
+	EndPosition        token.Position       `json:"-"`
 	IfNotExists        bool                 `json:"if_not_exists,omitempty"`
 	Database           string               `json:"database,omitempty"`
 	Table              string               `json:"table,omitempty"`
@@ -795,46 +1108,49 @@ type AttachQuery struct {
 }
 
 func (a *AttachQuery) Pos() token.Position { return a.Position }
-func (a *AttachQuery) End() token.Position { return a.Position }
+func (a *AttachQuery) End() token.Position { return a.EndPosition }
 func (a *AttachQuery) statementNode()      {}
 
 // BackupQuery represents a BACKUP statement.
 type BackupQuery struct {
-	Position   token.Position `json:"-"`
-	Database   string         `json:"database,omitempty"`
-	Table      string         `json:"table,omitempty"`
-	Dictionary string         `json:"dictionary,omitempty"`
-	All        bool           `json:"all,omitempty"` // BACKUP ALL
-	Temporary  bool           `json:"temporary,omitempty"`
-	Target     *FunctionCall  `json:"target,omitempty"` // Disk('path') or Null
-	Settings   []*SettingExpr `json:"settings,omitempty"`
-	Format     string         `json:"format,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table,omitempty"`
+	Dictionary  string         `json:"dictionary,omitempty"`
+	All         bool           `json:"all,omitempty"` // BACKUP ALL
+	Temporary   bool           `json:"temporary,omitempty"`
+	Target      *FunctionCall  `json:"target,omitempty"` // Disk('path') or Null
+	Settings    []*SettingExpr `json:"settings,omitempty"`
+	Format      string         `json:"format,omitempty"`
 }
 
 func (b *BackupQuery) Pos() token.Position { return b.Position }
-func (b *BackupQuery) End() token.Position { return b.Position }
+func (b *BackupQuery) End() token.Position { return b.EndPosition }
 func (b *BackupQuery) statementNode()      {}
 
 // RestoreQuery represents a RESTORE statement.
 type RestoreQuery struct {
-	Position   token.Position `json:"-"`
-	Database   string         `json:"database,omitempty"`
-	Table      string         `json:"table,omitempty"`
-	Dictionary string         `json:"dictionary,omitempty"`
-	All        bool           `json:"all,omitempty"` // RESTORE ALL
-	Temporary  bool           `json:"temporary,omitempty"`
-	Source     *FunctionCall  `json:"source,omitempty"` // Disk('path') or Null
-	Settings   []*SettingExpr `json:"settings,omitempty"`
-	Format     string         `json:"format,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table,omitempty"`
+	Dictionary  string         `json:"dictionary,omitempty"`
+	All         bool           `json:"all,omitempty"` // RESTORE ALL
+	Temporary   bool           `json:"temporary,omitempty"`
+	Source      *FunctionCall  `json:"source,omitempty"` // Disk('path') or Null
+	Settings    []*SettingExpr `json:"settings,omitempty"`
+	Format      string         `json:"format,omitempty"`
 }
 
 func (r *RestoreQuery) Pos() token.Position { return r.Position }
-func (r *RestoreQuery) End() token.Position { return r.Position }
+func (r *RestoreQuery) End() token.Position { return r.EndPosition }
 func (r *RestoreQuery) statementNode()      {}
 
 // DescribeQuery represents a DESCRIBE statement.
 type DescribeQuery struct {
 	Position      token.Position   `json:"-"`
+	EndPosition   token.Position   `json:"-"`
 	Database      string           `json:"database,omitempty"`
 	Table         string           `json:"table,omitempty"`
 	TableFunction *FunctionCall    `json:"table_function,omitempty"`
@@ -844,12 +1160,13 @@ type DescribeQuery struct {
 }
 
 func (d *DescribeQuery) Pos() token.Position { return d.Position }
-func (d *DescribeQuery) End() token.Position { return d.Position }
+func (d *DescribeQuery) End() token.Position { return d.EndPosition }
 func (d *DescribeQuery) statementNode()      {}
 
 // ShowQuery represents a SHOW statement.
 type ShowQuery struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	ShowType      ShowType       `json:"show_type"`
 	Temporary     bool           `json:"temporary,omitempty"`
 	Database      string         `json:"database,omitempty"`
@@ -863,7 +1180,7 @@ type ShowQuery struct {
 }
 
 func (s *ShowQuery) Pos() token.Position { return s.Position }
-func (s *ShowQuery) End() token.Position { return s.Position }
+func (s *ShowQuery) End() token.Position { return s.EndPosition }
 func (s *ShowQuery) statementNode()      {}
 
 // ShowType represents the type of SHOW statement.
@@ -894,6 +1211,7 @@ const (
 // ExplainQuery represents an EXPLAIN statement.
 type ExplainQuery struct {
 	Position       token.Position `json:"-"`
+	EndPosition    token.Position `json:"-"`
 	ExplainType    ExplainType    `json:"explain_type"`
 	Statement      Statement      `json:"statement"`
 	HasSettings    bool           `json:"has_settings,omitempty"`
@@ -902,7 +1220,7 @@ type ExplainQuery struct {
 }
 
 func (e *ExplainQuery) Pos() token.Position { return e.Position }
-func (e *ExplainQuery) End() token.Position { return e.Position }
+func (e *ExplainQuery) End() token.Position { return e.EndPosition }
 func (e *ExplainQuery) statementNode()      {}
 
 // ExplainType represents the type of EXPLAIN.
@@ -920,17 +1238,19 @@ const (
 
 // SetQuery represents a SET statement.
 type SetQuery struct {
-	Position token.Position `json:"-"`
-	Settings []*SettingExpr `json:"settings"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Settings    []*SettingExpr `json:"settings"`
 }
 
 func (s *SetQuery) Pos() token.Position { return s.Position }
-func (s *SetQuery) End() token.Position { return s.Position }
+func (s *SetQuery) End() token.Position { return s.EndPosition }
 func (s *SetQuery) statementNode()      {}
 
 // OptimizeQuery represents an OPTIMIZE statement.
 type OptimizeQuery struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	Database      string         `json:"database,omitempty"`
 	Table         string         `json:"table"`
 	Partition     Expression     `json:"partition,omitempty"`
@@ -943,61 +1263,165 @@ type OptimizeQuery struct {
 }
 
 func (o *OptimizeQuery) Pos() token.Position { return o.Position }
-func (o *OptimizeQuery) End() token.Position { return o.Position }
+func (o *OptimizeQuery) End() token.Position { return o.EndPosition }
 func (o *OptimizeQuery) statementNode()      {}
 
 // CheckQuery represents a CHECK TABLE statement.
 type CheckQuery struct {
-	Position  token.Position `json:"-"`
-	Database  string         `json:"database,omitempty"`
-	Table     string         `json:"table"`
-	Partition Expression     `json:"partition,omitempty"`
-	Part      Expression     `json:"part,omitempty"`
-	Format    string         `json:"format,omitempty"`
-	Settings  []*SettingExpr `json:"settings,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table"`
+	Partition   Expression     `json:"partition,omitempty"`
+	Part        Expression     `json:"part,omitempty"`
+	Format      string         `json:"format,omitempty"`
+	Settings    []*SettingExpr `json:"settings,omitempty"`
 }
 
 func (c *CheckQuery) Pos() token.Position { return c.Position }
-func (c *CheckQuery) End() token.Position { return c.Position }
+func (c *CheckQuery) End() token.Position { return c.EndPosition }
 func (c *CheckQuery) statementNode()      {}
 
 // SystemQuery represents a SYSTEM statement.
 type SystemQuery struct {
-	Position             token.Position `json:"-"`
-	Command              string         `json:"command"`
-	Database             string         `json:"database,omitempty"`
-	Table                string         `json:"table,omitempty"`
-	OnCluster            string         `json:"on_cluster,omitempty"`
-	DuplicateTableOutput bool           `json:"duplicate_table_output,omitempty"` // True for commands that need database/table output twice
-	Settings             []*SettingExpr `json:"settings,omitempty"`
+	Position       token.Position    `json:"-"`
+	EndPosition    token.Position    `json:"-"`
+	Kind           SystemCommandKind `json:"kind"`
+	Command        string            `json:"command,omitempty"` // Words of a command of kind SystemOther, as written
+	OnCluster      string            `json:"on_cluster,omitempty"`
+	Database       string            `json:"database,omitempty"`         // Database of Table, or the database of SYNC DATABASE REPLICA and DROP REPLICA ... FROM DATABASE
+	Table          string            `json:"table,omitempty"`            // Target table, view or dictionary
+	Name           string            `json:"name,omitempty"`             // Function or model of RELOAD FUNCTION/MODEL, protocol of START/STOP LISTEN, or backup of UNFREEZE
+	Cache          string            `json:"cache,omitempty"`            // Cache of DROP/PREWARM ... CACHE, such as MARK, QUERY or FILESYSTEM
+	CacheName      string            `json:"cache_name,omitempty"`       // DROP FILESYSTEM CACHE 'name'
+	CacheTag       string            `json:"cache_tag,omitempty"`        // DROP QUERY CACHE TAG 'tag'
+	SchemaCacheFor string            `json:"schema_cache_for,omitempty"` // DROP [FORMAT] SCHEMA CACHE FOR name
+	Failpoint      string            `json:"failpoint,omitempty"`
+	Logs           []string          `json:"logs,omitempty"` // FLUSH LOGS names
+	SyncMode       SyncReplicaMode   `json:"sync_mode,omitempty"`
+	SyncFrom       []string          `json:"sync_from,omitempty"`    // SYNC REPLICA ... LIGHTWEIGHT FROM replicas
+	Replica        string            `json:"replica,omitempty"`      // DROP [DATABASE] REPLICA name
+	Shard          string            `json:"shard,omitempty"`        // DROP DATABASE REPLICA ... FROM SHARD
+	ReplicaPath    string            `json:"replica_path,omitempty"` // DROP REPLICA ... FROM ZKPATH
+	Volume         string            `json:"volume,omitempty"`       // START/STOP MERGES ON VOLUME, as policy.volume
+	Duration       Expression        `json:"duration,omitempty"`     // SUSPEND FOR duration SECOND
+	Settings       []*SettingExpr    `json:"settings,omitempty"`
 }
 
 func (s *SystemQuery) Pos() token.Position { return s.Position }
-func (s *SystemQuery) End() token.Position { return s.Position }
+func (s *SystemQuery) End() token.Position { return s.EndPosition }
 func (s *SystemQuery) statementNode()      {}
 
+// SystemCommandKind is the command of a SYSTEM statement, written as in
+// the statement without its arguments. The caches of DROP ... CACHE and
+// PREWARM ... CACHE are in SystemQuery.Cache.
+type SystemCommandKind string
+
+const (
+	SystemShutdown                   SystemCommandKind = "SHUTDOWN"
+	SystemKill                       SystemCommandKind = "KILL"
+	SystemSuspend                    SystemCommandKind = "SUSPEND"
+	SystemDropCache                  SystemCommandKind = "DROP CACHE"
+	SystemPrewarmCache               SystemCommandKind = "PREWARM CACHE"
+	SystemReloadDictionary           SystemCommandKind = "RELOAD DICTIONARY"
+	SystemReloadDictionaries         SystemCommandKind = "RELOAD DICTIONARIES"
+	SystemReloadEmbeddedDictionaries SystemCommandKind = "RELOAD EMBEDDED DICTIONARIES"
+	SystemReloadModel                SystemCommandKind = "RELOAD MODEL"
+	SystemReloadModels               SystemCommandKind = "RELOAD MODELS"
+	SystemReloadFunction             SystemCommandKind = "RELOAD FUNCTION"
+	SystemReloadFunctions            SystemCommandKind = "RELOAD FUNCTIONS"
+	SystemReloadConfig               SystemCommandKind = "RELOAD CONFIG"
+	SystemReloadUsers                SystemCommandKind = "RELOAD USERS"
+	SystemReloadAsynchronousMetrics  SystemCommandKind = "RELOAD ASYNCHRONOUS METRICS"
+	SystemRestartReplica             SystemCommandKind = "RESTART REPLICA"
+	SystemRestartReplicas            SystemCommandKind = "RESTART REPLICAS"
+	SystemRestoreReplica             SystemCommandKind = "RESTORE REPLICA"
+	SystemDropReplica                SystemCommandKind = "DROP REPLICA"
+	SystemDropDatabaseReplica        SystemCommandKind = "DROP DATABASE REPLICA"
+	SystemSyncReplica                SystemCommandKind = "SYNC REPLICA"
+	SystemSyncDatabaseReplica        SystemCommandKind = "SYNC DATABASE REPLICA"
+	SystemSyncFileCache              SystemCommandKind = "SYNC FILE CACHE"
+	SystemSyncTransactionLog         SystemCommandKind = "SYNC TRANSACTION LOG"
+	SystemFlushLogs                  SystemCommandKind = "FLUSH LOGS"
+	SystemFlushDistributed           SystemCommandKind = "FLUSH DISTRIBUTED"
+	SystemFlushAsyncInsertQueue      SystemCommandKind = "FLUSH ASYNC INSERT QUEUE"
+	SystemStartMerges                SystemCommandKind = "START MERGES"
+	SystemStopMerges                 SystemCommandKind = "STOP MERGES"
+	SystemStartTTLMerges             SystemCommandKind = "START TTL MERGES"
+	SystemStopTTLMerges              SystemCommandKind = "STOP TTL MERGES"
+	SystemStartMoves                 SystemCommandKind = "START MOVES"
+	SystemStopMoves                  SystemCommandKind = "STOP MOVES"
+	SystemStartFetches               SystemCommandKind = "START FETCHES"
+	SystemStopFetches                SystemCommandKind = "STOP FETCHES"
+	SystemStartReplicatedSends       SystemCommandKind = "START REPLICATED SENDS"
+	SystemStopReplicatedSends        SystemCommandKind = "STOP REPLICATED SENDS"
+	SystemStartDistributedSends      SystemCommandKind = "START DISTRIBUTED SENDS"
+	SystemStopDistributedSends       SystemCommandKind = "STOP DISTRIBUTED SENDS"
+	SystemStartReplicationQueues     SystemCommandKind = "START REPLICATION QUEUES"
+	SystemStopReplicationQueues      SystemCommandKind = "STOP REPLICATION QUEUES"
+	SystemStartPullingReplicationLog SystemCommandKind = "START PULLING REPLICATION LOG"
+	SystemStopPullingReplicationLog  SystemCommandKind = "STOP PULLING REPLICATION LOG"
+	SystemStartCleanup               SystemCommandKind = "START CLEANUP"
+	SystemStopCleanup                SystemCommandKind = "STOP CLEANUP"
+	SystemStartReplicatedDDLQueries  SystemCommandKind = "START REPLICATED DDL QUERIES"
+	SystemStopReplicatedDDLQueries   SystemCommandKind = "STOP REPLICATED DDL QUERIES"
+	SystemStartListen                SystemCommandKind = "START LISTEN"
+	SystemStopListen                 SystemCommandKind = "STOP LISTEN"
+	SystemStartView                  SystemCommandKind = "START VIEW"
+	SystemStopView                   SystemCommandKind = "STOP VIEW"
+	SystemStartViews                 SystemCommandKind = "START VIEWS"
+	SystemStopViews                  SystemCommandKind = "STOP VIEWS"
+	SystemRefreshView                SystemCommandKind = "REFRESH VIEW"
+	SystemCancelView                 SystemCommandKind = "CANCEL VIEW"
+	SystemWaitView                   SystemCommandKind = "WAIT VIEW"
+	SystemEnableFailpoint            SystemCommandKind = "ENABLE FAILPOINT"
+	SystemDisableFailpoint           SystemCommandKind = "DISABLE FAILPOINT"
+	SystemWaitLoadingParts           SystemCommandKind = "WAIT LOADING PARTS"
+	SystemLoadPrimaryKey             SystemCommandKind = "LOAD PRIMARY KEY"
+	SystemUnloadPrimaryKey           SystemCommandKind = "UNLOAD PRIMARY KEY"
+	SystemUnfreeze                   SystemCommandKind = "UNFREEZE"
+	SystemOther                      SystemCommandKind = "OTHER" // Any other command, kept in SystemQuery.Command
+)
+
+// SyncReplicaMode is the mode of SYSTEM SYNC REPLICA. It is empty for the
+// default mode.
+type SyncReplicaMode string
+
+const (
+	SyncReplicaPull        SyncReplicaMode = "PULL"
+	SyncReplicaLightweight SyncReplicaMode = "LIGHTWEIGHT"
+	SyncReplicaStrict      SyncReplicaMode = "STRICT"
+)
+
 // TransactionControlQuery represents a transaction control statement (BEGIN, COMMIT, ROLLBACK, SET TRANSACTION SNAPSHOT).
 type TransactionControlQuery struct {
-	Position token.Position `json:"-"`
-	Action   string         `json:"action"` // "BEGIN", "COMMIT", "ROLLBACK", "SET_SNAPSHOT"
-	Snapshot int64          `json:"snapshot,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Action      string         `json:"action"` // "BEGIN", "COMMIT", "ROLLBACK", "SET_SNAPSHOT"
+	Snapshot    int64          `json:"snapshot,omitempty"`
 }
 
 func (t *TransactionControlQuery) Pos() token.Position { return t.Position }
-func (t *TransactionControlQuery) End() token.Position { return t.Position }
+func (t *TransactionControlQuery) End() token.Position { return t.EndPosition }
 func (t *TransactionControlQuery) statementNode()      {}
 
 // RenamePair represents a single rename pair in RENAME TABLE.
 type RenamePair struct {
-	FromDatabase string `json:"from_database,omitempty"`
-	FromTable    string `json:"from_table"`
-	ToDatabase   string `json:"to_database,omitempty"`
-	ToTable      string `json:"to_table"`
+	Position     token.Position `json:"-"`
+	EndPosition  token.Position `json:"-"`
+	FromDatabase string         `json:"from_database,omitempty"`
+	FromTable    string         `json:"from_table"`
+	ToDatabase   string         `json:"to_database,omitempty"`
+	ToTable      string         `json:"to_table"`
 }
 
+func (r *RenamePair) Pos() token.Position { return r.Position }
+func (r *RenamePair) End() token.Position { return r.EndPosition }
+
 // RenameQuery represents a RENAME TABLE statement.
 type RenameQuery struct {
 	Position       token.Position `json:"-"`
+	EndPosition    token.Position `json:"-"`
 	Pairs          []*RenamePair  `json:"pairs"`                     // Multiple rename pairs
 	From           string         `json:"from,omitempty"`            // Deprecated: for backward compat
 	To             string         `json:"to,omitempty"`              // Deprecated: for backward compat
@@ -1008,21 +1432,22 @@ type RenameQuery struct {
 }
 
 func (r *RenameQuery) Pos() token.Position { return r.Position }
-func (r *RenameQuery) End() token.Position { return r.Position }
+func (r *RenameQuery) End() token.Position { return r.EndPosition }
 func (r *RenameQuery) statementNode()      {}
 
 // ExchangeQuery represents an EXCHANGE TABLES statement.
 type ExchangeQuery struct {
-	Position  token.Position `json:"-"`
-	Database1 string         `json:"database1,omitempty"`
-	Table1    string         `json:"table1"`
-	Database2 string         `json:"database2,omitempty"`
-	Table2    string         `json:"table2"`
-	OnCluster string         `json:"on_cluster,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database1   string         `json:"database1,omitempty"`
+	Table1      string         `json:"table1"`
+	Database2   string         `json:"database2,omitempty"`
+	Table2      string         `json:"table2"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
 }
 
 func (e *ExchangeQuery) Pos() token.Position { return e.Position }
-func (e *ExchangeQuery) End() token.Position { return e.Position }
+func (e *ExchangeQuery) End() token.Position { return e.EndPosition }
 func (e *ExchangeQuery) statementNode()      {}
 
 // ExistsType represents the type of EXISTS query.
@@ -1037,268 +1462,637 @@ const (
 
 // ExistsQuery represents an EXISTS table_name statement (check if table exists).
 type ExistsQuery struct {
-	Position   token.Position `json:"-"`
-	ExistsType ExistsType     `json:"exists_type,omitempty"`
-	Temporary  bool           `json:"temporary,omitempty"`
-	Database   string         `json:"database,omitempty"`
-	Table      string         `json:"table"`
-	Settings   []*SettingExpr `json:"settings,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	ExistsType  ExistsType     `json:"exists_type,omitempty"`
+	Temporary   bool           `json:"temporary,omitempty"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table"`
+	Settings    []*SettingExpr `json:"settings,omitempty"`
 }
 
 func (e *ExistsQuery) Pos() token.Position { return e.Position }
-func (e *ExistsQuery) End() token.Position { return e.Position }
+func (e *ExistsQuery) End() token.Position { return e.EndPosition }
 func (e *ExistsQuery) statementNode()      {}
 
 // GrantQuery represents a GRANT or REVOKE statement.
 type GrantQuery struct {
-	Position token.Position `json:"-"`
-	IsRevoke bool           `json:"is_revoke,omitempty"`
+	Position      token.Position  `json:"-"`
+	EndPosition   token.Position  `json:"-"`
+	IsRevoke      bool            `json:"is_revoke,omitempty"`
+	OnCluster     string          `json:"on_cluster,omitempty"`
+	CurrentGrants bool            `json:"current_grants,omitempty"` // GRANT CURRENT GRANTS
+	Elements      []*GrantElement `json:"elements,omitempty"`       // Privileges and the objects they apply to
+	Roles         []string        `json:"roles,omitempty"`          // Roles granted or revoked, as in GRANT role TO user
+	Grantees      *RoleSet        `json:"grantees,omitempty"`       // Users and roles after TO or FROM
+	GrantOption   bool            `json:"grant_option,omitempty"`   // WITH GRANT OPTION, or REVOKE GRANT OPTION FOR
+	AdminOption   bool            `json:"admin_option,omitempty"`   // WITH ADMIN OPTION, or REVOKE ADMIN OPTION FOR
+	ReplaceOption bool            `json:"replace_option,omitempty"` // WITH REPLACE OPTION
 }
 
 func (g *GrantQuery) Pos() token.Position { return g.Position }
-func (g *GrantQuery) End() token.Position { return g.Position }
+func (g *GrantQuery) End() token.Position { return g.EndPosition }
 func (g *GrantQuery) statementNode()      {}
 
+// GrantElement represents privileges on an object in a GRANT or REVOKE
+// statement, such as SELECT(a, b), INSERT ON db.table.
+//
+// Database and Table hold the object after ON as written, with * for
+// wildcards: *.* sets both to "*", db.* sets Table to "*", and team*.*
+// sets Database to "team*". A single name, such as a table, a table
+// engine or a source like S3, is stored in Table. Both are empty if ON is
+// omitted, as in GRANT CURRENT GRANTS.
+type GrantElement struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Privileges  []*Privilege   `json:"privileges,omitempty"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table,omitempty"`
+}
+
+func (g *GrantElement) Pos() token.Position { return g.Position }
+func (g *GrantElement) End() token.Position { return g.EndPosition }
+
+// Privilege represents a privilege in a GRANT or REVOKE statement, such
+// as SELECT(a, b) or ALTER UPDATE.
+type Privilege struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Name        string         `json:"name"` // Upper case, with words separated by single spaces
+	Columns     []string       `json:"columns,omitempty"`
+}
+
+func (p *Privilege) Pos() token.Position { return p.Position }
+func (p *Privilege) End() token.Position { return p.EndPosition }
+
+// RoleSet represents a list of users and roles, such as the grantees of a
+// GRANT statement: names, CURRENT_USER, or ALL optionally followed by
+// EXCEPT and the names to leave out.
+type RoleSet struct {
+	Position          token.Position `json:"-"`
+	EndPosition       token.Position `json:"-"`
+	Names             []string       `json:"names,omitempty"` // As written, including any @host of a user
+	CurrentUser       bool           `json:"current_user,omitempty"`
+	All               bool           `json:"all,omitempty"`
+	Except            []string       `json:"except,omitempty"`
+	ExceptCurrentUser bool           `json:"except_current_user,omitempty"`
+}
+
+func (r *RoleSet) Pos() token.Position { return r.Position }
+func (r *RoleSet) End() token.Position { return r.EndPosition }
+
 // ShowGrantsQuery represents a SHOW GRANTS statement.
 type ShowGrantsQuery struct {
-	Position token.Position `json:"-"`
-	Format   string         `json:"format,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Format      string         `json:"format,omitempty"`
 }
 
 func (s *ShowGrantsQuery) Pos() token.Position { return s.Position }
-func (s *ShowGrantsQuery) End() token.Position { return s.Position }
+func (s *ShowGrantsQuery) End() token.Position { return s.EndPosition }
 func (s *ShowGrantsQuery) statementNode()      {}
 
 // KillQuery represents a KILL QUERY/MUTATION statement.
 type KillQuery struct {
-	Position token.Position `json:"-"`
-	Type     string         `json:"type"`              // "QUERY" or "MUTATION"
-	Where    Expression     `json:"where,omitempty"`   // WHERE condition
-	Sync     bool           `json:"sync,omitempty"`    // SYNC mode (default false = ASYNC)
-	Test     bool           `json:"test,omitempty"`    // TEST mode
-	Format   string         `json:"format,omitempty"`  // FORMAT clause
-	Settings []*SettingExpr `json:"settings,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Type        string         `json:"type"`             // "QUERY" or "MUTATION"
+	Where       Expression     `json:"where,omitempty"`  // WHERE condition
+	Sync        bool           `json:"sync,omitempty"`   // SYNC mode (default false = ASYNC)
+	Test        bool           `json:"test,omitempty"`   // TEST mode
+	Format      string         `json:"format,omitempty"` // FORMAT clause
+	Settings    []*SettingExpr `json:"settings,omitempty"`
 }
 
 func (k *KillQuery) Pos() token.Position { return k.Position }
-func (k *KillQuery) End() token.Position { return k.Position }
+func (k *KillQuery) End() token.Position { return k.EndPosition }
 func (k *KillQuery) statementNode()      {}
 
 // ShowPrivilegesQuery represents a SHOW PRIVILEGES statement.
 type ShowPrivilegesQuery struct {
-	Position token.Position `json:"-"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
 }
 
 func (s *ShowPrivilegesQuery) Pos() token.Position { return s.Position }
-func (s *ShowPrivilegesQuery) End() token.Position { return s.Position }
+func (s *ShowPrivilegesQuery) End() token.Position { return s.EndPosition }
 func (s *ShowPrivilegesQuery) statementNode()      {}
 
 // ShowCreateQuotaQuery represents a SHOW CREATE QUOTA statement.
 type ShowCreateQuotaQuery struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name,omitempty"`
-	Format   string         `json:"format,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Name        string         `json:"name,omitempty"`
+	Format      string         `json:"format,omitempty"`
 }
 
 func (s *ShowCreateQuotaQuery) Pos() token.Position { return s.Position }
-func (s *ShowCreateQuotaQuery) End() token.Position { return s.Position }
+func (s *ShowCreateQuotaQuery) End() token.Position { return s.EndPosition }
 func (s *ShowCreateQuotaQuery) statementNode()      {}
 
-// CreateQuotaQuery represents a CREATE QUOTA statement.
+// CreateQuotaQuery represents a CREATE QUOTA or ALTER QUOTA statement.
 type CreateQuotaQuery struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name,omitempty"`
+	Position    token.Position   `json:"-"`
+	EndPosition token.Position   `json:"-"`
+	IsAlter     bool             `json:"is_alter,omitempty"`
+	IfExists    bool             `json:"if_exists,omitempty"`
+	IfNotExists bool             `json:"if_not_exists,omitempty"`
+	OrReplace   bool             `json:"or_replace,omitempty"`
+	Names       []string         `json:"names,omitempty"`
+	OnCluster   string           `json:"on_cluster,omitempty"`
+	NewName     string           `json:"new_name,omitempty"` // ALTER QUOTA ... RENAME TO
+	Storage     string           `json:"storage,omitempty"`  // IN access_storage
+	KeyedBy     []string         `json:"keyed_by,omitempty"` // As written, such as user_name or 'client key or user name'
+	NotKeyed    bool             `json:"not_keyed,omitempty"`
+	Intervals   []*QuotaInterval `json:"intervals,omitempty"`
+	Roles       *RoleSet         `json:"roles,omitempty"` // TO roles
 }
 
 func (c *CreateQuotaQuery) Pos() token.Position { return c.Position }
-func (c *CreateQuotaQuery) End() token.Position { return c.Position }
+func (c *CreateQuotaQuery) End() token.Position { return c.EndPosition }
 func (c *CreateQuotaQuery) statementNode()      {}
 
+// QuotaInterval represents a FOR [RANDOMIZED] INTERVAL clause of a quota
+// with the limits that apply over it.
+type QuotaInterval struct {
+	Position     token.Position `json:"-"`
+	EndPosition  token.Position `json:"-"`
+	Randomized   bool           `json:"randomized,omitempty"`
+	Duration     Expression     `json:"duration"`
+	Unit         string         `json:"unit"` // Upper case: SECOND, MINUTE, HOUR, DAY, WEEK, MONTH, QUARTER or YEAR
+	Limits       []*QuotaLimit  `json:"limits,omitempty"`
+	NoLimits     bool           `json:"no_limits,omitempty"`
+	TrackingOnly bool           `json:"tracking_only,omitempty"`
+}
+
+func (q *QuotaInterval) Pos() token.Position { return q.Position }
+func (q *QuotaInterval) End() token.Position { return q.EndPosition }
+
+// QuotaLimit represents a limit of a quota interval, as in MAX queries = 100.
+type QuotaLimit struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Resource    string         `json:"resource"` // Lower case, with words separated by underscores: queries, result_rows, execution_time, ...
+	Value       Expression     `json:"value"`    // Number, or string with a suffix such as '12K'
+}
+
+func (q *QuotaLimit) Pos() token.Position { return q.Position }
+func (q *QuotaLimit) End() token.Position { return q.EndPosition }
+
 // CreateSettingsProfileQuery represents a CREATE SETTINGS PROFILE statement.
 type CreateSettingsProfileQuery struct {
-	Position token.Position `json:"-"`
-	Names    []string       `json:"names,omitempty"`
+	Position     token.Position `json:"-"`
+	EndPosition  token.Position `json:"-"`
+	IfNotExists  bool           `json:"if_not_exists,omitempty"`
+	OrReplace    bool           `json:"or_replace,omitempty"`
+	Names        []string       `json:"names,omitempty"`
+	OnCluster    string         `json:"on_cluster,omitempty"`
+	Storage      string         `json:"storage,omitempty"` // IN access_storage
+	Settings     []*SettingExpr `json:"settings,omitempty"`
+	SettingsNone bool           `json:"settings_none,omitempty"` // SETTINGS NONE
+	Roles        *RoleSet       `json:"roles,omitempty"`         // TO roles
 }
 
 func (c *CreateSettingsProfileQuery) Pos() token.Position { return c.Position }
-func (c *CreateSettingsProfileQuery) End() token.Position { return c.Position }
+func (c *CreateSettingsProfileQuery) End() token.Position { return c.EndPosition }
 func (c *CreateSettingsProfileQuery) statementNode()      {}
 
 // AlterSettingsProfileQuery represents an ALTER SETTINGS PROFILE statement.
+// Settings replaces all the settings of the profiles, while the other
+// fields change some of them.
 type AlterSettingsProfileQuery struct {
-	Position token.Position `json:"-"`
-	Names    []string       `json:"names,omitempty"`
+	Position        token.Position `json:"-"`
+	EndPosition     token.Position `json:"-"`
+	IfExists        bool           `json:"if_exists,omitempty"`
+	Names           []string       `json:"names,omitempty"`
+	OnCluster       string         `json:"on_cluster,omitempty"`
+	NewName         string         `json:"new_name,omitempty"` // RENAME TO
+	Settings        []*SettingExpr `json:"settings,omitempty"`
+	SettingsNone    bool           `json:"settings_none,omitempty"`   // SETTINGS NONE
+	AddSettings     []*SettingExpr `json:"add_settings,omitempty"`    // ADD SETTINGS and ADD PROFILES
+	ModifySettings  []*SettingExpr `json:"modify_settings,omitempty"` // MODIFY SETTINGS
+	DropSettings    []string       `json:"drop_settings,omitempty"`
+	DropProfiles    []string       `json:"drop_profiles,omitempty"`
+	DropAllSettings bool           `json:"drop_all_settings,omitempty"`
+	DropAllProfiles bool           `json:"drop_all_profiles,omitempty"`
+	Roles           *RoleSet       `json:"roles,omitempty"` // TO roles
 }
 
 func (a *AlterSettingsProfileQuery) Pos() token.Position { return a.Position }
-func (a *AlterSettingsProfileQuery) End() token.Position { return a.Position }
+func (a *AlterSettingsProfileQuery) End() token.Position { return a.EndPosition }
 func (a *AlterSettingsProfileQuery) statementNode()      {}
 
 // DropSettingsProfileQuery represents a DROP SETTINGS PROFILE statement.
 type DropSettingsProfileQuery struct {
-	Position token.Position `json:"-"`
-	Names    []string       `json:"names,omitempty"`
-	IfExists bool           `json:"if_exists,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Names       []string       `json:"names,omitempty"`
+	IfExists    bool           `json:"if_exists,omitempty"`
 }
 
 func (d *DropSettingsProfileQuery) Pos() token.Position { return d.Position }
-func (d *DropSettingsProfileQuery) End() token.Position { return d.Position }
+func (d *DropSettingsProfileQuery) End() token.Position { return d.EndPosition }
 func (d *DropSettingsProfileQuery) statementNode()      {}
 
 // CreateNamedCollectionQuery represents a CREATE NAMED COLLECTION statement.
 type CreateNamedCollectionQuery struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name,omitempty"`
+	Position    token.Position  `json:"-"`
+	EndPosition token.Position  `json:"-"`
+	IfNotExists bool            `json:"if_not_exists,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	OnCluster   string          `json:"on_cluster,omitempty"`
+	Pairs       []*KeyValuePair `json:"pairs,omitempty"` // AS key = value, ...
 }
 
 func (c *CreateNamedCollectionQuery) Pos() token.Position { return c.Position }
-func (c *CreateNamedCollectionQuery) End() token.Position { return c.Position }
+func (c *CreateNamedCollectionQuery) End() token.Position { return c.EndPosition }
 func (c *CreateNamedCollectionQuery) statementNode()      {}
 
 // AlterNamedCollectionQuery represents an ALTER NAMED COLLECTION statement.
 type AlterNamedCollectionQuery struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name,omitempty"`
+	Position    token.Position  `json:"-"`
+	EndPosition token.Position  `json:"-"`
+	IfExists    bool            `json:"if_exists,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	OnCluster   string          `json:"on_cluster,omitempty"`
+	Set         []*KeyValuePair `json:"set,omitempty"`    // SET key = value, ...
+	Delete      []string        `json:"delete,omitempty"` // DELETE key, ...
 }
 
 func (a *AlterNamedCollectionQuery) Pos() token.Position { return a.Position }
-func (a *AlterNamedCollectionQuery) End() token.Position { return a.Position }
+func (a *AlterNamedCollectionQuery) End() token.Position { return a.EndPosition }
 func (a *AlterNamedCollectionQuery) statementNode()      {}
 
 // DropNamedCollectionQuery represents a DROP NAMED COLLECTION statement.
 type DropNamedCollectionQuery struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name,omitempty"`
-	IfExists bool           `json:"if_exists,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Name        string         `json:"name,omitempty"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
 }
 
 func (d *DropNamedCollectionQuery) Pos() token.Position { return d.Position }
-func (d *DropNamedCollectionQuery) End() token.Position { return d.Position }
+func (d *DropNamedCollectionQuery) End() token.Position { return d.EndPosition }
 func (d *DropNamedCollectionQuery) statementNode()      {}
 
 // ShowCreateSettingsProfileQuery represents a SHOW CREATE SETTINGS PROFILE statement.
 type ShowCreateSettingsProfileQuery struct {
-	Position token.Position `json:"-"`
-	Names    []string       `json:"names,omitempty"`
-	Format   string         `json:"format,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Names       []string       `json:"names,omitempty"`
+	Format      string         `json:"format,omitempty"`
 }
 
 func (s *ShowCreateSettingsProfileQuery) Pos() token.Position { return s.Position }
-func (s *ShowCreateSettingsProfileQuery) End() token.Position { return s.Position }
+func (s *ShowCreateSettingsProfileQuery) End() token.Position { return s.EndPosition }
 func (s *ShowCreateSettingsProfileQuery) statementNode()      {}
 
+// RowPolicyKind is whether a row policy is PERMISSIVE or RESTRICTIVE.
+type RowPolicyKind string
+
+const (
+	RowPolicyPermissive  RowPolicyKind = "PERMISSIVE"
+	RowPolicyRestrictive RowPolicyKind = "RESTRICTIVE"
+)
+
 // CreateRowPolicyQuery represents a CREATE ROW POLICY or ALTER ROW POLICY statement.
 type CreateRowPolicyQuery struct {
-	Position token.Position `json:"-"`
-	IsAlter  bool           `json:"is_alter,omitempty"`
+	Position    token.Position   `json:"-"`
+	EndPosition token.Position   `json:"-"`
+	IsAlter     bool             `json:"is_alter,omitempty"`
+	IfExists    bool             `json:"if_exists,omitempty"`
+	IfNotExists bool             `json:"if_not_exists,omitempty"`
+	OrReplace   bool             `json:"or_replace,omitempty"`
+	Policies    []*RowPolicyName `json:"policies,omitempty"`
+	OnCluster   string           `json:"on_cluster,omitempty"`
+	NewName     string           `json:"new_name,omitempty"` // ALTER ROW POLICY ... RENAME TO
+	Storage     string           `json:"storage,omitempty"`  // IN access_storage
+	ForSelect   bool             `json:"for_select,omitempty"`
+	Using       Expression       `json:"using,omitempty"`      // Filter condition
+	UsingNone   bool             `json:"using_none,omitempty"` // USING NONE
+	Kind        RowPolicyKind    `json:"kind,omitempty"`       // AS PERMISSIVE or AS RESTRICTIVE
+	Roles       *RoleSet         `json:"roles,omitempty"`      // TO roles
 }
 
 func (c *CreateRowPolicyQuery) Pos() token.Position { return c.Position }
-func (c *CreateRowPolicyQuery) End() token.Position { return c.Position }
+func (c *CreateRowPolicyQuery) End() token.Position { return c.EndPosition }
 func (c *CreateRowPolicyQuery) statementNode()      {}
 
+// RowPolicyName represents row policies and the tables they are on, as in
+// p1, p2 ON db.table or p1 ON db.table, db2.table2. Every name refers to
+// a policy on every table. A Table of "*" stands for all tables of the
+// database, as in ON db.*.
+type RowPolicyName struct {
+	Position    token.Position     `json:"-"`
+	EndPosition token.Position     `json:"-"`
+	Names       []string           `json:"names"`
+	Tables      []*TableIdentifier `json:"tables"`
+}
+
+func (r *RowPolicyName) Pos() token.Position { return r.Position }
+func (r *RowPolicyName) End() token.Position { return r.EndPosition }
+
 // DropRowPolicyQuery represents a DROP ROW POLICY statement.
 type DropRowPolicyQuery struct {
-	Position token.Position `json:"-"`
-	IfExists bool           `json:"if_exists,omitempty"`
+	Position    token.Position   `json:"-"`
+	EndPosition token.Position   `json:"-"`
+	IfExists    bool             `json:"if_exists,omitempty"`
+	Policies    []*RowPolicyName `json:"policies,omitempty"`
+	OnCluster   string           `json:"on_cluster,omitempty"`
+	Storage     string           `json:"storage,omitempty"` // FROM access_storage
 }
 
 func (d *DropRowPolicyQuery) Pos() token.Position { return d.Position }
-func (d *DropRowPolicyQuery) End() token.Position { return d.Position }
+func (d *DropRowPolicyQuery) End() token.Position { return d.EndPosition }
 func (d *DropRowPolicyQuery) statementNode()      {}
 
 // ShowCreateRowPolicyQuery represents a SHOW CREATE ROW POLICY statement.
 type ShowCreateRowPolicyQuery struct {
-	Position token.Position `json:"-"`
-	Format   string         `json:"format,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Format      string         `json:"format,omitempty"`
 }
 
 func (s *ShowCreateRowPolicyQuery) Pos() token.Position { return s.Position }
-func (s *ShowCreateRowPolicyQuery) End() token.Position { return s.Position }
+func (s *ShowCreateRowPolicyQuery) End() token.Position { return s.EndPosition }
 func (s *ShowCreateRowPolicyQuery) statementNode()      {}
 
 // CreateRoleQuery represents a CREATE ROLE or ALTER ROLE statement.
 type CreateRoleQuery struct {
-	Position token.Position `json:"-"`
-	IsAlter  bool           `json:"is_alter,omitempty"`
+	Position     token.Position `json:"-"`
+	EndPosition  token.Position `json:"-"`
+	IsAlter      bool           `json:"is_alter,omitempty"`
+	IfExists     bool           `json:"if_exists,omitempty"`
+	IfNotExists  bool           `json:"if_not_exists,omitempty"`
+	OrReplace    bool           `json:"or_replace,omitempty"`
+	Names        []string       `json:"names,omitempty"` // As written, including any @host
+	OnCluster    string         `json:"on_cluster,omitempty"`
+	NewName      string         `json:"new_name,omitempty"` // ALTER ROLE ... RENAME TO
+	Storage      string         `json:"storage,omitempty"`  // IN access_storage
+	Settings     []*SettingExpr `json:"settings,omitempty"`
+	SettingsNone bool           `json:"settings_none,omitempty"` // SETTINGS NONE
 }
 
 func (c *CreateRoleQuery) Pos() token.Position { return c.Position }
-func (c *CreateRoleQuery) End() token.Position { return c.Position }
+func (c *CreateRoleQuery) End() token.Position { return c.EndPosition }
 func (c *CreateRoleQuery) statementNode()      {}
 
+// SettingWritability is the constraint on changing a setting in a
+// settings profile.
+type SettingWritability string
+
+const (
+	SettingConst                SettingWritability = "CONST"
+	SettingReadonly             SettingWritability = "READONLY" // Same as CONST
+	SettingWritable             SettingWritability = "WRITABLE"
+	SettingChangeableInReadonly SettingWritability = "CHANGEABLE_IN_READONLY"
+)
+
+// CreateUserQuery represents a CREATE USER or ALTER USER statement.
+type CreateUserQuery struct {
+	Position            token.Position          `json:"-"`
+	EndPosition         token.Position          `json:"-"`
+	IsAlter             bool                    `json:"is_alter,omitempty"`
+	IfExists            bool                    `json:"if_exists,omitempty"`
+	IfNotExists         bool                    `json:"if_not_exists,omitempty"`
+	OrReplace           bool                    `json:"or_replace,omitempty"`
+	Names               []string                `json:"names,omitempty"` // As written, including any @host
+	OnCluster           string                  `json:"on_cluster,omitempty"`
+	NewName             string                  `json:"new_name,omitempty"` // ALTER USER ... RENAME TO
+	Storage             string                  `json:"storage,omitempty"`  // IN access_storage
+	NotIdentified       bool                    `json:"not_identified,omitempty"`
+	AuthMethods         []*AuthenticationMethod `json:"auth_methods,omitempty"`
+	AddIdentified       bool                    `json:"add_identified,omitempty"`     // ALTER USER ... ADD IDENTIFIED keeps the existing methods
+	ResetAuthMethods    bool                    `json:"reset_auth_methods,omitempty"` // RESET AUTHENTICATION METHODS TO NEW
+	Hosts               []*UserHost             `json:"hosts,omitempty"`
+	AddHosts            []*UserHost             `json:"add_hosts,omitempty"`
+	DropHosts           []*UserHost             `json:"drop_hosts,omitempty"`
+	ValidUntil          Expression              `json:"valid_until,omitempty"`
+	DefaultRoles        *RoleSet                `json:"default_roles,omitempty"`
+	DefaultDatabase     string                  `json:"default_database,omitempty"`
+	DefaultDatabaseNone bool                    `json:"default_database_none,omitempty"` // DEFAULT DATABASE NONE
+	Grantees            *RoleSet                `json:"grantees,omitempty"`
+	Settings            []*SettingExpr          `json:"settings,omitempty"`
+	SettingsNone        bool                    `json:"settings_none,omitempty"` // SETTINGS NONE
+}
+
+func (c *CreateUserQuery) Pos() token.Position { return c.Position }
+func (c *CreateUserQuery) End() token.Position { return c.EndPosition }
+func (c *CreateUserQuery) statementNode()      {}
+
+// AuthenticationType is the kind of an authentication method of a user,
+// in lower case as written after IDENTIFIED WITH.
+type AuthenticationType string
+
+const (
+	AuthNoPassword          AuthenticationType = "no_password"
+	AuthPlaintextPassword   AuthenticationType = "plaintext_password"
+	AuthSHA256Password      AuthenticationType = "sha256_password"
+	AuthSHA256Hash          AuthenticationType = "sha256_hash"
+	AuthDoubleSHA1Password  AuthenticationType = "double_sha1_password"
+	AuthDoubleSHA1Hash      AuthenticationType = "double_sha1_hash"
+	AuthBcryptPassword      AuthenticationType = "bcrypt_password"
+	AuthBcryptHash          AuthenticationType = "bcrypt_hash"
+	AuthScramSHA256Password AuthenticationType = "scram_sha256_password"
+	AuthScramSHA256Hash     AuthenticationType = "scram_sha256_hash"
+	AuthLDAP                AuthenticationType = "ldap"
+	AuthKerberos            AuthenticationType = "kerberos"
+	AuthSSLCertificate      AuthenticationType = "ssl_certificate"
+	AuthSSHKey              AuthenticationType = "ssh_key"
+	AuthHTTP                AuthenticationType = "http"
+)
+
+// AuthenticationMethod represents an authentication method of a user, as
+// in IDENTIFIED WITH sha256_hash BY 'hash' SALT 'salt'. Type is empty for
+// IDENTIFIED BY 'password', which uses the server's default.
+type AuthenticationMethod struct {
+	Position        token.Position     `json:"-"`
+	EndPosition     token.Position     `json:"-"`
+	Type            AuthenticationType `json:"type,omitempty"`
+	Value           Expression         `json:"value,omitempty"` // Password or hash after BY, LDAP or HTTP SERVER, or Kerberos REALM
+	Salt            string             `json:"salt,omitempty"`
+	Scheme          string             `json:"scheme,omitempty"`            // HTTP SCHEME
+	CommonNames     []string           `json:"common_names,omitempty"`      // ssl_certificate CN
+	SubjectAltNames []string           `json:"subject_alt_names,omitempty"` // ssl_certificate SAN
+	SSHKeys         []*SSHKey          `json:"ssh_keys,omitempty"`
+	ValidUntil      Expression         `json:"valid_until,omitempty"`
+}
+
+func (a *AuthenticationMethod) Pos() token.Position { return a.Position }
+func (a *AuthenticationMethod) End() token.Position { return a.EndPosition }
+
+// SSHKey represents a public key of ssh_key authentication, as in
+// KEY 'AAAAC3...' TYPE 'ssh-ed25519'.
+type SSHKey struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Key         string         `json:"key"`
+	Type        string         `json:"type"`
+}
+
+func (s *SSHKey) Pos() token.Position { return s.Position }
+func (s *SSHKey) End() token.Position { return s.EndPosition }
+
+// UserHostKind is the way a HOST clause of a user matches client hosts.
+type UserHostKind string
+
+const (
+	UserHostAny    UserHostKind = "ANY"
+	UserHostNone   UserHostKind = "NONE"
+	UserHostLocal  UserHostKind = "LOCAL"
+	UserHostName   UserHostKind = "NAME"
+	UserHostRegexp UserHostKind = "REGEXP"
+	UserHostIP     UserHostKind = "IP"
+	UserHostLike   UserHostKind = "LIKE"
+)
+
+// UserHost represents a host a user may connect from, as in IP
+// '192.168.0.0/16' or LIKE '%.example.com'. Pattern is empty for ANY, NONE
+// and LOCAL.
+type UserHost struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Kind        UserHostKind   `json:"kind"`
+	Pattern     string         `json:"pattern,omitempty"`
+}
+
+func (u *UserHost) Pos() token.Position { return u.Position }
+func (u *UserHost) End() token.Position { return u.EndPosition }
+
 // DropRoleQuery represents a DROP ROLE statement.
 type DropRoleQuery struct {
-	Position token.Position `json:"-"`
-	IfExists bool           `json:"if_exists,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	Names       []string       `json:"names,omitempty"` // As written, including any @host
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	Storage     string         `json:"storage,omitempty"` // FROM access_storage
 }
 
 func (d *DropRoleQuery) Pos() token.Position { return d.Position }
-func (d *DropRoleQuery) End() token.Position { return d.Position }
+func (d *DropRoleQuery) End() token.Position { return d.EndPosition }
 func (d *DropRoleQuery) statementNode()      {}
 
 // ShowCreateRoleQuery represents a SHOW CREATE ROLE statement.
 type ShowCreateRoleQuery struct {
-	Position  token.Position `json:"-"`
-	RoleCount int            `json:"role_count,omitempty"` // Number of roles specified
-	Format    string         `json:"format,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	RoleCount   int            `json:"role_count,omitempty"` // Number of roles specified
+	Format      string         `json:"format,omitempty"`
 }
 
 func (s *ShowCreateRoleQuery) Pos() token.Position { return s.Position }
-func (s *ShowCreateRoleQuery) End() token.Position { return s.Position }
+func (s *ShowCreateRoleQuery) End() token.Position { return s.EndPosition }
 func (s *ShowCreateRoleQuery) statementNode()      {}
 
-// SetRoleQuery represents a SET DEFAULT ROLE statement.
+// SetRoleKind is the form of a SET ROLE statement.
+type SetRoleKind string
+
+const (
+	SetRole        SetRoleKind = "SET ROLE"         // SET ROLE roles
+	SetRoleDefault SetRoleKind = "SET ROLE DEFAULT" // SET ROLE DEFAULT
+	SetDefaultRole SetRoleKind = "SET DEFAULT ROLE" // SET DEFAULT ROLE roles TO users
+)
+
+// SetRoleQuery represents a SET ROLE or SET DEFAULT ROLE statement.
 type SetRoleQuery struct {
-	Position token.Position `json:"-"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Kind        SetRoleKind    `json:"kind"`
+	Roles       *RoleSet       `json:"roles,omitempty"` // Empty for NONE, nil for SET ROLE DEFAULT
+	Users       *RoleSet       `json:"users,omitempty"` // TO users, for SET DEFAULT ROLE
 }
 
 func (s *SetRoleQuery) Pos() token.Position { return s.Position }
-func (s *SetRoleQuery) End() token.Position { return s.Position }
+func (s *SetRoleQuery) End() token.Position { return s.EndPosition }
 func (s *SetRoleQuery) statementNode()      {}
 
 // CreateResourceQuery represents a CREATE RESOURCE statement.
 type CreateResourceQuery struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name"`
+	Position    token.Position       `json:"-"`
+	EndPosition token.Position       `json:"-"`
+	OrReplace   bool                 `json:"or_replace,omitempty"`
+	IfNotExists bool                 `json:"if_not_exists,omitempty"`
+	Name        string               `json:"name"`
+	OnCluster   string               `json:"on_cluster,omitempty"`
+	Operations  []*ResourceOperation `json:"operations,omitempty"`
 }
 
 func (c *CreateResourceQuery) Pos() token.Position { return c.Position }
-func (c *CreateResourceQuery) End() token.Position { return c.Position }
+func (c *CreateResourceQuery) End() token.Position { return c.EndPosition }
 func (c *CreateResourceQuery) statementNode()      {}
 
+// ResourceAccessMode is the kind of operation a resource is used for.
+type ResourceAccessMode string
+
+const (
+	ResourceRead         ResourceAccessMode = "READ"
+	ResourceWrite        ResourceAccessMode = "WRITE"
+	ResourceMasterThread ResourceAccessMode = "MASTER THREAD"
+	ResourceWorkerThread ResourceAccessMode = "WORKER THREAD"
+	ResourceQuery        ResourceAccessMode = "QUERY"
+)
+
+// ResourceOperation represents an operation a resource is used for, as in
+// READ DISK s3, WRITE ANY DISK or MASTER THREAD.
+type ResourceOperation struct {
+	Position    token.Position     `json:"-"`
+	EndPosition token.Position     `json:"-"`
+	Mode        ResourceAccessMode `json:"mode"`
+	Disk        string             `json:"disk,omitempty"` // For READ DISK and WRITE DISK
+	AnyDisk     bool               `json:"any_disk,omitempty"`
+}
+
+func (r *ResourceOperation) Pos() token.Position { return r.Position }
+func (r *ResourceOperation) End() token.Position { return r.EndPosition }
+
 // DropResourceQuery represents a DROP RESOURCE statement.
 type DropResourceQuery struct {
-	Position token.Position `json:"-"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	Name        string         `json:"name"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
 }
 
 func (d *DropResourceQuery) Pos() token.Position { return d.Position }
-func (d *DropResourceQuery) End() token.Position { return d.Position }
+func (d *DropResourceQuery) End() token.Position { return d.EndPosition }
 func (d *DropResourceQuery) statementNode()      {}
 
 // CreateWorkloadQuery represents a CREATE WORKLOAD statement.
 type CreateWorkloadQuery struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name"`
-	Parent   string         `json:"parent,omitempty"` // Parent workload name (after IN)
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	OrReplace   bool           `json:"or_replace,omitempty"`
+	IfNotExists bool           `json:"if_not_exists,omitempty"`
+	Name        string         `json:"name"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
+	Parent      string         `json:"parent,omitempty"` // Parent workload name (after IN)
+	Settings    []*SettingExpr `json:"settings,omitempty"`
 }
 
 func (c *CreateWorkloadQuery) Pos() token.Position { return c.Position }
-func (c *CreateWorkloadQuery) End() token.Position { return c.Position }
+func (c *CreateWorkloadQuery) End() token.Position { return c.EndPosition }
 func (c *CreateWorkloadQuery) statementNode()      {}
 
 // DropWorkloadQuery represents a DROP WORKLOAD statement.
 type DropWorkloadQuery struct {
-	Position token.Position `json:"-"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	IfExists    bool           `json:"if_exists,omitempty"`
+	Name        string         `json:"name"`
+	OnCluster   string         `json:"on_cluster,omitempty"`
 }
 
 func (d *DropWorkloadQuery) Pos() token.Position { return d.Position }
-func (d *DropWorkloadQuery) End() token.Position { return d.Position }
+func (d *DropWorkloadQuery) End() token.Position { return d.EndPosition }
 func (d *DropWorkloadQuery) statementNode()      {}
 
 // CreateIndexQuery represents a CREATE INDEX statement.
 type CreateIndexQuery struct {
 	Position             token.Position `json:"-"`
+	EndPosition          token.Position `json:"-"`
 	IndexName            string         `json:"index_name"`
 	Table                string         `json:"table"`
 	Columns              []Expression   `json:"columns,omitempty"`
@@ -1308,7 +2102,7 @@ type CreateIndexQuery struct {
 }
 
 func (c *CreateIndexQuery) Pos() token.Position { return c.Position }
-func (c *CreateIndexQuery) End() token.Position { return c.Position }
+func (c *CreateIndexQuery) End() token.Position { return c.EndPosition }
 func (c *CreateIndexQuery) statementNode()      {}
 
 // -----------------------------------------------------------------------------
@@ -1317,13 +2111,14 @@ func (c *CreateIndexQuery) statementNode()      {}
 // Identifier represents an identifier.
 type Identifier struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	Parts         []string       `json:"parts"` // e.g., ["db", "table", "column"] for db.table.column
 	Alias         string         `json:"alias,omitempty"`
-	Parenthesized bool           `json:"-"` // true if wrapped in parentheses, affects dot access parsing
+	Parenthesized bool           `json:"parenthesized,omitempty"` // true if wrapped in parentheses, affects dot access parsing
 }
 
 func (i *Identifier) Pos() token.Position { return i.Position }
-func (i *Identifier) End() token.Position { return i.Position }
+func (i *Identifier) End() token.Position { return i.EndPosition }
 func (i *Identifier) expressionNode()     {}
 
 // Name returns the full identifier name.
@@ -1343,19 +2138,21 @@ func (i *Identifier) Name() string {
 
 // TableIdentifier represents a table identifier.
 type TableIdentifier struct {
-	Position token.Position `json:"-"`
-	Database string         `json:"database,omitempty"`
-	Table    string         `json:"table"`
-	Alias    string         `json:"alias,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Database    string         `json:"database,omitempty"`
+	Table       string         `json:"table"`
+	Alias       string         `json:"alias,omitempty"`
 }
 
 func (t *TableIdentifier) Pos() token.Position { return t.Position }
-func (t *TableIdentifier) End() token.Position { return t.Position }
+func (t *TableIdentifier) End() token.Position { return t.EndPosition }
 func (t *TableIdentifier) expressionNode()     {}
 
 // Literal represents a literal value.
 type Literal struct {
 	Position       token.Position `json:"-"`
+	EndPosition    token.Position `json:"-"`
 	Type           LiteralType    `json:"type"`
 	Value          interface{}    `json:"value"`
 	Source         string         `json:"source,omitempty"`          // Original source text (for preserving 0.0 vs 0)
@@ -1367,45 +2164,9 @@ type Literal struct {
 }
 
 func (l *Literal) Pos() token.Position { return l.Position }
-func (l *Literal) End() token.Position { return l.Position }
+func (l *Literal) End() token.Position { return l.EndPosition }
 func (l *Literal) expressionNode()     {}
 
-// MarshalJSON handles special float values (NaN, +Inf, -Inf) that JSON doesn't support.
-func (l *Literal) MarshalJSON() ([]byte, error) {
-	type literalAlias Literal
-	// Handle special float values
-	if f, ok := l.Value.(float64); ok {
-		if math.IsNaN(f) {
-			return json.Marshal(&struct {
-				*literalAlias
-				Value string `json:"value"`
-			}{
-				literalAlias: (*literalAlias)(l),
-				Value:        "NaN",
-			})
-		}
-		if math.IsInf(f, 1) {
-			return json.Marshal(&struct {
-				*literalAlias
-				Value string `json:"value"`
-			}{
-				literalAlias: (*literalAlias)(l),
-				Value:        "+Inf",
-			})
-		}
-		if math.IsInf(f, -1) {
-			return json.Marshal(&struct {
-				*literalAlias
-				Value string `json:"value"`
-			}{
-				literalAlias: (*literalAlias)(l),
-				Value:        "-Inf",
-			})
-		}
-	}
-	return json.Marshal((*literalAlias)(l))
-}
-
 // LiteralType represents the type of a literal.
 type LiteralType string
 
@@ -1422,6 +2183,7 @@ const (
 // Asterisk represents a *.
 type Asterisk struct {
 	Position     token.Position       `json:"-"`
+	EndPosition  token.Position       `json:"-"`
 	Table        string               `json:"table,omitempty"`        // for table.*
 	Except       []string             `json:"except,omitempty"`       // for * EXCEPT (col1, col2) - deprecated, use Transformers
 	Replace      []*ReplaceExpr       `json:"replace,omitempty"`      // for * REPLACE (expr AS col) - deprecated, use Transformers
@@ -1430,22 +2192,24 @@ type Asterisk struct {
 }
 
 func (a *Asterisk) Pos() token.Position { return a.Position }
-func (a *Asterisk) End() token.Position { return a.Position }
+func (a *Asterisk) End() token.Position { return a.EndPosition }
 func (a *Asterisk) expressionNode()     {}
 
 // ReplaceExpr represents an expression in REPLACE clause.
 type ReplaceExpr struct {
-	Position token.Position `json:"-"`
-	Expr     Expression     `json:"expr"`
-	Name     string         `json:"name"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Expr        Expression     `json:"expr"`
+	Name        string         `json:"name"`
 }
 
 func (r *ReplaceExpr) Pos() token.Position { return r.Position }
-func (r *ReplaceExpr) End() token.Position { return r.Position }
+func (r *ReplaceExpr) End() token.Position { return r.EndPosition }
 
 // ColumnTransformer represents a single transformer (APPLY, EXCEPT, or REPLACE) in order.
 type ColumnTransformer struct {
 	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
 	Type        string         `json:"type"`                   // "apply", "except", "replace"
 	Apply       string         `json:"apply,omitempty"`        // function name for APPLY
 	ApplyParams []Expression   `json:"apply_params,omitempty"` // parameters for parameterized APPLY functions like quantiles(0.5)
@@ -1455,11 +2219,15 @@ type ColumnTransformer struct {
 	Replaces    []*ReplaceExpr `json:"replaces,omitempty"`     // replacement expressions for REPLACE
 }
 
+func (c *ColumnTransformer) Pos() token.Position { return c.Position }
+func (c *ColumnTransformer) End() token.Position { return c.EndPosition }
+
 // ColumnsMatcher represents COLUMNS('pattern') or COLUMNS(col1, col2) expression.
 // When Pattern is set, it's a regex matcher (ColumnsRegexpMatcher in explain).
 // When Columns is set, it's a list matcher (ColumnsListMatcher in explain).
 type ColumnsMatcher struct {
 	Position     token.Position       `json:"-"`
+	EndPosition  token.Position       `json:"-"`
 	Pattern      string               `json:"pattern,omitempty"`
 	Columns      []Expression         `json:"columns,omitempty"`      // For COLUMNS(id, name) syntax
 	Except       []string             `json:"except,omitempty"`       // for EXCEPT (col1, col2) - deprecated, use Transformers
@@ -1470,49 +2238,52 @@ type ColumnsMatcher struct {
 }
 
 func (c *ColumnsMatcher) Pos() token.Position { return c.Position }
-func (c *ColumnsMatcher) End() token.Position { return c.Position }
+func (c *ColumnsMatcher) End() token.Position { return c.EndPosition }
 func (c *ColumnsMatcher) expressionNode()     {}
 
 // FunctionCall represents a function call.
 type FunctionCall struct {
 	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
 	Name        string         `json:"name"`
 	Parameters  []Expression   `json:"parameters,omitempty"` // For parametric functions like quantile(0.9)(x)
 	Arguments   []Expression   `json:"arguments,omitempty"`
 	Settings    []*SettingExpr `json:"settings,omitempty"` // For table functions with SETTINGS
 	Distinct    bool           `json:"distinct,omitempty"`
-	Filter      Expression     `json:"filter,omitempty"`       // FILTER(WHERE condition) clause
+	Filter      Expression     `json:"filter,omitempty"` // FILTER(WHERE condition) clause
 	Over        *WindowSpec    `json:"over,omitempty"`
 	Alias       string         `json:"alias,omitempty"`
 	SQLStandard bool           `json:"sql_standard,omitempty"` // True for SQL standard syntax like TRIM(... FROM ...)
 }
 
 func (f *FunctionCall) Pos() token.Position { return f.Position }
-func (f *FunctionCall) End() token.Position { return f.Position }
+func (f *FunctionCall) End() token.Position { return f.EndPosition }
 func (f *FunctionCall) expressionNode()     {}
 
 // WindowSpec represents a window specification.
 type WindowSpec struct {
-	Position    token.Position     `json:"-"`
-	Name        string             `json:"name,omitempty"`
-	PartitionBy []Expression       `json:"partition_by,omitempty"`
-	OrderBy     []*OrderByElement  `json:"order_by,omitempty"`
-	Frame       *WindowFrame       `json:"frame,omitempty"`
+	Position    token.Position    `json:"-"`
+	EndPosition token.Position    `json:"-"`
+	Name        string            `json:"name,omitempty"`
+	PartitionBy []Expression      `json:"partition_by,omitempty"`
+	OrderBy     []*OrderByElement `json:"order_by,omitempty"`
+	Frame       *WindowFrame      `json:"frame,omitempty"`
 }
 
 func (w *WindowSpec) Pos() token.Position { return w.Position }
-func (w *WindowSpec) End() token.Position { return w.Position }
+func (w *WindowSpec) End() token.Position { return w.EndPosition }
 
 // WindowFrame represents a window frame.
 type WindowFrame struct {
-	Position   token.Position  `json:"-"`
-	Type       WindowFrameType `json:"type"`
-	StartBound *FrameBound     `json:"start"`
-	EndBound   *FrameBound     `json:"end,omitempty"`
+	Position    token.Position  `json:"-"`
+	EndPosition token.Position  `json:"-"`
+	Type        WindowFrameType `json:"type"`
+	StartBound  *FrameBound     `json:"start"`
+	EndBound    *FrameBound     `json:"end,omitempty"`
 }
 
 func (w *WindowFrame) Pos() token.Position { return w.Position }
-func (w *WindowFrame) End() token.Position { return w.Position }
+func (w *WindowFrame) End() token.Position { return w.EndPosition }
 
 // WindowFrameType represents the type of window frame.
 type WindowFrameType string
@@ -1526,12 +2297,13 @@ const (
 // FrameBound represents a window frame bound.
 type FrameBound struct {
 	Position     token.Position  `json:"-"`
+	EndPosition  token.Position  `json:"-"`
 	Type         FrameBoundType  `json:"type"`
 	Offset       Expression      `json:"offset,omitempty"`
 }
 
 func (f *FrameBound) Pos() token.Position { return f.Position }
-func (f *FrameBound) End() token.Position { return f.Position }
+func (f *FrameBound) End() token.Position { return f.EndPosition }
 
 // FrameBoundType represents the type of frame bound.
 type FrameBoundType string
@@ -1547,6 +2319,7 @@ const (
 // BinaryExpr represents a binary expression.
 type BinaryExpr struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	Left          Expression     `json:"left"`
 	Op            string         `json:"op"`
 	Right         Expression     `json:"right"`
@@ -1554,189 +2327,205 @@ type BinaryExpr struct {
 }
 
 func (b *BinaryExpr) Pos() token.Position { return b.Position }
-func (b *BinaryExpr) End() token.Position { return b.Position }
+func (b *BinaryExpr) End() token.Position { return b.EndPosition }
 func (b *BinaryExpr) expressionNode()     {}
 
 // UnaryExpr represents a unary expression.
 type UnaryExpr struct {
-	Position token.Position `json:"-"`
-	Op       string         `json:"op"`
-	Operand  Expression     `json:"operand"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Op          string         `json:"op"`
+	Operand     Expression     `json:"operand"`
 }
 
 func (u *UnaryExpr) Pos() token.Position { return u.Position }
-func (u *UnaryExpr) End() token.Position { return u.Position }
+func (u *UnaryExpr) End() token.Position { return u.EndPosition }
 func (u *UnaryExpr) expressionNode()     {}
 
 // TernaryExpr represents a ternary conditional expression (cond ? then : else).
 type TernaryExpr struct {
-	Position  token.Position `json:"-"`
-	Condition Expression     `json:"condition"`
-	Then      Expression     `json:"then"`
-	Else      Expression     `json:"else"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Condition   Expression     `json:"condition"`
+	Then        Expression     `json:"then"`
+	Else        Expression     `json:"else"`
 }
 
 func (t *TernaryExpr) Pos() token.Position { return t.Position }
-func (t *TernaryExpr) End() token.Position { return t.Position }
+func (t *TernaryExpr) End() token.Position { return t.EndPosition }
 func (t *TernaryExpr) expressionNode()     {}
 
 // Subquery represents a subquery.
 type Subquery struct {
-	Position token.Position `json:"-"`
-	Query    Statement      `json:"query"`
-	Alias    string         `json:"alias,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Query       Statement      `json:"query"`
+	Alias       string         `json:"alias,omitempty"`
 }
 
 func (s *Subquery) Pos() token.Position { return s.Position }
-func (s *Subquery) End() token.Position { return s.Position }
+func (s *Subquery) End() token.Position { return s.EndPosition }
 func (s *Subquery) expressionNode()     {}
 
 // WithElement represents a WITH element (CTE).
 type WithElement struct {
-	Position   token.Position `json:"-"`
-	Name       string         `json:"name"`
-	Query      Expression     `json:"query"`       // Subquery or Expression
-	ScalarWith bool           `json:"scalar_with"` // True for "(expr) AS name" syntax, false for "name AS (SELECT ...)"
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Name        string         `json:"name"`
+	Query       Expression     `json:"query"`       // Subquery or Expression
+	ScalarWith  bool           `json:"scalar_with"` // True for "(expr) AS name" syntax, false for "name AS (SELECT ...)"
 }
 
 func (w *WithElement) Pos() token.Position { return w.Position }
-func (w *WithElement) End() token.Position { return w.Position }
+func (w *WithElement) End() token.Position { return w.EndPosition }
 func (w *WithElement) expressionNode()     {}
 
 // CaseExpr represents a CASE expression.
 type CaseExpr struct {
-	Position    token.Position  `json:"-"`
-	Operand     Expression      `json:"operand,omitempty"` // for CASE x WHEN ...
-	Whens       []*WhenClause   `json:"whens"`
-	Else        Expression      `json:"else,omitempty"`
-	Alias       string          `json:"alias,omitempty"`
-	QuotedAlias bool            `json:"quoted_alias,omitempty"` // true if alias was double-quoted
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Operand     Expression     `json:"operand,omitempty"` // for CASE x WHEN ...
+	Whens       []*WhenClause  `json:"whens"`
+	Else        Expression     `json:"else,omitempty"`
+	Alias       string         `json:"alias,omitempty"`
+	QuotedAlias bool           `json:"quoted_alias,omitempty"` // true if alias was double-quoted
 }
 
 func (c *CaseExpr) Pos() token.Position { return c.Position }
-func (c *CaseExpr) End() token.Position { return c.Position }
+func (c *CaseExpr) End() token.Position { return c.EndPosition }
 func (c *CaseExpr) expressionNode()     {}
 
 // WhenClause represents a WHEN clause in a CASE expression.
 type WhenClause struct {
-	Position  token.Position `json:"-"`
-	Condition Expression     `json:"condition"`
-	Result    Expression     `json:"result"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Condition   Expression     `json:"condition"`
+	Result      Expression     `json:"result"`
 }
 
 func (w *WhenClause) Pos() token.Position { return w.Position }
-func (w *WhenClause) End() token.Position { return w.Position }
+func (w *WhenClause) End() token.Position { return w.EndPosition }
 
 // CastExpr represents a CAST expression.
 type CastExpr struct {
 	Position       token.Position `json:"-"`
+	EndPosition    token.Position `json:"-"`
 	Expr           Expression     `json:"expr"`
 	Type           *DataType      `json:"type,omitempty"`
 	TypeExpr       Expression     `json:"type_expr,omitempty"` // For dynamic type like CAST(x, if(cond, 'Type1', 'Type2'))
 	Alias          string         `json:"alias,omitempty"`
 	OperatorSyntax bool           `json:"operator_syntax,omitempty"` // true if using :: syntax
-	UsedASSyntax   bool           `json:"-"`                         // true if CAST(x AS Type) syntax used (not CAST(x, 'Type'))
+	UsedASSyntax   bool           `json:"used_as_syntax,omitempty"`  // true if CAST(x AS Type) syntax used (not CAST(x, 'Type'))
 }
 
 func (c *CastExpr) Pos() token.Position { return c.Position }
-func (c *CastExpr) End() token.Position { return c.Position }
+func (c *CastExpr) End() token.Position { return c.EndPosition }
 func (c *CastExpr) expressionNode()     {}
 
 // ExtractExpr represents an EXTRACT expression.
 type ExtractExpr struct {
-	Position token.Position `json:"-"`
-	Field    string         `json:"field"` // YEAR, MONTH, DAY, etc.
-	From     Expression     `json:"from"`
-	Alias    string         `json:"alias,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Field       string         `json:"field"` // YEAR, MONTH, DAY, etc.
+	From        Expression     `json:"from"`
+	Alias       string         `json:"alias,omitempty"`
 }
 
 func (e *ExtractExpr) Pos() token.Position { return e.Position }
-func (e *ExtractExpr) End() token.Position { return e.Position }
+func (e *ExtractExpr) End() token.Position { return e.EndPosition }
 func (e *ExtractExpr) expressionNode()     {}
 
 // IntervalExpr represents an INTERVAL expression.
 type IntervalExpr struct {
-	Position token.Position `json:"-"`
-	Value    Expression     `json:"value"`
-	Unit     string         `json:"unit"` // YEAR, MONTH, DAY, HOUR, MINUTE, SECOND, etc.
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Value       Expression     `json:"value"`
+	Unit        string         `json:"unit"` // YEAR, MONTH, DAY, HOUR, MINUTE, SECOND, etc.
 }
 
 func (i *IntervalExpr) Pos() token.Position { return i.Position }
-func (i *IntervalExpr) End() token.Position { return i.Position }
+func (i *IntervalExpr) End() token.Position { return i.EndPosition }
 func (i *IntervalExpr) expressionNode()     {}
 
 // ArrayAccess represents array element access.
 type ArrayAccess struct {
-	Position token.Position `json:"-"`
-	Array    Expression     `json:"array"`
-	Index    Expression     `json:"index"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Array       Expression     `json:"array"`
+	Index       Expression     `json:"index"`
 }
 
 func (a *ArrayAccess) Pos() token.Position { return a.Position }
-func (a *ArrayAccess) End() token.Position { return a.Position }
+func (a *ArrayAccess) End() token.Position { return a.EndPosition }
 func (a *ArrayAccess) expressionNode()     {}
 
 // TupleAccess represents tuple element access.
 type TupleAccess struct {
-	Position token.Position `json:"-"`
-	Tuple    Expression     `json:"tuple"`
-	Index    Expression     `json:"index"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Tuple       Expression     `json:"tuple"`
+	Index       Expression     `json:"index"`
 }
 
 func (t *TupleAccess) Pos() token.Position { return t.Position }
-func (t *TupleAccess) End() token.Position { return t.Position }
+func (t *TupleAccess) End() token.Position { return t.EndPosition }
 func (t *TupleAccess) expressionNode()     {}
 
 // Lambda represents a lambda expression.
 type Lambda struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	Parameters    []string       `json:"parameters"`
 	Body          Expression     `json:"body"`
-	Parenthesized bool           `json:"-"` // True if wrapped in explicit parentheses
+	Parenthesized bool           `json:"parenthesized,omitempty"` // True if wrapped in explicit parentheses
 }
 
 func (l *Lambda) Pos() token.Position { return l.Position }
-func (l *Lambda) End() token.Position { return l.Position }
+func (l *Lambda) End() token.Position { return l.EndPosition }
 func (l *Lambda) expressionNode()     {}
 
 // Parameter represents a parameter placeholder.
 type Parameter struct {
-	Position token.Position `json:"-"`
-	Name     string         `json:"name,omitempty"`
-	Type     *DataType      `json:"type,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Name        string         `json:"name,omitempty"`
+	Type        *DataType      `json:"type,omitempty"`
 }
 
 func (p *Parameter) Pos() token.Position { return p.Position }
-func (p *Parameter) End() token.Position { return p.Position }
+func (p *Parameter) End() token.Position { return p.EndPosition }
 func (p *Parameter) expressionNode()     {}
 
 // AliasedExpr represents an expression with an alias.
 type AliasedExpr struct {
-	Position token.Position `json:"-"`
-	Expr     Expression     `json:"expr"`
-	Alias    string         `json:"alias"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Expr        Expression     `json:"expr"`
+	Alias       string         `json:"alias"`
 }
 
 func (a *AliasedExpr) Pos() token.Position { return a.Position }
-func (a *AliasedExpr) End() token.Position { return a.Position }
+func (a *AliasedExpr) End() token.Position { return a.EndPosition }
 func (a *AliasedExpr) expressionNode()     {}
 
 // BetweenExpr represents a BETWEEN expression.
 type BetweenExpr struct {
-	Position token.Position `json:"-"`
-	Expr     Expression     `json:"expr"`
-	Not      bool           `json:"not,omitempty"`
-	Low      Expression     `json:"low"`
-	High     Expression     `json:"high"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Expr        Expression     `json:"expr"`
+	Not         bool           `json:"not,omitempty"`
+	Low         Expression     `json:"low"`
+	High        Expression     `json:"high"`
 }
 
 func (b *BetweenExpr) Pos() token.Position { return b.Position }
-func (b *BetweenExpr) End() token.Position { return b.Position }
+func (b *BetweenExpr) End() token.Position { return b.EndPosition }
 func (b *BetweenExpr) expressionNode()     {}
 
 // InExpr represents an IN expression.
 type InExpr struct {
 	Position      token.Position `json:"-"`
+	EndPosition   token.Position `json:"-"`
 	Expr          Expression     `json:"expr"`
 	Not           bool           `json:"not,omitempty"`
 	Global        bool           `json:"global,omitempty"`
@@ -1746,23 +2535,25 @@ type InExpr struct {
 }
 
 func (i *InExpr) Pos() token.Position { return i.Position }
-func (i *InExpr) End() token.Position { return i.Position }
+func (i *InExpr) End() token.Position { return i.EndPosition }
 func (i *InExpr) expressionNode()     {}
 
 // IsNullExpr represents an IS NULL or IS NOT NULL expression.
 type IsNullExpr struct {
-	Position token.Position `json:"-"`
-	Expr     Expression     `json:"expr"`
-	Not      bool           `json:"not,omitempty"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Expr        Expression     `json:"expr"`
+	Not         bool           `json:"not,omitempty"`
 }
 
 func (i *IsNullExpr) Pos() token.Position { return i.Position }
-func (i *IsNullExpr) End() token.Position { return i.Position }
+func (i *IsNullExpr) End() token.Position { return i.EndPosition }
 func (i *IsNullExpr) expressionNode()     {}
 
 // LikeExpr represents a LIKE or ILIKE expression.
 type LikeExpr struct {
 	Position        token.Position `json:"-"`
+	EndPosition     token.Position `json:"-"`
 	Expr            Expression     `json:"expr"`
 	Not             bool           `json:"not,omitempty"`
 	CaseInsensitive bool           `json:"case_insensitive,omitempty"` // true for ILIKE
@@ -1771,25 +2562,41 @@ type LikeExpr struct {
 }
 
 func (l *LikeExpr) Pos() token.Position { return l.Position }
-func (l *LikeExpr) End() token.Position { return l.Position }
+func (l *LikeExpr) End() token.Position { return l.EndPosition }
 func (l *LikeExpr) expressionNode()     {}
 
 // ExistsExpr represents an EXISTS expression.
 type ExistsExpr struct {
-	Position token.Position `json:"-"`
-	Query    Statement      `json:"query"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Query       Statement      `json:"query"`
 }
 
 func (e *ExistsExpr) Pos() token.Position { return e.Position }
-func (e *ExistsExpr) End() token.Position { return e.Position }
+func (e *ExistsExpr) End() token.Position { return e.EndPosition }
 func (e *ExistsExpr) expressionNode()     {}
 
 // ParallelWithQuery represents multiple statements executed in parallel with PARALLEL WITH.
 type ParallelWithQuery struct {
-	Position   token.Position `json:"-"`
-	Statements []Statement    `json:"statements"`
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Statements  []Statement    `json:"statements"`
 }
 
 func (p *ParallelWithQuery) Pos() token.Position { return p.Position }
-func (p *ParallelWithQuery) End() token.Position { return p.Position }
+func (p *ParallelWithQuery) End() token.Position { return p.EndPosition }
 func (p *ParallelWithQuery) statementNode()      {}
+
+// BadStatement is a placeholder for a statement that could not be parsed.
+// It spans the tokens up to the next semicolon and keeps whatever part of
+// the statement was parsed before the error.
+type BadStatement struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Partial     Statement      `json:"partial,omitempty"` // Partially parsed statement, if any
+	Error       string         `json:"error"`             // First error reported for the statement
+}
+
+func (b *BadStatement) Pos() token.Position { return b.Position }
+func (b *BadStatement) End() token.Position { return b.EndPosition }
+func (b *BadStatement) statementNode()      {}
@@ -0,0 +1,146 @@
+package ast
+
+import (
+	"sort"
+
+	"github.com/sqlc-dev/doubleclick/token"
+)
+
+// Comment represents a single -- , # or /* */ comment.
+type Comment struct {
+	Position    token.Position `json:"-"`
+	EndPosition token.Position `json:"-"`
+	Text        string         `json:"text"` // Comment text including the comment markers
+}
+
+func (c *Comment) Pos() token.Position { return c.Position }
+func (c *Comment) End() token.Position { return c.EndPosition }
+
+// Comments holds the comments associated with a node.
+type Comments struct {
+	Leading  []*Comment // Comments on the lines before the node
+	Trailing []*Comment // Comments after the node, starting on the line where it ends
+}
+
+// A CommentMap maps AST nodes to the comments associated with them.
+type CommentMap map[Node]*Comments
+
+// NewCommentMap associates each comment with the nearest node of the
+// statements it was parsed with. Comments are expected in source order,
+// as returned by Parser.Comments.
+//
+// A comment that starts on the line where a node ends is a trailing
+// comment of that node. Otherwise it is a leading comment of the node
+// that follows it. Comments are attached to the outermost node that
+// ends (or starts) next to them, and never leave the innermost node
+// that encloses them, so a comment between two statements belongs to a
+// statement, while a comment between two columns belongs to a column.
+func NewCommentMap(stmts []Statement, comments []*Comment) CommentMap {
+	cmap := make(CommentMap)
+	nodes := make(map[Statement][]Node)
+	for _, c := range comments {
+		candidates := make([]Node, 0, len(stmts))
+		var enclosing Node
+		for _, stmt := range stmts {
+			if stmt == nil || !hasSpan(stmt) {
+				continue
+			}
+			if stmt.Pos().Offset < c.Pos().Offset && c.End().Offset <= stmt.End().Offset {
+				if _, ok := nodes[stmt]; !ok {
+					nodes[stmt] = spannedNodes(stmt)
+				}
+				candidates = nodes[stmt]
+				enclosing = stmt
+				break
+			}
+			candidates = append(candidates, stmt)
+		}
+		cmap.add(c, candidates, enclosing)
+	}
+	return cmap
+}
+
+// add attaches c to the nearest of nodes, restricted to the innermost
+// node that encloses c, falling back to that enclosing node itself.
+func (cmap CommentMap) add(c *Comment, nodes []Node, enclosing Node) {
+	for _, n := range nodes {
+		if n.Pos().Offset < c.Pos().Offset && c.End().Offset <= n.End().Offset && within(n, enclosing) {
+			enclosing = n
+		}
+	}
+
+	var prev, next Node
+	for _, n := range nodes {
+		if n == enclosing || !within(n, enclosing) {
+			continue
+		}
+		if n.End().Offset <= c.Pos().Offset {
+			if prev == nil || n.End().Offset > prev.End().Offset ||
+				n.End().Offset == prev.End().Offset && n.Pos().Offset < prev.Pos().Offset {
+				prev = n
+			}
+		}
+		if n.Pos().Offset >= c.End().Offset {
+			if next == nil || n.Pos().Offset < next.Pos().Offset ||
+				n.Pos().Offset == next.Pos().Offset && n.End().Offset > next.End().Offset {
+				next = n
+			}
+		}
+	}
+
+	switch {
+	case prev != nil && prev.End().Line == c.Pos().Line:
+		cmap.get(prev).Trailing = append(cmap.get(prev).Trailing, c)
+	case next != nil:
+		cmap.get(next).Leading = append(cmap.get(next).Leading, c)
+	case prev != nil:
+		cmap.get(prev).Trailing = append(cmap.get(prev).Trailing, c)
+	case enclosing != nil:
+		cmap.get(enclosing).Trailing = append(cmap.get(enclosing).Trailing, c)
+	}
+}
+
+func (cmap CommentMap) get(n Node) *Comments {
+	cs, ok := cmap[n]
+	if !ok {
+		cs = new(Comments)
+		cmap[n] = cs
+	}
+	return cs
+}
+
+// Comments returns all comments in the map, in source order.
+func (cmap CommentMap) Comments() []*Comment {
+	var list []*Comment
+	for _, cs := range cmap {
+		list = append(list, cs.Leading...)
+		list = append(list, cs.Trailing...)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Pos().Offset < list[j].Pos().Offset
+	})
+	return list
+}
+
+// spannedNodes returns root and all of its descendants that cover at
+// least one byte of source.
+func spannedNodes(root Node) []Node {
+	var list []Node
+	Inspect(root, func(n Node) bool {
+		if n != nil && hasSpan(n) {
+			list = append(list, n)
+		}
+		return true
+	})
+	return list
+}
+
+func hasSpan(n Node) bool {
+	return n.End().Offset > n.Pos().Offset
+}
+
+// within reports whether n lies inside outer. Every node lies inside a
+// nil outer node.
+func within(n, outer Node) bool {
+	return outer == nil || outer.Pos().Offset <= n.Pos().Offset && n.End().Offset <= outer.End().Offset
+}
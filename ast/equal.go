@@ -0,0 +1,113 @@
+package ast
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/sqlc-dev/doubleclick/token"
+)
+
+// ignoredFields lists fields that only record how a node was written,
+// not what it means, and are skipped by Equal.
+var ignoredFields = map[string]bool{
+	"Parenthesized":  true, // (x) vs x
+	"SpacedCommas":   true, // [1, 2] vs [1,2]
+	"SpacedBrackets": true, // [ 1 ] vs [1]
+}
+
+var positionType = reflect.TypeOf(token.Position{})
+
+// Equal reports whether a and b are structurally equal. Source positions
+// and purely syntactic flags such as Parenthesized and SpacedCommas are
+// ignored, so two parses of the same query that differ only in spacing
+// or redundant parentheses compare equal. NaN float literals are equal
+// to each other. A nil slice differs from an empty one, since the parser
+// uses that to tell an empty parameter list, as in medianGK()(x), from
+// none, as in medianGK(x).
+func Equal(a, b Node) bool {
+	return equalValue(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func equalValue(a, b reflect.Value) bool {
+	a, b = nonNilElem(a), nonNilElem(b)
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		return equalValue(a.Elem(), b.Elem())
+
+	case reflect.Struct:
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Type == positionType || ignoredFields[f.Name] {
+				continue
+			}
+			if !equalValue(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equalValue(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !equalValue(iter.Value(), bv) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Float32, reflect.Float64:
+		x, y := a.Float(), b.Float()
+		return x == y || math.IsNaN(x) && math.IsNaN(y)
+
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+
+	case reflect.String:
+		return a.String() == b.String()
+
+	default:
+		return a.Equal(b)
+	}
+}
+
+// nonNilElem unwraps an interface value and returns the zero Value for
+// nil interfaces and nil pointers, so that a typed nil node compares
+// equal to a nil one.
+func nonNilElem(v reflect.Value) reflect.Value {
+	if v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.IsValid() && v.Kind() == reflect.Pointer && v.IsNil() {
+		return reflect.Value{}
+	}
+	return v
+}
@@ -0,0 +1,916 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Every node is encoded as a JSON object whose "node" member names the
+// node type, e.g. {"node":"Identifier","parts":["a"]}. The tag lets
+// UnmarshalNode rebuild the concrete type behind the Expression and
+// Statement interface fields.
+
+// nodeTypes maps the "node" tag of an encoded node to a constructor for
+// the corresponding node type.
+var nodeTypes = map[string]func() Node{
+	"SelectWithUnionQuery":           func() Node { return new(SelectWithUnionQuery) },
+	"SelectIntersectExceptQuery":     func() Node { return new(SelectIntersectExceptQuery) },
+	"SelectQuery":                    func() Node { return new(SelectQuery) },
+	"ArrayJoinClause":                func() Node { return new(ArrayJoinClause) },
+	"WindowDefinition":               func() Node { return new(WindowDefinition) },
+	"IntoOutfileClause":              func() Node { return new(IntoOutfileClause) },
+	"TablesInSelectQuery":            func() Node { return new(TablesInSelectQuery) },
+	"TablesInSelectQueryElement":     func() Node { return new(TablesInSelectQueryElement) },
+	"TableExpression":                func() Node { return new(TableExpression) },
+	"SampleClause":                   func() Node { return new(SampleClause) },
+	"TableJoin":                      func() Node { return new(TableJoin) },
+	"OrderByElement":                 func() Node { return new(OrderByElement) },
+	"InterpolateElement":             func() Node { return new(InterpolateElement) },
+	"SettingExpr":                    func() Node { return new(SettingExpr) },
+	"InsertQuery":                    func() Node { return new(InsertQuery) },
+	"CreateTableQuery":               func() Node { return new(CreateTableQuery) },
+	"CreateViewQuery":                func() Node { return new(CreateViewQuery) },
+	"CreateMaterializedViewQuery":    func() Node { return new(CreateMaterializedViewQuery) },
+	"CreateWindowViewQuery":          func() Node { return new(CreateWindowViewQuery) },
+	"CreateDatabaseQuery":            func() Node { return new(CreateDatabaseQuery) },
+	"CreateFunctionQuery":            func() Node { return new(CreateFunctionQuery) },
+	"CreateDictionaryQuery":          func() Node { return new(CreateDictionaryQuery) },
+	"ColumnDeclaration":              func() Node { return new(ColumnDeclaration) },
+	"DictionaryAttributeDeclaration": func() Node { return new(DictionaryAttributeDeclaration) },
+	"DictionaryDefinition":           func() Node { return new(DictionaryDefinition) },
+	"DictionarySource":               func() Node { return new(DictionarySource) },
+	"KeyValuePair":                   func() Node { return new(KeyValuePair) },
+	"DictionaryLifetime":             func() Node { return new(DictionaryLifetime) },
+	"DictionaryLayout":               func() Node { return new(DictionaryLayout) },
+	"DictionaryRange":                func() Node { return new(DictionaryRange) },
+	"DataType":                       func() Node { return new(DataType) },
+	"ObjectTypeArgument":             func() Node { return new(ObjectTypeArgument) },
+	"NameTypePair":                   func() Node { return new(NameTypePair) },
+	"CodecExpr":                      func() Node { return new(CodecExpr) },
+	"IndexDefinition":                func() Node { return new(IndexDefinition) },
+	"Constraint":                     func() Node { return new(Constraint) },
+	"EngineClause":                   func() Node { return new(EngineClause) },
+	"TTLClause":                      func() Node { return new(TTLClause) },
+	"TTLElement":                     func() Node { return new(TTLElement) },
+	"DropTableQuery":                 func() Node { return new(DropTableQuery) },
+	"DropViewQuery":                  func() Node { return new(DropViewQuery) },
+	"DropDictionaryQuery":            func() Node { return new(DropDictionaryQuery) },
+	"DropDatabaseQuery":              func() Node { return new(DropDatabaseQuery) },
+	"DropFunctionQuery":              func() Node { return new(DropFunctionQuery) },
+	"DropIndexQuery":                 func() Node { return new(DropIndexQuery) },
+	"DropUserQuery":                  func() Node { return new(DropUserQuery) },
+	"DropQuotaQuery":                 func() Node { return new(DropQuotaQuery) },
+	"UndropQuery":                    func() Node { return new(UndropQuery) },
+	"UpdateQuery":                    func() Node { return new(UpdateQuery) },
+	"AlterQuery":                     func() Node { return new(AlterQuery) },
+	"AlterCommand":                   func() Node { return new(AlterCommand) },
+	"Projection":                     func() Node { return new(Projection) },
+	"ProjectionSelectQuery":          func() Node { return new(ProjectionSelectQuery) },
+	"Assignment":                     func() Node { return new(Assignment) },
+	"TruncateQuery":                  func() Node { return new(TruncateQuery) },
+	"DeleteQuery":                    func() Node { return new(DeleteQuery) },
+	"UseQuery":                       func() Node { return new(UseQuery) },
+	"DetachQuery":                    func() Node { return new(DetachQuery) },
+	"AttachQuery":                    func() Node { return new(AttachQuery) },
+	"BackupQuery":                    func() Node { return new(BackupQuery) },
+	"RestoreQuery":                   func() Node { return new(RestoreQuery) },
+	"DescribeQuery":                  func() Node { return new(DescribeQuery) },
+	"ShowQuery":                      func() Node { return new(ShowQuery) },
+	"ExplainQuery":                   func() Node { return new(ExplainQuery) },
+	"SetQuery":                       func() Node { return new(SetQuery) },
+	"OptimizeQuery":                  func() Node { return new(OptimizeQuery) },
+	"CheckQuery":                     func() Node { return new(CheckQuery) },
+	"SystemQuery":                    func() Node { return new(SystemQuery) },
+	"TransactionControlQuery":        func() Node { return new(TransactionControlQuery) },
+	"RenamePair":                     func() Node { return new(RenamePair) },
+	"RenameQuery":                    func() Node { return new(RenameQuery) },
+	"ExchangeQuery":                  func() Node { return new(ExchangeQuery) },
+	"ExistsQuery":                    func() Node { return new(ExistsQuery) },
+	"GrantQuery":                     func() Node { return new(GrantQuery) },
+	"GrantElement":                   func() Node { return new(GrantElement) },
+	"Privilege":                      func() Node { return new(Privilege) },
+	"RoleSet":                        func() Node { return new(RoleSet) },
+	"ShowGrantsQuery":                func() Node { return new(ShowGrantsQuery) },
+	"KillQuery":                      func() Node { return new(KillQuery) },
+	"ShowPrivilegesQuery":            func() Node { return new(ShowPrivilegesQuery) },
+	"ShowCreateQuotaQuery":           func() Node { return new(ShowCreateQuotaQuery) },
+	"CreateQuotaQuery":               func() Node { return new(CreateQuotaQuery) },
+	"QuotaInterval":                  func() Node { return new(QuotaInterval) },
+	"QuotaLimit":                     func() Node { return new(QuotaLimit) },
+	"CreateSettingsProfileQuery":     func() Node { return new(CreateSettingsProfileQuery) },
+	"AlterSettingsProfileQuery":      func() Node { return new(AlterSettingsProfileQuery) },
+	"DropSettingsProfileQuery":       func() Node { return new(DropSettingsProfileQuery) },
+	"CreateNamedCollectionQuery":     func() Node { return new(CreateNamedCollectionQuery) },
+	"AlterNamedCollectionQuery":      func() Node { return new(AlterNamedCollectionQuery) },
+	"DropNamedCollectionQuery":       func() Node { return new(DropNamedCollectionQuery) },
+	"ShowCreateSettingsProfileQuery": func() Node { return new(ShowCreateSettingsProfileQuery) },
+	"CreateRowPolicyQuery":           func() Node { return new(CreateRowPolicyQuery) },
+	"RowPolicyName":                  func() Node { return new(RowPolicyName) },
+	"DropRowPolicyQuery":             func() Node { return new(DropRowPolicyQuery) },
+	"ShowCreateRowPolicyQuery":       func() Node { return new(ShowCreateRowPolicyQuery) },
+	"CreateRoleQuery":                func() Node { return new(CreateRoleQuery) },
+	"CreateUserQuery":                func() Node { return new(CreateUserQuery) },
+	"AuthenticationMethod":           func() Node { return new(AuthenticationMethod) },
+	"SSHKey":                         func() Node { return new(SSHKey) },
+	"UserHost":                       func() Node { return new(UserHost) },
+	"DropRoleQuery":                  func() Node { return new(DropRoleQuery) },
+	"ShowCreateRoleQuery":            func() Node { return new(ShowCreateRoleQuery) },
+	"SetRoleQuery":                   func() Node { return new(SetRoleQuery) },
+	"CreateResourceQuery":            func() Node { return new(CreateResourceQuery) },
+	"ResourceOperation":              func() Node { return new(ResourceOperation) },
+	"DropResourceQuery":              func() Node { return new(DropResourceQuery) },
+	"CreateWorkloadQuery":            func() Node { return new(CreateWorkloadQuery) },
+	"DropWorkloadQuery":              func() Node { return new(DropWorkloadQuery) },
+	"CreateIndexQuery":               func() Node { return new(CreateIndexQuery) },
+	"Identifier":                     func() Node { return new(Identifier) },
+	"TableIdentifier":                func() Node { return new(TableIdentifier) },
+	"Literal":                        func() Node { return new(Literal) },
+	"Asterisk":                       func() Node { return new(Asterisk) },
+	"ReplaceExpr":                    func() Node { return new(ReplaceExpr) },
+	"ColumnTransformer":              func() Node { return new(ColumnTransformer) },
+	"ColumnsMatcher":                 func() Node { return new(ColumnsMatcher) },
+	"FunctionCall":                   func() Node { return new(FunctionCall) },
+	"WindowSpec":                     func() Node { return new(WindowSpec) },
+	"WindowFrame":                    func() Node { return new(WindowFrame) },
+	"FrameBound":                     func() Node { return new(FrameBound) },
+	"BinaryExpr":                     func() Node { return new(BinaryExpr) },
+	"UnaryExpr":                      func() Node { return new(UnaryExpr) },
+	"TernaryExpr":                    func() Node { return new(TernaryExpr) },
+	"Subquery":                       func() Node { return new(Subquery) },
+	"WithElement":                    func() Node { return new(WithElement) },
+	"CaseExpr":                       func() Node { return new(CaseExpr) },
+	"WhenClause":                     func() Node { return new(WhenClause) },
+	"CastExpr":                       func() Node { return new(CastExpr) },
+	"ExtractExpr":                    func() Node { return new(ExtractExpr) },
+	"IntervalExpr":                   func() Node { return new(IntervalExpr) },
+	"ArrayAccess":                    func() Node { return new(ArrayAccess) },
+	"TupleAccess":                    func() Node { return new(TupleAccess) },
+	"Lambda":                         func() Node { return new(Lambda) },
+	"Parameter":                      func() Node { return new(Parameter) },
+	"AliasedExpr":                    func() Node { return new(AliasedExpr) },
+	"BetweenExpr":                    func() Node { return new(BetweenExpr) },
+	"InExpr":                         func() Node { return new(InExpr) },
+	"IsNullExpr":                     func() Node { return new(IsNullExpr) },
+	"LikeExpr":                       func() Node { return new(LikeExpr) },
+	"ExistsExpr":                     func() Node { return new(ExistsExpr) },
+	"ParallelWithQuery":              func() Node { return new(ParallelWithQuery) },
+	"BadStatement":                   func() Node { return new(BadStatement) },
+	"Comment":                        func() Node { return new(Comment) },
+}
+
+// UnmarshalNode decodes a node encoded by its MarshalJSON method. The
+// concrete type is taken from the "node" tag. A JSON null decodes to a
+// nil Node.
+func UnmarshalNode(data []byte) (Node, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	var tag struct {
+		Node string `json:"node"`
+	}
+	if err := json.Unmarshal(data, &tag); err != nil {
+		return nil, err
+	}
+	newNode, ok := nodeTypes[tag.Node]
+	if !ok {
+		return nil, fmt.Errorf("ast: unknown node type %q", tag.Node)
+	}
+	n := newNode()
+	if err := json.Unmarshal(data, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// UnmarshalStatement decodes a statement encoded by its MarshalJSON
+// method.
+func UnmarshalStatement(data []byte) (Statement, error) {
+	n, err := UnmarshalNode(data)
+	if err != nil || n == nil {
+		return nil, err
+	}
+	stmt, ok := n.(Statement)
+	if !ok {
+		return nil, fmt.Errorf("ast: %T is not a statement", n)
+	}
+	return stmt, nil
+}
+
+// jsonField returns the JSON member name of a struct field, whether the
+// field is omitted when empty, and false if the field is not encoded.
+func jsonField(f reflect.StructField) (name string, omitEmpty, ok bool) {
+	if !f.IsExported() {
+		return "", false, false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, strings.Contains(opts, "omitempty"), true
+}
+
+// isEmptyValue reports whether v is empty in the sense of the omitempty
+// option of encoding/json, except that only nil slices are empty: the
+// parser distinguishes f() from f, so an empty list must survive.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.IsNil()
+	case reflect.Array, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Pointer:
+		return v.IsZero()
+	case reflect.Interface:
+		// A nil node stored in an interface field counts as empty.
+		return v.IsNil() || v.Elem().Kind() == reflect.Pointer && v.Elem().IsNil()
+	}
+	return false
+}
+
+// marshalNode encodes n as a JSON object tagged with its node type.
+// Fields are written in declaration order using their json struct tags.
+func marshalNode(n Node) ([]byte, error) {
+	v := reflect.ValueOf(n).Elem()
+	t := v.Type()
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"node":`)
+	buf.WriteString(strconv.Quote(t.Name()))
+	for i := 0; i < t.NumField(); i++ {
+		name, omitEmpty, ok := jsonField(t.Field(i))
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		if omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		b, err := json.Marshal(jsonValue(fv.Interface()))
+		if err != nil {
+			return nil, fmt.Errorf("ast: encoding %s.%s: %w", t.Name(), t.Field(i).Name, err)
+		}
+		buf.WriteByte(',')
+		buf.WriteString(strconv.Quote(name))
+		buf.WriteByte(':')
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonValue replaces values that JSON cannot represent. NaN, +Inf and
+// -Inf become their string form, and strings that are not valid UTF-8,
+// such as '\xFF' literals or identifiers, become a {"base64": ...}
+// object.
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN"
+		case math.IsInf(v, 1):
+			return "+Inf"
+		case math.IsInf(v, -1):
+			return "-Inf"
+		}
+	case string:
+		if !utf8.ValidString(v) {
+			return rawString{Base64: []byte(v)}
+		}
+	case []string:
+		for _, e := range v {
+			if !utf8.ValidString(e) {
+				elems := make([]interface{}, len(v))
+				for i, e := range v {
+					elems[i] = jsonValue(e)
+				}
+				return elems
+			}
+		}
+	}
+	return v
+}
+
+// rawString is the JSON form of a string that is not valid UTF-8.
+type rawString struct {
+	Base64 []byte `json:"base64"`
+}
+
+// unmarshalNode decodes a JSON object produced by marshalNode into n.
+// Members that are missing from data leave the corresponding field
+// unchanged.
+func unmarshalNode(data []byte, n Node) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(n).Elem()
+	t := v.Type()
+	if raw, ok := members["node"]; ok {
+		var tag string
+		if err := json.Unmarshal(raw, &tag); err != nil {
+			return err
+		}
+		if tag != t.Name() {
+			return fmt.Errorf("ast: cannot decode %s into %s", tag, t.Name())
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, ok := jsonField(t.Field(i))
+		if !ok {
+			continue
+		}
+		raw, ok := members[name]
+		if !ok {
+			continue
+		}
+		if err := decodeValue(raw, v.Field(i)); err != nil {
+			return fmt.Errorf("ast: decoding %s.%s: %w", t.Name(), t.Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+var (
+	nodeInterface  = reflect.TypeOf((*Node)(nil)).Elem()
+	emptyInterface = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+// decodeValue decodes raw into dst. Interface fields holding nodes are
+// decoded with UnmarshalNode, and slices are decoded element by element
+// so that slices of interfaces work too. Everything else is left to
+// encoding/json.
+func decodeValue(raw json.RawMessage, dst reflect.Value) error {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch {
+	case dst.Type() == emptyInterface:
+		v, err := decodeAny(raw)
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			dst.Set(reflect.ValueOf(v))
+		}
+		return nil
+
+	case dst.Kind() == reflect.Interface && dst.Type().Implements(nodeInterface):
+		n, err := UnmarshalNode(raw)
+		if err != nil {
+			return err
+		}
+		nv := reflect.ValueOf(n)
+		if !nv.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("%T does not implement %s", n, dst.Type())
+		}
+		dst.Set(nv)
+		return nil
+
+	case dst.Kind() == reflect.String && bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")):
+		var s rawString
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		dst.SetString(string(s.Base64))
+		return nil
+
+	case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() != reflect.Uint8:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(dst.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := decodeValue(elem, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+		return nil
+	}
+
+	return json.Unmarshal(raw, dst.Addr().Interface())
+}
+
+// decodeAny decodes a value stored in an interface{} field. Arrays are
+// decoded as []Expression, objects as strings written by jsonValue, and
+// numbers are kept as json.Number so the owning node can choose the Go
+// type.
+func decodeAny(raw json.RawMessage) (interface{}, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '[' {
+		var exprs []Expression
+		err := decodeValue(raw, reflect.ValueOf(&exprs).Elem())
+		return exprs, err
+	}
+	if len(raw) > 0 && raw[0] == '{' {
+		var s rawString
+		err := json.Unmarshal(raw, &s)
+		return string(s.Base64), err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	err := dec.Decode(&v)
+	return v, err
+}
+
+// literalValue converts a value decoded by decodeAny to the Go type the
+// parser uses for a literal of type t.
+func literalValue(v interface{}, t LiteralType) (interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		if t == LiteralArray || t == LiteralTuple {
+			return []Expression(nil), nil
+		}
+	case json.Number:
+		if t != LiteralFloat {
+			if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+				return i, nil
+			}
+			if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+				return u, nil
+			}
+		}
+		return strconv.ParseFloat(string(v), 64)
+	case string:
+		if t == LiteralFloat {
+			switch v {
+			case "NaN":
+				return math.NaN(), nil
+			case "+Inf":
+				return math.Inf(1), nil
+			case "-Inf":
+				return math.Inf(-1), nil
+			}
+		}
+	}
+	return v, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numeric values are restored
+// as int64, uint64 or float64 depending on the literal type.
+func (l *Literal) UnmarshalJSON(data []byte) error {
+	if err := unmarshalNode(data, l); err != nil {
+		return err
+	}
+	v, err := literalValue(l.Value, l.Type)
+	if err != nil {
+		return fmt.Errorf("ast: decoding Literal.Value: %w", err)
+	}
+	l.Value = v
+	return nil
+}
+
+// MarshalJSON and UnmarshalJSON implementations for all nodes.
+
+func (s *SelectWithUnionQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SelectWithUnionQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (s *SelectIntersectExceptQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SelectIntersectExceptQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (s *SelectQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SelectQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (a *ArrayJoinClause) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *ArrayJoinClause) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (w *WindowDefinition) MarshalJSON() ([]byte, error)    { return marshalNode(w) }
+func (w *WindowDefinition) UnmarshalJSON(data []byte) error { return unmarshalNode(data, w) }
+
+func (i *IntoOutfileClause) MarshalJSON() ([]byte, error)    { return marshalNode(i) }
+func (i *IntoOutfileClause) UnmarshalJSON(data []byte) error { return unmarshalNode(data, i) }
+
+func (t *TablesInSelectQuery) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TablesInSelectQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (t *TablesInSelectQueryElement) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TablesInSelectQueryElement) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (t *TableExpression) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TableExpression) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (s *SampleClause) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SampleClause) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (t *TableJoin) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TableJoin) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (o *OrderByElement) MarshalJSON() ([]byte, error)    { return marshalNode(o) }
+func (o *OrderByElement) UnmarshalJSON(data []byte) error { return unmarshalNode(data, o) }
+
+func (i *InterpolateElement) MarshalJSON() ([]byte, error)    { return marshalNode(i) }
+func (i *InterpolateElement) UnmarshalJSON(data []byte) error { return unmarshalNode(data, i) }
+
+func (s *SettingExpr) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SettingExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (i *InsertQuery) MarshalJSON() ([]byte, error)    { return marshalNode(i) }
+func (i *InsertQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, i) }
+
+func (c *CreateTableQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateTableQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *CreateViewQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateViewQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *CreateMaterializedViewQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateMaterializedViewQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *CreateWindowViewQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateWindowViewQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *CreateDatabaseQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateDatabaseQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *CreateFunctionQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateFunctionQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *CreateDictionaryQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateDictionaryQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *ColumnDeclaration) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *ColumnDeclaration) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (d *DictionaryAttributeDeclaration) MarshalJSON() ([]byte, error) { return marshalNode(d) }
+func (d *DictionaryAttributeDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalNode(data, d)
+}
+
+func (d *DictionaryDefinition) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DictionaryDefinition) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DictionarySource) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DictionarySource) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (k *KeyValuePair) MarshalJSON() ([]byte, error)    { return marshalNode(k) }
+func (k *KeyValuePair) UnmarshalJSON(data []byte) error { return unmarshalNode(data, k) }
+
+func (d *DictionaryLifetime) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DictionaryLifetime) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DictionaryLayout) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DictionaryLayout) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DictionaryRange) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DictionaryRange) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DataType) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DataType) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (o *ObjectTypeArgument) MarshalJSON() ([]byte, error)    { return marshalNode(o) }
+func (o *ObjectTypeArgument) UnmarshalJSON(data []byte) error { return unmarshalNode(data, o) }
+
+func (n *NameTypePair) MarshalJSON() ([]byte, error)    { return marshalNode(n) }
+func (n *NameTypePair) UnmarshalJSON(data []byte) error { return unmarshalNode(data, n) }
+
+func (c *CodecExpr) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CodecExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (i *IndexDefinition) MarshalJSON() ([]byte, error)    { return marshalNode(i) }
+func (i *IndexDefinition) UnmarshalJSON(data []byte) error { return unmarshalNode(data, i) }
+
+func (c *Constraint) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *Constraint) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (e *EngineClause) MarshalJSON() ([]byte, error)    { return marshalNode(e) }
+func (e *EngineClause) UnmarshalJSON(data []byte) error { return unmarshalNode(data, e) }
+
+func (t *TTLClause) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TTLClause) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (t *TTLElement) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TTLElement) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (d *DropTableQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropTableQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DropViewQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropViewQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DropDictionaryQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropDictionaryQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DropDatabaseQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropDatabaseQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DropFunctionQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropFunctionQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DropIndexQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropIndexQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DropUserQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropUserQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (d *DropQuotaQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropQuotaQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (u *UndropQuery) MarshalJSON() ([]byte, error)    { return marshalNode(u) }
+func (u *UndropQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, u) }
+
+func (u *UpdateQuery) MarshalJSON() ([]byte, error)    { return marshalNode(u) }
+func (u *UpdateQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, u) }
+
+func (a *AlterQuery) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *AlterQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (a *AlterCommand) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *AlterCommand) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (p *Projection) MarshalJSON() ([]byte, error)    { return marshalNode(p) }
+func (p *Projection) UnmarshalJSON(data []byte) error { return unmarshalNode(data, p) }
+
+func (p *ProjectionSelectQuery) MarshalJSON() ([]byte, error)    { return marshalNode(p) }
+func (p *ProjectionSelectQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, p) }
+
+func (a *Assignment) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *Assignment) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (t *TruncateQuery) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TruncateQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (d *DeleteQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DeleteQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (u *UseQuery) MarshalJSON() ([]byte, error)    { return marshalNode(u) }
+func (u *UseQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, u) }
+
+func (d *DetachQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DetachQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (a *AttachQuery) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *AttachQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (b *BackupQuery) MarshalJSON() ([]byte, error)    { return marshalNode(b) }
+func (b *BackupQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, b) }
+
+func (r *RestoreQuery) MarshalJSON() ([]byte, error)    { return marshalNode(r) }
+func (r *RestoreQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, r) }
+
+func (d *DescribeQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DescribeQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (s *ShowQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *ShowQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (e *ExplainQuery) MarshalJSON() ([]byte, error)    { return marshalNode(e) }
+func (e *ExplainQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, e) }
+
+func (s *SetQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SetQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (o *OptimizeQuery) MarshalJSON() ([]byte, error)    { return marshalNode(o) }
+func (o *OptimizeQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, o) }
+
+func (c *CheckQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CheckQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (s *SystemQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SystemQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (t *TransactionControlQuery) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TransactionControlQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (r *RenamePair) MarshalJSON() ([]byte, error)    { return marshalNode(r) }
+func (r *RenamePair) UnmarshalJSON(data []byte) error { return unmarshalNode(data, r) }
+
+func (r *RenameQuery) MarshalJSON() ([]byte, error)    { return marshalNode(r) }
+func (r *RenameQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, r) }
+
+func (e *ExchangeQuery) MarshalJSON() ([]byte, error)    { return marshalNode(e) }
+func (e *ExchangeQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, e) }
+
+func (e *ExistsQuery) MarshalJSON() ([]byte, error)    { return marshalNode(e) }
+func (e *ExistsQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, e) }
+
+func (g *GrantQuery) MarshalJSON() ([]byte, error)    { return marshalNode(g) }
+func (g *GrantQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, g) }
+
+func (g *GrantElement) MarshalJSON() ([]byte, error)    { return marshalNode(g) }
+func (g *GrantElement) UnmarshalJSON(data []byte) error { return unmarshalNode(data, g) }
+
+func (p *Privilege) MarshalJSON() ([]byte, error)    { return marshalNode(p) }
+func (p *Privilege) UnmarshalJSON(data []byte) error { return unmarshalNode(data, p) }
+
+func (r *RoleSet) MarshalJSON() ([]byte, error)    { return marshalNode(r) }
+func (r *RoleSet) UnmarshalJSON(data []byte) error { return unmarshalNode(data, r) }
+
+func (s *ShowGrantsQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *ShowGrantsQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (k *KillQuery) MarshalJSON() ([]byte, error)    { return marshalNode(k) }
+func (k *KillQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, k) }
+
+func (s *ShowPrivilegesQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *ShowPrivilegesQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (s *ShowCreateQuotaQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *ShowCreateQuotaQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (c *CreateQuotaQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateQuotaQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (q *QuotaInterval) MarshalJSON() ([]byte, error)    { return marshalNode(q) }
+func (q *QuotaInterval) UnmarshalJSON(data []byte) error { return unmarshalNode(data, q) }
+
+func (q *QuotaLimit) MarshalJSON() ([]byte, error)    { return marshalNode(q) }
+func (q *QuotaLimit) UnmarshalJSON(data []byte) error { return unmarshalNode(data, q) }
+
+func (c *CreateSettingsProfileQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateSettingsProfileQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (a *AlterSettingsProfileQuery) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *AlterSettingsProfileQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (d *DropSettingsProfileQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropSettingsProfileQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (c *CreateNamedCollectionQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateNamedCollectionQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (a *AlterNamedCollectionQuery) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *AlterNamedCollectionQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (d *DropNamedCollectionQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropNamedCollectionQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (s *ShowCreateSettingsProfileQuery) MarshalJSON() ([]byte, error) { return marshalNode(s) }
+func (s *ShowCreateSettingsProfileQuery) UnmarshalJSON(data []byte) error {
+	return unmarshalNode(data, s)
+}
+
+func (c *CreateRowPolicyQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateRowPolicyQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (r *RowPolicyName) MarshalJSON() ([]byte, error)    { return marshalNode(r) }
+func (r *RowPolicyName) UnmarshalJSON(data []byte) error { return unmarshalNode(data, r) }
+
+func (d *DropRowPolicyQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropRowPolicyQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (s *ShowCreateRowPolicyQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *ShowCreateRowPolicyQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (c *CreateRoleQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateRoleQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *CreateUserQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateUserQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (a *AuthenticationMethod) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *AuthenticationMethod) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (s *SSHKey) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SSHKey) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (u *UserHost) MarshalJSON() ([]byte, error)    { return marshalNode(u) }
+func (u *UserHost) UnmarshalJSON(data []byte) error { return unmarshalNode(data, u) }
+
+func (d *DropRoleQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropRoleQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (s *ShowCreateRoleQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *ShowCreateRoleQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (s *SetRoleQuery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *SetRoleQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (c *CreateResourceQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateResourceQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (r *ResourceOperation) MarshalJSON() ([]byte, error)    { return marshalNode(r) }
+func (r *ResourceOperation) UnmarshalJSON(data []byte) error { return unmarshalNode(data, r) }
+
+func (d *DropResourceQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropResourceQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (c *CreateWorkloadQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateWorkloadQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (d *DropWorkloadQuery) MarshalJSON() ([]byte, error)    { return marshalNode(d) }
+func (d *DropWorkloadQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, d) }
+
+func (c *CreateIndexQuery) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CreateIndexQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (i *Identifier) MarshalJSON() ([]byte, error)    { return marshalNode(i) }
+func (i *Identifier) UnmarshalJSON(data []byte) error { return unmarshalNode(data, i) }
+
+func (t *TableIdentifier) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TableIdentifier) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (l *Literal) MarshalJSON() ([]byte, error) { return marshalNode(l) }
+
+func (a *Asterisk) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *Asterisk) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (r *ReplaceExpr) MarshalJSON() ([]byte, error)    { return marshalNode(r) }
+func (r *ReplaceExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, r) }
+
+func (c *ColumnTransformer) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *ColumnTransformer) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (c *ColumnsMatcher) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *ColumnsMatcher) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (f *FunctionCall) MarshalJSON() ([]byte, error)    { return marshalNode(f) }
+func (f *FunctionCall) UnmarshalJSON(data []byte) error { return unmarshalNode(data, f) }
+
+func (w *WindowSpec) MarshalJSON() ([]byte, error)    { return marshalNode(w) }
+func (w *WindowSpec) UnmarshalJSON(data []byte) error { return unmarshalNode(data, w) }
+
+func (w *WindowFrame) MarshalJSON() ([]byte, error)    { return marshalNode(w) }
+func (w *WindowFrame) UnmarshalJSON(data []byte) error { return unmarshalNode(data, w) }
+
+func (f *FrameBound) MarshalJSON() ([]byte, error)    { return marshalNode(f) }
+func (f *FrameBound) UnmarshalJSON(data []byte) error { return unmarshalNode(data, f) }
+
+func (b *BinaryExpr) MarshalJSON() ([]byte, error)    { return marshalNode(b) }
+func (b *BinaryExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, b) }
+
+func (u *UnaryExpr) MarshalJSON() ([]byte, error)    { return marshalNode(u) }
+func (u *UnaryExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, u) }
+
+func (t *TernaryExpr) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TernaryExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (s *Subquery) MarshalJSON() ([]byte, error)    { return marshalNode(s) }
+func (s *Subquery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, s) }
+
+func (w *WithElement) MarshalJSON() ([]byte, error)    { return marshalNode(w) }
+func (w *WithElement) UnmarshalJSON(data []byte) error { return unmarshalNode(data, w) }
+
+func (c *CaseExpr) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CaseExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (w *WhenClause) MarshalJSON() ([]byte, error)    { return marshalNode(w) }
+func (w *WhenClause) UnmarshalJSON(data []byte) error { return unmarshalNode(data, w) }
+
+func (c *CastExpr) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *CastExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
+
+func (e *ExtractExpr) MarshalJSON() ([]byte, error)    { return marshalNode(e) }
+func (e *ExtractExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, e) }
+
+func (i *IntervalExpr) MarshalJSON() ([]byte, error)    { return marshalNode(i) }
+func (i *IntervalExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, i) }
+
+func (a *ArrayAccess) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *ArrayAccess) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (t *TupleAccess) MarshalJSON() ([]byte, error)    { return marshalNode(t) }
+func (t *TupleAccess) UnmarshalJSON(data []byte) error { return unmarshalNode(data, t) }
+
+func (l *Lambda) MarshalJSON() ([]byte, error)    { return marshalNode(l) }
+func (l *Lambda) UnmarshalJSON(data []byte) error { return unmarshalNode(data, l) }
+
+func (p *Parameter) MarshalJSON() ([]byte, error)    { return marshalNode(p) }
+func (p *Parameter) UnmarshalJSON(data []byte) error { return unmarshalNode(data, p) }
+
+func (a *AliasedExpr) MarshalJSON() ([]byte, error)    { return marshalNode(a) }
+func (a *AliasedExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, a) }
+
+func (b *BetweenExpr) MarshalJSON() ([]byte, error)    { return marshalNode(b) }
+func (b *BetweenExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, b) }
+
+func (i *InExpr) MarshalJSON() ([]byte, error)    { return marshalNode(i) }
+func (i *InExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, i) }
+
+func (i *IsNullExpr) MarshalJSON() ([]byte, error)    { return marshalNode(i) }
+func (i *IsNullExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, i) }
+
+func (l *LikeExpr) MarshalJSON() ([]byte, error)    { return marshalNode(l) }
+func (l *LikeExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, l) }
+
+func (e *ExistsExpr) MarshalJSON() ([]byte, error)    { return marshalNode(e) }
+func (e *ExistsExpr) UnmarshalJSON(data []byte) error { return unmarshalNode(data, e) }
+
+func (p *ParallelWithQuery) MarshalJSON() ([]byte, error)    { return marshalNode(p) }
+func (p *ParallelWithQuery) UnmarshalJSON(data []byte) error { return unmarshalNode(data, p) }
+
+func (b *BadStatement) MarshalJSON() ([]byte, error)    { return marshalNode(b) }
+func (b *BadStatement) UnmarshalJSON(data []byte) error { return unmarshalNode(data, b) }
+
+func (c *Comment) MarshalJSON() ([]byte, error)    { return marshalNode(c) }
+func (c *Comment) UnmarshalJSON(data []byte) error { return unmarshalNode(data, c) }
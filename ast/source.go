@@ -0,0 +1,19 @@
+package ast
+
+import "reflect"
+
+// Text returns the source text of n, given the source it was parsed from.
+// The text is returned exactly as written, including any comments inside
+// the node. For a statement it excludes the terminating semicolon.
+//
+// Text returns an empty string if n is nil or its range lies outside src.
+func Text(src string, n Node) string {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return ""
+	}
+	start, end := n.Pos().Offset, n.End().Offset
+	if start < 0 || start > end || end > len(src) {
+		return ""
+	}
+	return src[start:end]
+}
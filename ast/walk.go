@@ -0,0 +1,616 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children
+// of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+func walkList[N Node](v Visitor, list []N) {
+	for _, node := range list {
+		Walk(v, node)
+	}
+}
+
+// Walk traverses an AST in depth-first order: It starts by calling
+// v.Visit(node). If the visitor w returned by v.Visit(node) is not nil,
+// Walk is invoked recursively with visitor w for each of the non-nil
+// children of node, followed by a call of w.Visit(nil).
+//
+// Children are visited in the order they are declared in the node's
+// struct. Walk does nothing if node is nil or a typed nil pointer, so
+// optional children never reach the visitor. Fields that only repeat
+// nodes held by another field, such as TTLClause.Expression, are not
+// walked, so every node is visited once.
+func Walk(v Visitor, node Node) {
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return
+	}
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	// walk children
+	switch n := node.(type) {
+	// Statements
+	case *SelectWithUnionQuery:
+		walkList(v, n.Selects)
+		walkList(v, n.Settings)
+		Walk(v, n.Format)
+
+	case *SelectIntersectExceptQuery:
+		walkList(v, n.Selects)
+
+	case *SelectQuery:
+		walkList(v, n.With)
+		walkList(v, n.DistinctOn)
+		Walk(v, n.Top)
+		walkList(v, n.Columns)
+		Walk(v, n.From)
+		Walk(v, n.ArrayJoin)
+		Walk(v, n.PreWhere)
+		Walk(v, n.Where)
+		walkList(v, n.GroupBy)
+		Walk(v, n.Having)
+		Walk(v, n.Qualify)
+		walkList(v, n.Window)
+		walkList(v, n.OrderBy)
+		walkList(v, n.Interpolate)
+		Walk(v, n.Limit)
+		walkList(v, n.LimitBy)
+		Walk(v, n.LimitByLimit)
+		Walk(v, n.LimitByOffset)
+		Walk(v, n.Offset)
+		walkList(v, n.Settings)
+		Walk(v, n.IntoOutfile)
+		Walk(v, n.Format)
+
+	case *ArrayJoinClause:
+		walkList(v, n.Columns)
+
+	case *WindowDefinition:
+		Walk(v, n.Spec)
+
+	case *TablesInSelectQuery:
+		walkList(v, n.Tables)
+
+	case *TablesInSelectQueryElement:
+		Walk(v, n.Table)
+		Walk(v, n.Join)
+		Walk(v, n.ArrayJoin)
+
+	case *TableExpression:
+		Walk(v, n.Table)
+		Walk(v, n.Sample)
+
+	case *SampleClause:
+		Walk(v, n.Ratio)
+		Walk(v, n.Offset)
+
+	case *TableJoin:
+		Walk(v, n.On)
+		walkList(v, n.Using)
+
+	case *OrderByElement:
+		Walk(v, n.Expression)
+		Walk(v, n.FillFrom)
+		Walk(v, n.FillTo)
+		Walk(v, n.FillStep)
+		Walk(v, n.FillStaleness)
+
+	case *InterpolateElement:
+		Walk(v, n.Value)
+
+	case *SettingExpr:
+		Walk(v, n.Value)
+		Walk(v, n.Min)
+		Walk(v, n.Max)
+
+	case *InsertQuery:
+		Walk(v, n.Function)
+		walkList(v, n.Columns)
+		walkList(v, n.ColumnExpressions)
+		Walk(v, n.PartitionBy)
+		for _, row := range n.Values {
+			walkList(v, row)
+		}
+		Walk(v, n.Select)
+		walkList(v, n.With)
+		Walk(v, n.Format)
+		walkList(v, n.Settings)
+
+	case *CreateTableQuery:
+		walkList(v, n.Columns)
+		walkList(v, n.Indexes)
+		walkList(v, n.Projections)
+		walkList(v, n.Constraints)
+		walkList(v, n.ColumnsPrimaryKey)
+		Walk(v, n.Engine)
+		walkList(v, n.OrderBy)
+		Walk(v, n.PartitionBy)
+		walkList(v, n.PrimaryKey)
+		Walk(v, n.SampleBy)
+		Walk(v, n.TTL)
+		walkList(v, n.Settings)
+		walkList(v, n.QuerySettings)
+		Walk(v, n.AsSelect)
+		Walk(v, n.AsTableFunction)
+
+	case *CreateViewQuery:
+		walkList(v, n.Columns)
+		Walk(v, n.AsSelect)
+
+	case *CreateMaterializedViewQuery:
+		Walk(v, n.RefreshInterval)
+		walkList(v, n.Columns)
+		walkList(v, n.Indexes)
+		walkList(v, n.Projections)
+		walkList(v, n.ColumnsPrimaryKey)
+		Walk(v, n.Engine)
+		walkList(v, n.OrderBy)
+		Walk(v, n.PartitionBy)
+		walkList(v, n.PrimaryKey)
+		Walk(v, n.SampleBy)
+		Walk(v, n.TTL)
+		walkList(v, n.Settings)
+		walkList(v, n.QuerySettings)
+		Walk(v, n.AsSelect)
+
+	case *CreateWindowViewQuery:
+		walkList(v, n.Columns)
+		Walk(v, n.InnerEngine)
+		Walk(v, n.Engine)
+		walkList(v, n.OrderBy)
+		Walk(v, n.PartitionBy)
+		walkList(v, n.PrimaryKey)
+		Walk(v, n.SampleBy)
+		Walk(v, n.TTL)
+		walkList(v, n.Settings)
+		walkList(v, n.QuerySettings)
+		Walk(v, n.AsSelect)
+
+	case *CreateDatabaseQuery:
+		Walk(v, n.Engine)
+		walkList(v, n.OrderBy)
+		walkList(v, n.Settings)
+
+	case *CreateFunctionQuery:
+		Walk(v, n.Body)
+
+	case *CreateDictionaryQuery:
+		walkList(v, n.Attributes)
+		Walk(v, n.Definition)
+
+	case *ColumnDeclaration:
+		Walk(v, n.Type)
+		Walk(v, n.Default)
+		Walk(v, n.Codec)
+		walkList(v, n.Statistics)
+		Walk(v, n.TTL)
+		walkList(v, n.Settings)
+
+	case *DictionaryAttributeDeclaration:
+		Walk(v, n.Type)
+		Walk(v, n.Default)
+		Walk(v, n.Expression)
+
+	case *DictionaryDefinition:
+		walkList(v, n.PrimaryKey)
+		Walk(v, n.Source)
+		Walk(v, n.Lifetime)
+		Walk(v, n.Layout)
+		Walk(v, n.Range)
+		walkList(v, n.Settings)
+
+	case *DictionarySource:
+		walkList(v, n.Args)
+
+	case *KeyValuePair:
+		Walk(v, n.Value)
+
+	case *DictionaryLifetime:
+		Walk(v, n.Min)
+		Walk(v, n.Max)
+
+	case *DictionaryLayout:
+		walkList(v, n.Args)
+
+	case *DictionaryRange:
+		Walk(v, n.Min)
+		Walk(v, n.Max)
+
+	case *DataType:
+		walkList(v, n.Parameters)
+
+	case *ObjectTypeArgument:
+		Walk(v, n.Expr)
+
+	case *NameTypePair:
+		Walk(v, n.Type)
+
+	case *CodecExpr:
+		walkList(v, n.Codecs)
+
+	case *IndexDefinition:
+		Walk(v, n.Expression)
+		Walk(v, n.Type)
+		Walk(v, n.Granularity)
+
+	case *Constraint:
+		Walk(v, n.Expression)
+
+	case *EngineClause:
+		walkList(v, n.Parameters)
+
+	case *TTLClause:
+		// Expression and Expressions repeat the expressions of Elements
+		walkList(v, n.Elements)
+
+	case *TTLElement:
+		Walk(v, n.Expr)
+		Walk(v, n.Codec)
+		Walk(v, n.Where)
+		walkList(v, n.GroupBy)
+		walkList(v, n.Set)
+
+	case *DropTableQuery:
+		walkList(v, n.Tables)
+		walkList(v, n.Settings)
+
+	case *DropViewQuery:
+		walkList(v, n.Views)
+		walkList(v, n.Settings)
+
+	case *DropDictionaryQuery:
+		walkList(v, n.Settings)
+
+	case *DropDatabaseQuery:
+		walkList(v, n.Settings)
+
+	case *UpdateQuery:
+		walkList(v, n.Assignments)
+		Walk(v, n.Where)
+
+	case *AlterQuery:
+		walkList(v, n.Commands)
+		walkList(v, n.Settings)
+
+	case *AlterCommand:
+		Walk(v, n.Column)
+		// IndexExpr repeats the expression of IndexDef
+		Walk(v, n.IndexDef)
+		Walk(v, n.Constraint)
+		Walk(v, n.Partition)
+		Walk(v, n.TTL)
+		walkList(v, n.Settings)
+		Walk(v, n.Where)
+		walkList(v, n.Assignments)
+		Walk(v, n.Projection)
+		walkList(v, n.StatisticsTypes)
+		walkList(v, n.OrderByExpr)
+		Walk(v, n.SampleByExpr)
+		Walk(v, n.Query)
+		Walk(v, n.RefreshInterval)
+		Walk(v, n.RefreshOffset)
+		Walk(v, n.RefreshRandomize)
+
+	case *Projection:
+		Walk(v, n.Select)
+
+	case *ProjectionSelectQuery:
+		walkList(v, n.With)
+		walkList(v, n.Columns)
+		walkList(v, n.GroupBy)
+		walkList(v, n.OrderBy)
+
+	case *Assignment:
+		Walk(v, n.Value)
+
+	case *TruncateQuery:
+		walkList(v, n.Settings)
+
+	case *DeleteQuery:
+		Walk(v, n.Partition)
+		Walk(v, n.Where)
+		walkList(v, n.Settings)
+
+	case *AttachQuery:
+		walkList(v, n.Columns)
+		walkList(v, n.ColumnsPrimaryKey)
+		walkList(v, n.Indexes)
+		Walk(v, n.Engine)
+		walkList(v, n.OrderBy)
+		walkList(v, n.PrimaryKey)
+		Walk(v, n.PartitionBy)
+		Walk(v, n.SelectQuery)
+		walkList(v, n.Settings)
+
+	case *BackupQuery:
+		Walk(v, n.Target)
+		walkList(v, n.Settings)
+
+	case *RestoreQuery:
+		Walk(v, n.Source)
+		walkList(v, n.Settings)
+
+	case *DescribeQuery:
+		Walk(v, n.TableFunction)
+		Walk(v, n.TableExpr)
+		walkList(v, n.Settings)
+
+	case *ShowQuery:
+		Walk(v, n.Where)
+		Walk(v, n.Limit)
+
+	case *ExplainQuery:
+		Walk(v, n.Statement)
+
+	case *SetQuery:
+		walkList(v, n.Settings)
+
+	case *OptimizeQuery:
+		Walk(v, n.Partition)
+		walkList(v, n.Settings)
+
+	case *CheckQuery:
+		Walk(v, n.Partition)
+		Walk(v, n.Part)
+		walkList(v, n.Settings)
+
+	case *SystemQuery:
+		Walk(v, n.Duration)
+		walkList(v, n.Settings)
+
+	case *RenameQuery:
+		walkList(v, n.Pairs)
+		walkList(v, n.Settings)
+
+	case *ExistsQuery:
+		walkList(v, n.Settings)
+
+	case *KillQuery:
+		Walk(v, n.Where)
+		walkList(v, n.Settings)
+
+	case *CreateIndexQuery:
+		walkList(v, n.Columns)
+
+	case *ParallelWithQuery:
+		walkList(v, n.Statements)
+
+	case *BadStatement:
+		Walk(v, n.Partial)
+
+	case *GrantQuery:
+		walkList(v, n.Elements)
+		Walk(v, n.Grantees)
+
+	case *GrantElement:
+		walkList(v, n.Privileges)
+
+	case *CreateRoleQuery:
+		walkList(v, n.Settings)
+
+	case *CreateUserQuery:
+		walkList(v, n.AuthMethods)
+		walkList(v, n.Hosts)
+		walkList(v, n.AddHosts)
+		walkList(v, n.DropHosts)
+		Walk(v, n.ValidUntil)
+		Walk(v, n.DefaultRoles)
+		Walk(v, n.Grantees)
+		walkList(v, n.Settings)
+
+	case *AuthenticationMethod:
+		Walk(v, n.Value)
+		walkList(v, n.SSHKeys)
+		Walk(v, n.ValidUntil)
+
+	case *SetRoleQuery:
+		Walk(v, n.Roles)
+		Walk(v, n.Users)
+
+	case *CreateRowPolicyQuery:
+		walkList(v, n.Policies)
+		Walk(v, n.Using)
+		Walk(v, n.Roles)
+
+	case *RowPolicyName:
+		walkList(v, n.Tables)
+
+	case *DropRowPolicyQuery:
+		walkList(v, n.Policies)
+
+	case *CreateSettingsProfileQuery:
+		walkList(v, n.Settings)
+		Walk(v, n.Roles)
+
+	case *AlterSettingsProfileQuery:
+		walkList(v, n.Settings)
+		walkList(v, n.AddSettings)
+		walkList(v, n.ModifySettings)
+		Walk(v, n.Roles)
+
+	case *CreateNamedCollectionQuery:
+		walkList(v, n.Pairs)
+
+	case *AlterNamedCollectionQuery:
+		walkList(v, n.Set)
+
+	case *CreateResourceQuery:
+		walkList(v, n.Operations)
+
+	case *CreateWorkloadQuery:
+		walkList(v, n.Settings)
+
+	case *CreateQuotaQuery:
+		walkList(v, n.Intervals)
+		Walk(v, n.Roles)
+
+	case *QuotaInterval:
+		Walk(v, n.Duration)
+		walkList(v, n.Limits)
+
+	case *QuotaLimit:
+		Walk(v, n.Value)
+
+	case *UndropQuery, *UseQuery, *DetachQuery, *TransactionControlQuery,
+		*RenamePair, *ExchangeQuery, *ShowGrantsQuery, *Privilege, *RoleSet,
+		*ShowPrivilegesQuery, *ShowCreateQuotaQuery,
+		*DropSettingsProfileQuery, *DropNamedCollectionQuery,
+		*ShowCreateSettingsProfileQuery, *ShowCreateRowPolicyQuery,
+		*DropRoleQuery, *ShowCreateRoleQuery, *ResourceOperation,
+		*SSHKey, *UserHost,
+		*DropResourceQuery, *DropWorkloadQuery,
+		*DropFunctionQuery, *DropIndexQuery, *DropUserQuery, *DropQuotaQuery,
+		*IntoOutfileClause:
+		// nothing to do
+
+	// Expressions
+	case *Literal:
+		if exprs, ok := n.Value.([]Expression); ok {
+			walkList(v, exprs)
+		}
+
+	case *Asterisk:
+		// Replace repeats the replacements of Transformers
+		walkList(v, n.Transformers)
+
+	case *ReplaceExpr:
+		Walk(v, n.Expr)
+
+	case *ColumnTransformer:
+		walkList(v, n.ApplyParams)
+		Walk(v, n.ApplyLambda)
+		walkList(v, n.Replaces)
+
+	case *ColumnsMatcher:
+		walkList(v, n.Columns)
+		// Replace repeats the replacements of Transformers
+		walkList(v, n.Transformers)
+
+	case *FunctionCall:
+		walkList(v, n.Parameters)
+		walkList(v, n.Arguments)
+		walkList(v, n.Settings)
+		Walk(v, n.Filter)
+		Walk(v, n.Over)
+
+	case *WindowSpec:
+		walkList(v, n.PartitionBy)
+		walkList(v, n.OrderBy)
+		Walk(v, n.Frame)
+
+	case *WindowFrame:
+		Walk(v, n.StartBound)
+		Walk(v, n.EndBound)
+
+	case *FrameBound:
+		Walk(v, n.Offset)
+
+	case *BinaryExpr:
+		Walk(v, n.Left)
+		Walk(v, n.Right)
+
+	case *UnaryExpr:
+		Walk(v, n.Operand)
+
+	case *TernaryExpr:
+		Walk(v, n.Condition)
+		Walk(v, n.Then)
+		Walk(v, n.Else)
+
+	case *Subquery:
+		Walk(v, n.Query)
+
+	case *WithElement:
+		Walk(v, n.Query)
+
+	case *CaseExpr:
+		Walk(v, n.Operand)
+		walkList(v, n.Whens)
+		Walk(v, n.Else)
+
+	case *WhenClause:
+		Walk(v, n.Condition)
+		Walk(v, n.Result)
+
+	case *CastExpr:
+		Walk(v, n.Expr)
+		Walk(v, n.Type)
+		Walk(v, n.TypeExpr)
+
+	case *ExtractExpr:
+		Walk(v, n.From)
+
+	case *IntervalExpr:
+		Walk(v, n.Value)
+
+	case *ArrayAccess:
+		Walk(v, n.Array)
+		Walk(v, n.Index)
+
+	case *TupleAccess:
+		Walk(v, n.Tuple)
+		Walk(v, n.Index)
+
+	case *Lambda:
+		Walk(v, n.Body)
+
+	case *Parameter:
+		Walk(v, n.Type)
+
+	case *AliasedExpr:
+		Walk(v, n.Expr)
+
+	case *BetweenExpr:
+		Walk(v, n.Expr)
+		Walk(v, n.Low)
+		Walk(v, n.High)
+
+	case *InExpr:
+		Walk(v, n.Expr)
+		walkList(v, n.List)
+		Walk(v, n.Query)
+
+	case *IsNullExpr:
+		Walk(v, n.Expr)
+
+	case *LikeExpr:
+		Walk(v, n.Expr)
+		Walk(v, n.Pattern)
+
+	case *ExistsExpr:
+		Walk(v, n.Query)
+
+	case *Identifier, *TableIdentifier, *Comment:
+		// nothing to do
+
+	default:
+		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", n))
+	}
+
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order: It starts by calling
+// f(node). If f returns true, Inspect invokes f recursively for each of
+// the non-nil children of node, followed by a call of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
@@ -194,10 +194,39 @@ func Node(sb *strings.Builder, node interface{}, depth int) {
 	// DDL statements
 	case *ast.InsertQuery:
 		explainInsertQuery(sb, n, indent, depth)
-	case *ast.CreateQuery:
-		explainCreateQuery(sb, n, indent, depth)
-	case *ast.DropQuery:
-		explainDropQuery(sb, n, indent, depth)
+	case *ast.CreateTableQuery:
+		explainCreateQuery(sb, createTableQuery(n), indent, depth)
+	case *ast.CreateViewQuery:
+		explainCreateQuery(sb, createViewQuery(n), indent, depth)
+	case *ast.CreateMaterializedViewQuery:
+		explainCreateQuery(sb, createMaterializedViewQuery(n), indent, depth)
+	case *ast.CreateWindowViewQuery:
+		explainCreateQuery(sb, createWindowViewQuery(n), indent, depth)
+	case *ast.CreateDatabaseQuery:
+		explainCreateQuery(sb, createDatabaseQuery(n), indent, depth)
+	case *ast.CreateFunctionQuery:
+		explainCreateFunctionQuery(sb, n, indent, depth)
+	case *ast.CreateDictionaryQuery:
+		explainCreateDictionaryQuery(sb, n, indent, depth)
+	case *ast.DropTableQuery:
+		explainDropTables(sb, n.Tables, n.Format, n.Settings, indent, depth)
+	case *ast.DropViewQuery:
+		explainDropTables(sb, n.Views, n.Format, n.Settings, indent, depth)
+	case *ast.DropDictionaryQuery:
+		explainDropQuery(sb, n.Database, n.Dictionary, n.Format, n.Settings, indent)
+	case *ast.DropDatabaseQuery:
+		explainDropDatabaseQuery(sb, n, indent)
+	case *ast.DropFunctionQuery:
+		fmt.Fprintf(sb, "%sDropFunctionQuery\n", indent)
+	case *ast.DropIndexQuery:
+		// Two spaces before the table name, as the database is not shown
+		fmt.Fprintf(sb, "%sDropIndexQuery  %s (children %d)\n", indent, n.Table, 2)
+		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Index)
+		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Table)
+	case *ast.DropUserQuery:
+		fmt.Fprintf(sb, "%sDROP USER query\n", indent)
+	case *ast.DropQuotaQuery:
+		fmt.Fprintf(sb, "%sDROP QUOTA query\n", indent)
 	case *ast.UndropQuery:
 		explainUndropQuery(sb, n, indent, depth)
 	case *ast.RenameQuery:
@@ -264,6 +293,8 @@ func Node(sb *strings.Builder, node interface{}, depth int) {
 		} else {
 			fmt.Fprintf(sb, "%sSHOW CREATE ROW POLICY query\n", indent)
 		}
+	case *ast.CreateUserQuery:
+		explainCreateUserQuery(sb, n, indent)
 	case *ast.CreateRoleQuery:
 		fmt.Fprintf(sb, "%sCreateRoleQuery\n", indent)
 	case *ast.DropRoleQuery:
@@ -245,12 +245,9 @@ func explainSelectWithUnionQueryWithInheritedWith(sb *strings.Builder, n *ast.Se
 	if n.SettingsBeforeFormat && len(n.Settings) > 0 {
 		fmt.Fprintf(sb, "%s Set\n", indent)
 	}
-	// FORMAT clause - check individual SelectQuery nodes
-	for _, sel := range n.Selects {
-		if sq, ok := sel.(*ast.SelectQuery); ok && sq.Format != nil {
-			Node(sb, sq.Format, depth+1)
-			break
-		}
+	// FORMAT clause
+	if format := selectFormat(n); format != nil {
+		Node(sb, format, depth+1)
 	}
 	// SETTINGS after FORMAT
 	if n.SettingsAfterFormat && len(n.Settings) > 0 {
@@ -345,11 +342,8 @@ func explainSelectWithUnionQuery(sb *strings.Builder, n *ast.SelectWithUnionQuer
 	// FORMAT clause - check if any SelectQuery has Format set
 	// Skip this when inside CreateQuery context, as Format is output at CreateQuery level
 	if !inCreateQueryContext {
-		for _, sel := range n.Selects {
-			if sq, ok := sel.(*ast.SelectQuery); ok && sq.Format != nil {
-				Node(sb, sq.Format, depth+1)
-				break
-			}
+		if format := selectFormat(n); format != nil {
+			Node(sb, format, depth+1)
 		}
 	}
 	// When SETTINGS comes AFTER FORMAT, output Set last (check SelectWithUnionQuery first, then SelectQuery)
@@ -622,13 +616,8 @@ func countSelectUnionChildren(n *ast.SelectWithUnionQuery) int {
 	}
 	// Check if any SelectQuery has Format set
 	// Skip this when inside CreateQuery context, as Format is output at CreateQuery level
-	if !inCreateQueryContext {
-		for _, sel := range n.Selects {
-			if sq, ok := sel.(*ast.SelectQuery); ok && sq.Format != nil {
-				count++
-				break
-			}
-		}
+	if !inCreateQueryContext && selectFormat(n) != nil {
+		count++
 	}
 	// Count union-level SETTINGS (either before or after FORMAT)
 	if len(n.Settings) > 0 && (n.SettingsBeforeFormat || n.SettingsAfterFormat) {
@@ -861,3 +850,17 @@ func countSelectQueryChildren(n *ast.SelectQuery) int {
 	}
 	return count
 }
+
+// selectFormat returns the FORMAT of a union of SELECTs: the union-level
+// FORMAT if there is one, or else that of the first SELECT that has one.
+func selectFormat(n *ast.SelectWithUnionQuery) *ast.Identifier {
+	if n.Format != nil {
+		return n.Format
+	}
+	for _, sel := range n.Selects {
+		if sq, ok := sel.(*ast.SelectQuery); ok && sq.Format != nil {
+			return sq.Format
+		}
+	}
+	return nil
+}
@@ -89,6 +89,11 @@ func explainInsertQuery(sb *strings.Builder, n *ast.InsertQuery, indent string,
 		// For INSERT with SELECT, temporarily clear Format from the SELECT
 		// (FORMAT in INSERT belongs to INSERT, not SELECT, and shouldn't be output in EXPLAIN)
 		if swu, ok := n.Select.(*ast.SelectWithUnionQuery); ok {
+			if swu.Format != nil {
+				savedFormat := swu.Format
+				swu.Format = nil
+				defer func() { swu.Format = savedFormat }()
+			}
 			for _, sel := range swu.Selects {
 				if sq, ok := sel.(*ast.SelectQuery); ok && sq.Format != nil {
 					savedFormat := sq.Format
@@ -111,92 +116,233 @@ func explainInsertQuery(sb *strings.Builder, n *ast.InsertQuery, indent string,
 	}
 }
 
-func explainCreateQuery(sb *strings.Builder, n *ast.CreateQuery, indent string, depth int) {
-	if n == nil {
-		fmt.Fprintf(sb, "%s*ast.CreateQuery\n", indent)
-		return
-	}
-	// Handle special CREATE types
-	if n.CreateFunction {
-		children := 2 // identifier + lambda
-		fmt.Fprintf(sb, "%sCreateFunctionQuery %s (children %d)\n", indent, n.FunctionName, children)
-		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.FunctionName)
-		if n.FunctionBody != nil {
-			Node(sb, n.FunctionBody, depth+1)
-		}
+func explainCreateUserQuery(sb *strings.Builder, n *ast.CreateUserQuery, indent string) {
+	if !n.NotIdentified && len(n.AuthMethods) == 0 {
+		fmt.Fprintf(sb, "%sCreateUserQuery\n", indent)
 		return
 	}
-	if n.CreateUser || n.AlterUser {
-		if n.HasAuthenticationData {
-			// Each authentication value is a separate AuthenticationData child
-			if len(n.AuthenticationValues) > 0 {
-				fmt.Fprintf(sb, "%sCreateUserQuery (children %d)\n", indent, len(n.AuthenticationValues))
-				for _, val := range n.AuthenticationValues {
-					// Each AuthenticationData has 1 child (the Literal value)
-					fmt.Fprintf(sb, "%s AuthenticationData (children 1)\n", indent)
-					// Escape the value - strings need \' escaping
-					escaped := escapeStringLiteral(val)
-					fmt.Fprintf(sb, "%s  Literal \\'%s\\'\n", indent, escaped)
-				}
-			} else if n.SSHKeyCount > 0 {
-				// SSH key authentication - each key is a PublicSSHKey child
-				fmt.Fprintf(sb, "%sCreateUserQuery (children 1)\n", indent)
-				fmt.Fprintf(sb, "%s AuthenticationData (children %d)\n", indent, n.SSHKeyCount)
-				for i := 0; i < n.SSHKeyCount; i++ {
-					fmt.Fprintf(sb, "%s  PublicSSHKey\n", indent)
-				}
-			} else {
-				// No values - just output CreateUserQuery with 1 child
-				fmt.Fprintf(sb, "%sCreateUserQuery (children 1)\n", indent)
-				fmt.Fprintf(sb, "%s AuthenticationData\n", indent)
+
+	// Passwords, hashes, servers and realms are Literal children
+	var values []string
+	sshKeys := 0
+	for _, m := range n.AuthMethods {
+		if lit, ok := m.Value.(*ast.Literal); ok {
+			if s, ok := lit.Value.(string); ok {
+				values = append(values, s)
 			}
-		} else {
-			fmt.Fprintf(sb, "%sCreateUserQuery\n", indent)
 		}
-		return
+		sshKeys += len(m.SSHKeys)
 	}
-	if n.CreateDictionary {
-		// Dictionary: count children = database identifier (if any) + table identifier + attributes (if any) + definition (if any) + comment (if any)
-		children := 1 // table identifier
-		hasDatabase := n.Database != ""
-		if hasDatabase {
-			children++ // database identifier
-		}
-		if len(n.DictionaryAttrs) > 0 {
-			children++
-		}
-		if n.DictionaryDef != nil {
-			children++
-		}
-		if n.Comment != "" {
-			children++
-		}
-		// Format: "CreateQuery [database] [table] (children N)"
-		if hasDatabase {
-			fmt.Fprintf(sb, "%sCreateQuery %s %s (children %d)\n", indent, n.Database, n.Table, children)
-			fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Database)
-		} else {
-			fmt.Fprintf(sb, "%sCreateQuery %s (children %d)\n", indent, n.Table, children)
-		}
-		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Table)
-		// Dictionary attributes
-		if len(n.DictionaryAttrs) > 0 {
-			fmt.Fprintf(sb, "%s ExpressionList (children %d)\n", indent, len(n.DictionaryAttrs))
-			for _, attr := range n.DictionaryAttrs {
-				explainDictionaryAttributeDeclaration(sb, attr, indent+"  ", depth+2)
-			}
+
+	if len(values) > 0 {
+		// Each authentication value is a separate AuthenticationData child
+		fmt.Fprintf(sb, "%sCreateUserQuery (children %d)\n", indent, len(values))
+		for _, val := range values {
+			// Each AuthenticationData has 1 child (the Literal value)
+			fmt.Fprintf(sb, "%s AuthenticationData (children 1)\n", indent)
+			// Escape the value - strings need \' escaping
+			escaped := escapeStringLiteral(val)
+			fmt.Fprintf(sb, "%s  Literal \\'%s\\'\n", indent, escaped)
 		}
-		// Dictionary definition
-		if n.DictionaryDef != nil {
-			explainDictionaryDefinition(sb, n.DictionaryDef, indent+" ", depth+1)
+	} else if sshKeys > 0 {
+		// SSH key authentication - each key is a PublicSSHKey child
+		fmt.Fprintf(sb, "%sCreateUserQuery (children 1)\n", indent)
+		fmt.Fprintf(sb, "%s AuthenticationData (children %d)\n", indent, sshKeys)
+		for i := 0; i < sshKeys; i++ {
+			fmt.Fprintf(sb, "%s  PublicSSHKey\n", indent)
 		}
-		// Dictionary COMMENT
-		if n.Comment != "" {
-			fmt.Fprintf(sb, "%s Literal \\'%s\\'\n", indent, n.Comment)
+	} else {
+		// No values - just output CreateUserQuery with 1 child
+		fmt.Fprintf(sb, "%sCreateUserQuery (children 1)\n", indent)
+		fmt.Fprintf(sb, "%s AuthenticationData\n", indent)
+	}
+}
+
+func explainCreateFunctionQuery(sb *strings.Builder, n *ast.CreateFunctionQuery, indent string, depth int) {
+	children := 2 // identifier + lambda
+	fmt.Fprintf(sb, "%sCreateFunctionQuery %s (children %d)\n", indent, n.Name, children)
+	fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Name)
+	if n.Body != nil {
+		Node(sb, n.Body, depth+1)
+	}
+}
+
+func explainCreateDictionaryQuery(sb *strings.Builder, n *ast.CreateDictionaryQuery, indent string, depth int) {
+	// Dictionary: count children = database identifier (if any) + table identifier + attributes (if any) + definition (if any) + comment (if any)
+	children := 1 // table identifier
+	hasDatabase := n.Database != ""
+	if hasDatabase {
+		children++ // database identifier
+	}
+	if len(n.Attributes) > 0 {
+		children++
+	}
+	if n.Definition != nil {
+		children++
+	}
+	if n.Comment != "" {
+		children++
+	}
+	// Format: "CreateQuery [database] [table] (children N)"
+	if hasDatabase {
+		fmt.Fprintf(sb, "%sCreateQuery %s %s (children %d)\n", indent, n.Database, n.Dictionary, children)
+		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Database)
+	} else {
+		fmt.Fprintf(sb, "%sCreateQuery %s (children %d)\n", indent, n.Dictionary, children)
+	}
+	fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Dictionary)
+	// Dictionary attributes
+	if len(n.Attributes) > 0 {
+		fmt.Fprintf(sb, "%s ExpressionList (children %d)\n", indent, len(n.Attributes))
+		for _, attr := range n.Attributes {
+			explainDictionaryAttributeDeclaration(sb, attr, indent+"  ", depth+2)
 		}
-		return
 	}
+	// Dictionary definition
+	if n.Definition != nil {
+		explainDictionaryDefinition(sb, n.Definition, indent+" ", depth+1)
+	}
+	// Dictionary COMMENT
+	if n.Comment != "" {
+		fmt.Fprintf(sb, "%s Literal \\'%s\\'\n", indent, n.Comment)
+	}
+}
+
+// createQuery holds the clauses of the CREATE TABLE, VIEW and DATABASE
+// statements, which ClickHouse explains as a single CreateQuery node.
+type createQuery struct {
+	Database                  string
+	Table                     string
+	View                      string
+	CreateDatabase            bool
+	Materialized              bool
+	WindowView                bool
+	HasRefresh                bool
+	To                        string
+	Columns                   []*ast.ColumnDeclaration
+	Indexes                   []*ast.IndexDefinition
+	Projections               []*ast.Projection
+	Constraints               []*ast.Constraint
+	ColumnsPrimaryKey         []ast.Expression
+	HasEmptyColumnsPrimaryKey bool
+	InnerEngine               *ast.EngineClause
+	Engine                    *ast.EngineClause
+	OrderBy                   []ast.Expression
+	OrderByHasModifiers       bool
+	PartitionBy               ast.Expression
+	PrimaryKey                []ast.Expression
+	SampleBy                  ast.Expression
+	TTL                       *ast.TTLClause
+	Settings                  []*ast.SettingExpr
+	QuerySettings             []*ast.SettingExpr
+	SettingsBeforeComment     bool
+	AsSelect                  ast.Statement
+	AsTableFunction           ast.Expression
+	Comment                   string
+	Format                    string
+}
+
+func createTableQuery(n *ast.CreateTableQuery) *createQuery {
+	return &createQuery{
+		Database:                  n.Database,
+		Table:                     n.Table,
+		Columns:                   n.Columns,
+		Indexes:                   n.Indexes,
+		Projections:               n.Projections,
+		Constraints:               n.Constraints,
+		ColumnsPrimaryKey:         n.ColumnsPrimaryKey,
+		HasEmptyColumnsPrimaryKey: n.HasEmptyColumnsPrimaryKey,
+		Engine:                    n.Engine,
+		OrderBy:                   n.OrderBy,
+		OrderByHasModifiers:       n.OrderByHasModifiers,
+		PartitionBy:               n.PartitionBy,
+		PrimaryKey:                n.PrimaryKey,
+		SampleBy:                  n.SampleBy,
+		TTL:                       n.TTL,
+		Settings:                  n.Settings,
+		QuerySettings:             n.QuerySettings,
+		SettingsBeforeComment:     n.SettingsBeforeComment,
+		AsSelect:                  n.AsSelect,
+		AsTableFunction:           n.AsTableFunction,
+		Comment:                   n.Comment,
+		Format:                    n.Format,
+	}
+}
+
+func createViewQuery(n *ast.CreateViewQuery) *createQuery {
+	return &createQuery{
+		Database: n.Database,
+		View:     n.View,
+		Columns:  n.Columns,
+		AsSelect: n.AsSelect,
+		Comment:  n.Comment,
+		Format:   n.Format,
+	}
+}
 
+func createMaterializedViewQuery(n *ast.CreateMaterializedViewQuery) *createQuery {
+	return &createQuery{
+		Database:              n.Database,
+		View:                  n.View,
+		Materialized:          true,
+		HasRefresh:            n.HasRefresh,
+		To:                    n.To,
+		Columns:               n.Columns,
+		Indexes:               n.Indexes,
+		Projections:           n.Projections,
+		ColumnsPrimaryKey:     n.ColumnsPrimaryKey,
+		Engine:                n.Engine,
+		OrderBy:               n.OrderBy,
+		OrderByHasModifiers:   n.OrderByHasModifiers,
+		PartitionBy:           n.PartitionBy,
+		PrimaryKey:            n.PrimaryKey,
+		SampleBy:              n.SampleBy,
+		TTL:                   n.TTL,
+		Settings:              n.Settings,
+		QuerySettings:         n.QuerySettings,
+		SettingsBeforeComment: n.SettingsBeforeComment,
+		AsSelect:              n.AsSelect,
+		Comment:               n.Comment,
+		Format:                n.Format,
+	}
+}
+
+func createWindowViewQuery(n *ast.CreateWindowViewQuery) *createQuery {
+	return &createQuery{
+		Database:              n.Database,
+		View:                  n.View,
+		WindowView:            true,
+		To:                    n.To,
+		Columns:               n.Columns,
+		InnerEngine:           n.InnerEngine,
+		Engine:                n.Engine,
+		OrderBy:               n.OrderBy,
+		OrderByHasModifiers:   n.OrderByHasModifiers,
+		PartitionBy:           n.PartitionBy,
+		PrimaryKey:            n.PrimaryKey,
+		SampleBy:              n.SampleBy,
+		TTL:                   n.TTL,
+		Settings:              n.Settings,
+		QuerySettings:         n.QuerySettings,
+		SettingsBeforeComment: n.SettingsBeforeComment,
+		AsSelect:              n.AsSelect,
+		Comment:               n.Comment,
+		Format:                n.Format,
+	}
+}
+
+func createDatabaseQuery(n *ast.CreateDatabaseQuery) *createQuery {
+	return &createQuery{
+		Database:       n.Database,
+		CreateDatabase: true,
+		Engine:         n.Engine,
+		OrderBy:        n.OrderBy,
+		Settings:       n.Settings,
+		Format:         n.Format,
+	}
+}
+
+func explainCreateQuery(sb *strings.Builder, n *createQuery, indent string, depth int) {
 	name := n.Table
 	if n.View != "" {
 		name = n.View
@@ -642,120 +788,67 @@ func explainCreateQuery(sb *strings.Builder, n *ast.CreateQuery, indent string,
 	}
 }
 
-func explainDropQuery(sb *strings.Builder, n *ast.DropQuery, indent string, depth int) {
-	// DROP USER has a special output format
-	if n.User != "" {
-		fmt.Fprintf(sb, "%sDROP USER query\n", indent)
-		return
-	}
-
-	// DROP FUNCTION has a special output format
-	if n.Function != "" {
-		fmt.Fprintf(sb, "%sDropFunctionQuery\n", indent)
-		return
-	}
-
-	// DROP ROLE
-	if n.Role != "" {
-		fmt.Fprintf(sb, "%sDROP ROLE query\n", indent)
-		return
-	}
-
-	// DROP QUOTA
-	if n.Quota != "" {
-		fmt.Fprintf(sb, "%sDROP QUOTA query\n", indent)
-		return
-	}
-
-	// DROP POLICY
-	if n.Policy != "" {
-		fmt.Fprintf(sb, "%sDROP POLICY query\n", indent)
-		return
-	}
-
-	// DROP ROW POLICY
-	if n.RowPolicy != "" {
-		fmt.Fprintf(sb, "%sDROP ROW POLICY query\n", indent)
-		return
-	}
-
-	// DROP SETTINGS PROFILE
-	if n.SettingsProfile != "" {
-		fmt.Fprintf(sb, "%sDROP SETTINGS PROFILE query\n", indent)
-		return
-	}
-
-	// DROP INDEX - outputs as DropIndexQuery with two spaces before table name
-	if n.Index != "" {
-		fmt.Fprintf(sb, "%sDropIndexQuery  %s (children %d)\n", indent, n.Table, 2)
-		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Index)
-		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Table)
-		return
-	}
-
-	// Handle multiple tables: DROP TABLE t1, t2, t3
-	if len(n.Tables) > 1 {
+// explainDropTables explains DROP TABLE and DROP VIEW, which list the
+// tables in an ExpressionList when more than one is dropped.
+func explainDropTables(sb *strings.Builder, tables []*ast.TableIdentifier, format string, settings []*ast.SettingExpr, indent string, depth int) {
+	if len(tables) > 1 {
 		fmt.Fprintf(sb, "%sDropQuery   (children %d)\n", indent, 1)
-		fmt.Fprintf(sb, "%s ExpressionList (children %d)\n", indent, len(n.Tables))
-		for _, t := range n.Tables {
+		fmt.Fprintf(sb, "%s ExpressionList (children %d)\n", indent, len(tables))
+		for _, t := range tables {
 			Node(sb, t, depth+2)
 		}
 		return
 	}
-
-	name := n.Table
-	if n.View != "" {
-		name = n.View
-	}
-	if n.Dictionary != "" {
-		name = n.Dictionary
-	}
-	if n.DropDatabase {
-		name = n.Database
+	var database, name string
+	if len(tables) == 1 {
+		database, name = tables[0].Database, tables[0].Table
 	}
-	// Check if we have a database-qualified name (for DROP TABLE db.table)
-	hasDatabase := n.Database != "" && !n.DropDatabase
-	hasFormat := n.Format != ""
+	explainDropQuery(sb, database, name, format, settings, indent)
+}
 
-	if hasDatabase {
+func explainDropQuery(sb *strings.Builder, database, name, format string, settings []*ast.SettingExpr, indent string) {
+	hasFormat := format != ""
+	if database != "" {
 		// Database-qualified: DropQuery db table (children 2 or 3)
 		children := 2
 		if hasFormat {
 			children = 3
 		}
-		fmt.Fprintf(sb, "%sDropQuery %s %s (children %d)\n", indent, EscapeIdentifier(n.Database), EscapeIdentifier(name), children)
-		fmt.Fprintf(sb, "%s Identifier %s\n", indent, EscapeIdentifier(n.Database))
-		fmt.Fprintf(sb, "%s Identifier %s\n", indent, EscapeIdentifier(name))
-		if hasFormat {
-			fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Format)
-		}
-	} else if n.DropDatabase {
-		// DROP DATABASE uses different spacing
-		children := 1
-		if hasFormat {
-			children = 2
-		}
-		fmt.Fprintf(sb, "%sDropQuery %s  (children %d)\n", indent, EscapeIdentifier(name), children)
+		fmt.Fprintf(sb, "%sDropQuery %s %s (children %d)\n", indent, EscapeIdentifier(database), EscapeIdentifier(name), children)
+		fmt.Fprintf(sb, "%s Identifier %s\n", indent, EscapeIdentifier(database))
 		fmt.Fprintf(sb, "%s Identifier %s\n", indent, EscapeIdentifier(name))
 		if hasFormat {
-			fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Format)
-		}
-	} else {
-		children := 1
-		if hasFormat {
-			children++
-		}
-		if len(n.Settings) > 0 {
-			children++
-		}
-		fmt.Fprintf(sb, "%sDropQuery  %s (children %d)\n", indent, EscapeIdentifier(name), children)
-		fmt.Fprintf(sb, "%s Identifier %s\n", indent, EscapeIdentifier(name))
-		if hasFormat {
-			fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Format)
-		}
-		if len(n.Settings) > 0 {
-			fmt.Fprintf(sb, "%s Set\n", indent)
+			fmt.Fprintf(sb, "%s Identifier %s\n", indent, format)
 		}
+		return
+	}
+	children := 1
+	if hasFormat {
+		children++
+	}
+	if len(settings) > 0 {
+		children++
+	}
+	fmt.Fprintf(sb, "%sDropQuery  %s (children %d)\n", indent, EscapeIdentifier(name), children)
+	fmt.Fprintf(sb, "%s Identifier %s\n", indent, EscapeIdentifier(name))
+	if hasFormat {
+		fmt.Fprintf(sb, "%s Identifier %s\n", indent, format)
+	}
+	if len(settings) > 0 {
+		fmt.Fprintf(sb, "%s Set\n", indent)
+	}
+}
+
+func explainDropDatabaseQuery(sb *strings.Builder, n *ast.DropDatabaseQuery, indent string) {
+	// DROP DATABASE uses different spacing
+	children := 1
+	if n.Format != "" {
+		children = 2
+	}
+	fmt.Fprintf(sb, "%sDropQuery %s  (children %d)\n", indent, EscapeIdentifier(n.Database), children)
+	fmt.Fprintf(sb, "%s Identifier %s\n", indent, EscapeIdentifier(n.Database))
+	if n.Format != "" {
+		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Format)
 	}
 }
 
@@ -886,51 +979,53 @@ func explainSetQuery(sb *strings.Builder, indent string) {
 }
 
 func explainSystemQuery(sb *strings.Builder, n *ast.SystemQuery, indent string) {
-	// Some commands like FLUSH LOGS don't show the log name as a child
-	// For other commands, table/database names are shown as children
-	isFlushLogs := strings.HasPrefix(strings.ToUpper(n.Command), "FLUSH LOGS")
-
-	// Count children - database and table are children if present and not FLUSH LOGS
-	children := 0
-	if !isFlushLogs {
+	// ClickHouse prints the target table in different shapes depending on
+	// the command: FLUSH LOGS prints no names at all, and some commands
+	// repeat the names or print an unqualified table twice.
+	var names []string
+	switch n.Kind {
+	case ast.SystemFlushLogs:
+	case ast.SystemFlushDistributed, ast.SystemStartDistributedSends, ast.SystemStopDistributedSends,
+		ast.SystemReloadDictionary:
 		if n.Database != "" {
-			children++
+			names = append(names, n.Database, n.Table, n.Database, n.Table)
+		} else if n.Kind == ast.SystemFlushDistributed {
+			names = append(names, n.Table, n.Table)
+		} else if n.Table != "" {
+			names = append(names, n.Table, n.Table)
+		}
+	case ast.SystemRestoreReplica, ast.SystemDropReplica, ast.SystemLoadPrimaryKey, ast.SystemUnloadPrimaryKey:
+		if n.Database != "" && n.Table != "" {
+			names = append(names, n.Database, n.Table)
+		} else if n.Table != "" {
+			names = append(names, n.Table, n.Table)
+		} else if n.Database != "" {
+			names = append(names, n.Database)
 		}
-		if n.Table != "" {
-			children++
+	default:
+		if n.Database != "" {
+			names = append(names, n.Database)
 		}
-		// For commands that need duplicate output, double the count
-		if n.DuplicateTableOutput && children > 0 {
-			children *= 2
+		if n.Table != "" {
+			names = append(names, n.Table)
 		}
 	}
+
+	children := len(names)
 	// Settings adds a child
 	if len(n.Settings) > 0 {
 		children++
 	}
-	if children > 0 {
-		fmt.Fprintf(sb, "%sSYSTEM query (children %d)\n", indent, children)
-		if n.Database != "" {
-			fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Database)
-		}
-		if n.Table != "" {
-			fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Table)
-		}
-		// Output again for duplicate commands
-		if n.DuplicateTableOutput {
-			if n.Database != "" {
-				fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Database)
-			}
-			if n.Table != "" {
-				fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Table)
-			}
-		}
-		// Output Set for settings
-		if len(n.Settings) > 0 {
-			fmt.Fprintf(sb, "%s Set\n", indent)
-		}
-	} else {
+	if children == 0 {
 		fmt.Fprintf(sb, "%sSYSTEM query\n", indent)
+		return
+	}
+	fmt.Fprintf(sb, "%sSYSTEM query (children %d)\n", indent, children)
+	for _, name := range names {
+		fmt.Fprintf(sb, "%s Identifier %s\n", indent, name)
+	}
+	if len(n.Settings) > 0 {
+		fmt.Fprintf(sb, "%s Set\n", indent)
 	}
 }
 
@@ -965,6 +1060,12 @@ func explainExplainQuery(sb *strings.Builder, n *ast.ExplainQuery, indent string
 			swu.Settings = nil
 			defer func() { swu.Settings = savedSettings }()
 		}
+		if swu.Format != nil {
+			format = swu.Format
+			// Temporarily nil out the format so it's not output by SelectWithUnionQuery
+			swu.Format = nil
+			defer func() { swu.Format = format }()
+		}
 		for _, sel := range swu.Selects {
 			if sq, ok := sel.(*ast.SelectQuery); ok {
 				if sq.Format != nil {
@@ -1711,7 +1812,7 @@ func explainAlterQuery(sb *strings.Builder, n *ast.AlterQuery, indent string, de
 	}
 
 	children := 2 // ExpressionList + Identifier for table
-	if n.Database != "" {
+	if n.Database != "" && !n.IsDatabase {
 		children = 3 // ExpressionList + Identifier for database + Identifier for table
 	}
 	if len(n.Settings) > 0 {
@@ -1734,7 +1835,9 @@ func explainAlterQuery(sb *strings.Builder, n *ast.AlterQuery, indent string, de
 	if n.Database != "" {
 		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Database)
 	}
-	fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Table)
+	if !n.IsDatabase {
+		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Table)
+	}
 	if hasFormat {
 		fmt.Fprintf(sb, "%s Identifier %s\n", indent, n.Format)
 	}
@@ -1778,6 +1881,10 @@ func explainAlterCommand(sb *strings.Builder, cmd *ast.AlterCommand, indent stri
 	if cmdType == ast.AlterFreeze {
 		cmdType = "FREEZE_ALL"
 	}
+	// UNFREEZE (without partition) is shown as UNFREEZE_ALL in EXPLAIN AST
+	if cmdType == ast.AlterUnfreeze {
+		cmdType = "UNFREEZE_ALL"
+	}
 	if children > 0 {
 		fmt.Fprintf(sb, "%sAlterCommand %s (children %d)\n", indent, cmdType, children)
 	} else {
@@ -1841,7 +1948,7 @@ func explainAlterCommand(sb *strings.Builder, cmd *ast.AlterCommand, indent stri
 		if cmd.Comment != "" {
 			fmt.Fprintf(sb, "%s Literal \\'%s\\'\n", indent, escapeStringLiteral(cmd.Comment))
 		}
-	case ast.AlterModifyComment:
+	case ast.AlterModifyComment, ast.AlterModifyDatabaseComment:
 		if cmd.Comment != "" {
 			fmt.Fprintf(sb, "%s Literal \\'%s\\'\n", indent, escapeStringLiteral(cmd.Comment))
 		}
@@ -1937,10 +2044,10 @@ func explainAlterCommand(sb *strings.Builder, cmd *ast.AlterCommand, indent stri
 				Node(sb, expr, depth+3)
 			}
 		}
-	case ast.AlterModifySetting:
+	case ast.AlterModifySetting, ast.AlterModifyDatabaseSetting:
 		fmt.Fprintf(sb, "%s Set\n", indent)
 	case ast.AlterDropPartition, ast.AlterDropDetachedPartition, ast.AlterDetachPartition, ast.AlterAttachPartition,
-		ast.AlterReplacePartition, ast.AlterFetchPartition, ast.AlterMovePartition, ast.AlterFreezePartition, ast.AlterApplyPatches, ast.AlterApplyDeletedMask:
+		ast.AlterReplacePartition, ast.AlterFetchPartition, ast.AlterMovePartition, ast.AlterFreezePartition, ast.AlterUnfreezePartition, ast.AlterApplyPatches, ast.AlterApplyDeletedMask:
 		if cmd.Partition != nil {
 			// PARTITION ALL is shown as Partition_ID (empty) in EXPLAIN AST
 			if ident, ok := cmd.Partition.(*ast.Identifier); ok && strings.ToUpper(ident.Name()) == "ALL" {
@@ -1962,7 +2069,7 @@ func explainAlterCommand(sb *strings.Builder, cmd *ast.AlterCommand, indent stri
 				Node(sb, cmd.Partition, depth+2)
 			}
 		}
-	case ast.AlterFreeze:
+	case ast.AlterFreeze, ast.AlterUnfreeze:
 		// No children
 	case ast.AlterDeleteWhere:
 		if cmd.Where != nil {
@@ -2182,7 +2289,7 @@ func countAlterCommandChildren(cmd *ast.AlterCommand) int {
 		if cmd.Comment != "" {
 			children++
 		}
-	case ast.AlterModifyComment:
+	case ast.AlterModifyComment, ast.AlterModifyDatabaseComment:
 		if cmd.Comment != "" {
 			children++
 		}
@@ -2245,14 +2352,14 @@ func countAlterCommandChildren(cmd *ast.AlterCommand) int {
 		if cmd.TTL != nil && cmd.TTL.Expression != nil {
 			children++
 		}
-	case ast.AlterModifySetting:
+	case ast.AlterModifySetting, ast.AlterModifyDatabaseSetting:
 		children = 1
 	case ast.AlterDropPartition, ast.AlterDropDetachedPartition, ast.AlterDetachPartition, ast.AlterAttachPartition,
-		ast.AlterReplacePartition, ast.AlterFetchPartition, ast.AlterMovePartition, ast.AlterFreezePartition, ast.AlterApplyPatches, ast.AlterApplyDeletedMask:
+		ast.AlterReplacePartition, ast.AlterFetchPartition, ast.AlterMovePartition, ast.AlterFreezePartition, ast.AlterUnfreezePartition, ast.AlterApplyPatches, ast.AlterApplyDeletedMask:
 		if cmd.Partition != nil {
 			children++
 		}
-	case ast.AlterFreeze:
+	case ast.AlterFreeze, ast.AlterUnfreeze:
 		// No children
 	case ast.AlterDeleteWhere:
 		if cmd.Where != nil {
@@ -2690,16 +2797,29 @@ func explainParallelWithQuery(sb *strings.Builder, n *ast.ParallelWithQuery, ind
 
 func getParallelWithName(stmt ast.Statement) string {
 	switch s := stmt.(type) {
-	case *ast.DropQuery:
+	case *ast.DropTableQuery:
 		tableName := ""
 		if len(s.Tables) > 0 {
-			if s.Tables[0].Table != "" {
-				tableName = s.Tables[0].Table
-			}
+			tableName = s.Tables[0].Table
+		}
+		return "DropQuery__" + tableName
+	case *ast.DropViewQuery:
+		tableName := ""
+		if len(s.Views) > 0 {
+			tableName = s.Views[0].Table
 		}
 		return "DropQuery__" + tableName
-	case *ast.CreateQuery:
+	case *ast.DropDictionaryQuery:
+		return "DropQuery__" + s.Dictionary
+	case *ast.DropDatabaseQuery:
+		return "DropQuery__"
+	case *ast.CreateTableQuery:
 		return "CreateQuery_" + s.Table
+	case *ast.CreateDictionaryQuery:
+		return "CreateQuery_" + s.Dictionary
+	case *ast.CreateViewQuery, *ast.CreateMaterializedViewQuery, *ast.CreateWindowViewQuery,
+		*ast.CreateDatabaseQuery, *ast.CreateFunctionQuery:
+		return "CreateQuery_"
 	case *ast.InsertQuery:
 		return "InsertQuery__"
 	default:
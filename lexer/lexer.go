@@ -3,6 +3,7 @@ package lexer
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"strings"
 	"unicode"
@@ -11,12 +12,19 @@ import (
 	"github.com/sqlc-dev/doubleclick/token"
 )
 
-// Lexer tokenizes ClickHouse SQL input.
+// Lexer tokenizes ClickHouse SQL input. Between calls to KeepRaw and
+// DropRaw it also keeps the input read, so that it can be returned by
+// ReadRaw.
 type Lexer struct {
-	reader *bufio.Reader
-	ch     rune   // current character
-	pos    token.Position
-	eof    bool
+	reader   *bufio.Reader
+	ch       rune           // current character
+	size     int            // width of the current character in bytes
+	bad      byte           // current character, if it is an invalid UTF-8 byte
+	pos      token.Position // position of the current character
+	eof      bool
+	keep     bool   // whether to keep the input read in raw
+	raw      []byte // input read since rawStart, for ReadRaw
+	rawStart int    // offset of raw[0]
 }
 
 // Item represents a lexical token with its value and position.
@@ -24,40 +32,128 @@ type Item struct {
 	Token  token.Token
 	Value  string
 	Pos    token.Position
-	Quoted bool // true if this identifier was double-quoted
+	End    token.Position // position immediately after the token
+	Quoted bool           // true if this identifier was double-quoted
 }
 
 // New creates a new Lexer from an io.Reader.
 func New(r io.Reader) *Lexer {
 	l := &Lexer{
 		reader: bufio.NewReader(r),
-		pos:    token.Position{Offset: 0, Line: 1, Column: 0},
+		pos:    token.Position{Offset: 0, Line: 1, Column: 1},
 	}
 	l.readChar()
 	return l
 }
 
 func (l *Lexer) readChar() {
+	// Advance past the current character
+	l.pos.Offset += l.size
+	if l.ch == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+	} else if l.size > 0 {
+		l.pos.Column++
+	}
+
 	if l.eof {
 		l.ch = 0
+		l.size = 0
 		return
 	}
 
 	r, size, err := l.reader.ReadRune()
 	if err != nil {
 		l.ch = 0
+		l.size = 0
 		l.eof = true
 		return
 	}
 
-	if l.ch == '\n' {
-		l.pos.Line++
-		l.pos.Column = 1
-	} else {
-		l.pos.Column++
+	if r == utf8.RuneError && size == 1 {
+		// Keep the invalid byte itself rather than its replacement
+		l.reader.UnreadRune()
+		l.bad, _ = l.reader.ReadByte()
 	}
-	l.pos.Offset += size
+
 	l.ch = r
+	l.size = size
+	if l.keep {
+		l.appendRaw()
+	}
+}
+
+// appendRaw appends the current character to raw, as written.
+func (l *Lexer) appendRaw() {
+	if l.ch == utf8.RuneError && l.size == 1 {
+		l.raw = append(l.raw, l.bad)
+	} else {
+		l.raw = utf8.AppendRune(l.raw, l.ch)
+	}
+}
+
+// KeepRaw makes the lexer keep the input from the end of the last token
+// returned, until DropRaw is called. It does nothing if the input is
+// already being kept.
+func (l *Lexer) KeepRaw() {
+	if l.keep {
+		return
+	}
+	l.keep = true
+	l.raw = l.raw[:0]
+	l.rawStart = l.pos.Offset
+	if l.size > 0 {
+		l.appendRaw()
+	}
+}
+
+// DropRaw discards the kept input and stops keeping it.
+func (l *Lexer) DropRaw() {
+	l.keep = false
+	l.raw = l.raw[:0]
+}
+
+// Release discards the kept input before offset, which will not be
+// requested from ReadRaw again.
+func (l *Lexer) Release(offset int) {
+	if n := offset - l.rawStart; l.keep && n > 0 && n <= len(l.raw) {
+		l.raw = append(l.raw[:0], l.raw[n:]...)
+		l.rawStart = offset
+	}
+}
+
+// ReadRaw returns the input from pos to the end, exactly as written, along
+// with the position of its end. The input from pos must have been kept and
+// not released. Afterwards the lexer is at EOF.
+func (l *Lexer) ReadRaw(pos token.Position) (string, token.Position, error) {
+	if !l.keep || pos.Offset < l.rawStart || pos.Offset > l.rawStart+len(l.raw) {
+		return "", pos, fmt.Errorf("raw input at offset %d is no longer available", pos.Offset)
+	}
+	rest, err := io.ReadAll(l.reader)
+	if err != nil {
+		return "", pos, err
+	}
+	data := string(l.raw[pos.Offset-l.rawStart:]) + string(rest)
+
+	end := pos
+	for _, r := range data {
+		if r == '\n' {
+			end.Line++
+			end.Column = 1
+		} else {
+			end.Column++
+		}
+	}
+	end.Offset = pos.Offset + len(data)
+
+	l.keep = false
+	l.raw = nil
+	l.rawStart = end.Offset
+	l.pos = end
+	l.ch = 0
+	l.size = 0
+	l.eof = true
+	return data, end, nil
 }
 
 func (l *Lexer) peekChar() rune {
@@ -172,6 +268,12 @@ func isClickHouseWhitespace(ch rune) bool {
 
 // NextToken returns the next token from the input.
 func (l *Lexer) NextToken() Item {
+	item := l.next()
+	item.End = l.pos
+	return item
+}
+
+func (l *Lexer) next() Item {
 	l.skipWhitespace()
 
 	pos := l.pos
@@ -322,7 +424,9 @@ func (l *Lexer) NextToken() Item {
 		}
 		// Check for tagged dollar quote: $tag$...$tag$
 		if tag := l.tryReadDollarTag(); tag != "" {
-			return l.readDollarQuotedString(tag)
+			item := l.readDollarQuotedString(tag)
+			item.Pos = pos // the opening $tag$ was already consumed
+			return item
 		}
 		// Otherwise $ starts an identifier (e.g., $alias$name$)
 		return l.readDollarIdentifier()
@@ -384,9 +488,7 @@ func (l *Lexer) readLineComment() Item {
 		sb.WriteRune(l.ch)
 		l.readChar()
 	}
-	// Strip trailing semicolon from comment - it's a statement terminator, not part of comment
-	text := strings.TrimRight(sb.String(), ";")
-	return Item{Token: token.LINE_COMMENT, Value: text, Pos: pos}
+	return Item{Token: token.LINE_COMMENT, Value: sb.String(), Pos: pos}
 }
 
 func (l *Lexer) readHashComment() Item {
@@ -400,9 +502,7 @@ func (l *Lexer) readHashComment() Item {
 		sb.WriteRune(l.ch)
 		l.readChar()
 	}
-	// Strip trailing semicolon from comment - it's a statement terminator, not part of comment
-	text := strings.TrimRight(sb.String(), ";")
-	return Item{Token: token.LINE_COMMENT, Value: text, Pos: pos}
+	return Item{Token: token.LINE_COMMENT, Value: sb.String(), Pos: pos}
 }
 
 // readUnicodeMinusComment reads from a unicode minus (U+2212) to the end of line or semicolon.
@@ -1196,13 +1296,17 @@ func (l *Lexer) readIdentifier() Item {
 	// Check for hex string literal: x'...' or X'...'
 	if (l.ch == 'x' || l.ch == 'X') && l.peekChar() == '\'' {
 		l.readChar() // skip x
-		return l.readHexString() // read as hex-decoded string
+		item := l.readHexString() // read as hex-decoded string
+		item.Pos = pos
+		return item
 	}
 
 	// Check for binary string literal: b'...' or B'...'
 	if (l.ch == 'b' || l.ch == 'B') && l.peekChar() == '\'' {
 		l.readChar() // skip b
-		return l.readBinaryString() // read as binary-decoded string
+		item := l.readBinaryString() // read as binary-decoded string
+		item.Pos = pos
+		return item
 	}
 
 	for isIdentChar(l.ch) {
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sqlc-dev/doubleclick/ast"
+	"github.com/sqlc-dev/doubleclick/lexer"
+	"github.com/sqlc-dev/doubleclick/token"
+)
+
+// ParseError describes a syntax error found while parsing.
+type ParseError struct {
+	Pos         token.Position // Start of the offending token
+	EndPos      token.Position // End of the offending token
+	Token       token.Token    // Offending token
+	Value       string         // Text of the offending token
+	Expected    []token.Token  // Tokens that would have been accepted, if known
+	Statement   int            // Index of the statement in the input, counting statements separated by ';'
+	Msg         string         // Description of the error, without the position
+	Misspelled  string         // Identifier at or just before the error that looks like a misspelled keyword
+	Suggestions []string       // Keywords Misspelled is close to
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at line %d, column %d", e.Msg, e.Pos.Line, e.Pos.Column)
+}
+
+// Snippet renders the error together with the line of src it occurred on,
+// marking the offending token with carets and listing any suggestions:
+//
+//	unexpected token IDENT at line 1, column 1
+//	  1 | SELET 1
+//	    | ^^^^^
+//	did you mean SELECT instead of SELET?
+//
+// src must be the input the error was reported for.
+func (e *ParseError) Snippet(src string) string {
+	var sb strings.Builder
+	sb.WriteString(e.Error())
+
+	if e.Pos.Offset <= len(src) {
+		lineStart := strings.LastIndexByte(src[:e.Pos.Offset], '\n') + 1
+		lineEnd := len(src)
+		if i := strings.IndexByte(src[e.Pos.Offset:], '\n'); i >= 0 {
+			lineEnd = e.Pos.Offset + i
+		}
+		line := strings.TrimRight(src[lineStart:lineEnd], "\r")
+
+		width := 1
+		if e.EndPos.Offset > e.Pos.Offset && e.EndPos.Offset <= lineEnd {
+			width = len([]rune(src[e.Pos.Offset:e.EndPos.Offset]))
+		}
+
+		// Keep tabs in the caret line so the caret lines up with the token
+		var indent strings.Builder
+		for _, r := range src[lineStart:e.Pos.Offset] {
+			if r == '\t' {
+				indent.WriteRune('\t')
+			} else {
+				indent.WriteRune(' ')
+			}
+		}
+
+		number := fmt.Sprint(e.Pos.Line)
+		gutter := strings.Repeat(" ", len(number))
+		fmt.Fprintf(&sb, "\n  %s | %s", number, line)
+		fmt.Fprintf(&sb, "\n  %s | %s%s", gutter, indent.String(), strings.Repeat("^", width))
+	}
+
+	if len(e.Suggestions) > 0 {
+		fmt.Fprintf(&sb, "\ndid you mean %s instead of %s?", strings.Join(e.Suggestions, " or "), e.Misspelled)
+	}
+	return sb.String()
+}
+
+// ErrorList is a list of parse errors, in the order they were found.
+// ParseStatements returns an ErrorList when the input has syntax errors;
+// use errors.As to retrieve it, or the first *ParseError in it.
+type ErrorList []*ParseError
+
+// Error implements the error interface.
+func (l ErrorList) Error() string {
+	return fmt.Sprintf("parse errors: %v", []*ParseError(l))
+}
+
+// Unwrap returns the errors in the list.
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, e := range l {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Snippet renders every error in the list with ParseError.Snippet,
+// separated by blank lines.
+func (l ErrorList) Snippet(src string) string {
+	snippets := make([]string, len(l))
+	for i, e := range l {
+		snippets[i] = e.Snippet(src)
+	}
+	return strings.Join(snippets, "\n\n")
+}
+
+// Err returns an error equivalent to this list, or nil if the list is empty.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// statementStarts are the tokens a statement can start with.
+var statementStarts = []token.Token{
+	token.SELECT, token.WITH, token.FROM, token.LPAREN, token.INSERT, token.CREATE, token.REPLACE,
+	token.DROP, token.ALTER, token.TRUNCATE, token.UNDROP, token.USE, token.DESCRIBE,
+	token.DESC, token.SHOW, token.EXPLAIN, token.SET, token.UPDATE, token.DELETE,
+	token.OPTIMIZE, token.SYSTEM, token.RENAME, token.EXCHANGE, token.EXISTS,
+	token.DETACH, token.ATTACH, token.CHECK, token.GRANT, token.REVOKE, token.BEGIN,
+	token.COMMIT, token.ROLLBACK, token.BACKUP, token.RESTORE, token.KILL,
+}
+
+// createKinds are the object kinds named in errors for an unknown CREATE.
+var createKinds = []token.Token{
+	token.TABLE, token.DATABASE, token.VIEW, token.FUNCTION, token.USER,
+}
+
+// errorAt records an error at item. expected lists the tokens that would
+// have been accepted instead, if known.
+func (p *Parser) errorAt(item lexer.Item, msg string, expected ...token.Token) {
+	e := &ParseError{
+		Pos:       item.Pos,
+		EndPos:    item.End,
+		Token:     item.Token,
+		Value:     item.Value,
+		Expected:  expected,
+		Statement: p.statement,
+		Msg:       msg,
+	}
+
+	// A misspelled keyword is either the offending token itself, or was
+	// taken for an identifier or alias just before it. Skip the previous
+	// token if an earlier error already reported it.
+	if s := suggestKeywords(item, expected); len(s) > 0 {
+		e.Misspelled, e.Suggestions = item.Value, s
+	} else if item.Pos == p.current.Pos &&
+		(len(p.errors) == 0 || p.errors[len(p.errors)-1].Pos.Offset < p.prev.Pos.Offset) {
+		if s := suggestKeywords(p.prev, nil); len(s) > 0 {
+			e.Misspelled, e.Suggestions = p.prev.Value, s
+		}
+	}
+
+	p.errors = append(p.errors, e)
+}
+
+// unexpected records an error for an unexpected current token.
+func (p *Parser) unexpected(expected ...token.Token) {
+	p.errorAt(p.current, fmt.Sprintf("unexpected token %s", p.current.Token), expected...)
+}
+
+// suggestKeywords returns the keywords closest to the text of an
+// unquoted identifier, choosing among the expected tokens if any are given
+// and falling back to all keywords if none of them is close.
+func suggestKeywords(item lexer.Item, expected []token.Token) []string {
+	if item.Token != token.IDENT || item.Quoted || len(item.Value) < 3 {
+		return nil
+	}
+	word := strings.ToUpper(item.Value)
+	if s := closestKeywords(word, expected); len(s) > 0 {
+		return s
+	}
+	var keywords []token.Token
+	for _, tok := range token.Keywords {
+		keywords = append(keywords, tok)
+	}
+	return closestKeywords(word, keywords)
+}
+
+// closestKeywords returns the keywords among candidates within a small
+// edit distance of word, keeping only the closest ones.
+func closestKeywords(word string, candidates []token.Token) []string {
+	maxDist := 1
+	if len(word) > 4 {
+		maxDist = 2
+	}
+
+	var suggestions []string
+	best := maxDist
+	for _, tok := range candidates {
+		if !tok.IsKeyword() {
+			continue
+		}
+		keyword := tok.String()
+		if keyword == word {
+			continue
+		}
+		switch d := editDistance(word, keyword); {
+		case d < best:
+			best = d
+			suggestions = []string{keyword}
+		case d == best:
+			suggestions = append(suggestions, keyword)
+		}
+	}
+	sort.Strings(suggestions)
+	return suggestions
+}
+
+// editDistance returns the number of single-character insertions,
+// deletions, substitutions and adjacent transpositions needed to turn a
+// into b.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// recoverStatement checks whether the statement starting at start was
+// parsed without errors, up to a semicolon or the start of another
+// statement. If not, it skips to the next semicolon and returns an
+// *ast.BadStatement covering the skipped input, keeping stmt as the
+// partially parsed statement.
+func (p *Parser) recoverStatement(start lexer.Item, stmt ast.Statement) ast.Statement {
+	if len(p.errors) == 0 {
+		if p.currentIs(token.SEMICOLON) || p.currentIs(token.EOF) || slices.Contains(statementStarts, p.current.Token) {
+			return stmt
+		}
+		p.unexpected(token.SEMICOLON)
+	}
+	for !p.currentIs(token.SEMICOLON) && !p.currentIs(token.EOF) {
+		p.nextToken()
+	}
+
+	bad := &ast.BadStatement{
+		Position: start.Pos,
+		Error:    p.errors[0].Error(),
+	}
+	if stmt != nil && !reflect.ValueOf(stmt).IsNil() {
+		bad.Partial = stmt
+	}
+	p.finish(bad)
+	return bad
+}
@@ -152,6 +152,7 @@ func (p *Parser) parseExpressionFrom(left ast.Expression, precedence int) ast.Ex
 		if left == nil {
 			return nil
 		}
+		p.finish(left)
 		if p.current.Pos == startPos {
 			break
 		}
@@ -247,6 +248,7 @@ func (p *Parser) parseGroupingSets() []ast.Expression {
 		if p.currentIs(token.LPAREN) {
 			// Parse as tuple
 			tuple := p.parseGroupedOrTuple()
+			p.finish(tuple)
 			exprs = append(exprs, tuple)
 		} else {
 			// Single expression
@@ -382,45 +384,50 @@ func (p *Parser) parseImplicitAlias(expr ast.Expression) ast.Expression {
 		}
 		alias := p.current.Value
 		p.nextToken()
+		expr = p.applyImplicitAlias(expr, alias)
+		p.finish(expr)
+	}
+	return expr
+}
 
-		// Set alias on the expression if it supports it
-		switch e := expr.(type) {
-		case *ast.Identifier:
-			e.Alias = alias
-			return e
-		case *ast.FunctionCall:
-			e.Alias = alias
-			return e
-		case *ast.Subquery:
-			e.Alias = alias
-			return e
-		case *ast.CastExpr:
-			// Only set alias on CastExpr if using :: operator syntax
-			// Function-style CAST() aliases go to AliasedExpr
-			if e.OperatorSyntax {
-				e.Alias = alias
-				return e
-			}
-			return &ast.AliasedExpr{
-				Position: expr.Pos(),
-				Expr:     expr,
-				Alias:    alias,
-			}
-		case *ast.CaseExpr:
-			e.Alias = alias
-			return e
-		case *ast.ExtractExpr:
+// applyImplicitAlias sets alias on the expression if it supports it,
+// otherwise wraps the expression in an AliasedExpr
+func (p *Parser) applyImplicitAlias(expr ast.Expression, alias string) ast.Expression {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		e.Alias = alias
+		return e
+	case *ast.FunctionCall:
+		e.Alias = alias
+		return e
+	case *ast.Subquery:
+		e.Alias = alias
+		return e
+	case *ast.CastExpr:
+		// Only set alias on CastExpr if using :: operator syntax
+		// Function-style CAST() aliases go to AliasedExpr
+		if e.OperatorSyntax {
 			e.Alias = alias
 			return e
-		default:
-			return &ast.AliasedExpr{
-				Position: expr.Pos(),
-				Expr:     expr,
-				Alias:    alias,
-			}
+		}
+		return &ast.AliasedExpr{
+			Position: expr.Pos(),
+			Expr:     expr,
+			Alias:    alias,
+		}
+	case *ast.CaseExpr:
+		e.Alias = alias
+		return e
+	case *ast.ExtractExpr:
+		e.Alias = alias
+		return e
+	default:
+		return &ast.AliasedExpr{
+			Position: expr.Pos(),
+			Expr:     expr,
+			Alias:    alias,
 		}
 	}
-	return expr
 }
 
 func (p *Parser) parseExpression(precedence int) ast.Expression {
@@ -428,6 +435,7 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 	if left == nil {
 		return nil
 	}
+	p.finish(left)
 
 	for !p.currentIs(token.EOF) && precedence < p.precedenceForCurrent() {
 		// Track position to detect infinite loops (when infix parsing doesn't consume tokens)
@@ -436,6 +444,7 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		if left == nil {
 			return nil
 		}
+		p.finish(left)
 		// If we didn't advance, break to avoid infinite loop
 		if p.current.Pos == startPos {
 			break
@@ -665,9 +674,10 @@ func (p *Parser) parseIdentifierOrFunction() ast.Expression {
 			fnName = "toTime"
 		}
 		strLit := &ast.Literal{
-			Position: p.current.Pos,
-			Type:     "String",
-			Value:    p.current.Value,
+			Position:    p.current.Pos,
+			EndPosition: p.current.End,
+			Type:        "String",
+			Value:       p.current.Value,
 		}
 		p.nextToken()
 		return &ast.FunctionCall{
@@ -700,9 +710,10 @@ func (p *Parser) parseIdentifierOrFunction() ast.Expression {
 			Alias:    name,
 			Arguments: []ast.Expression{
 				&ast.Literal{
-					Position: pos,
-					Type:     "String",
-					Value:    varName,
+					Position:    pos,
+					EndPosition: p.prevEnd,
+					Type:        "String",
+					Value:       varName,
 				},
 			},
 		}
@@ -792,7 +803,7 @@ func (p *Parser) parseFunctionCall(name string, pos token.Position) *ast.Functio
 	// view(SELECT ...) should parse SELECT as a subquery
 	if strings.ToLower(name) == "view" && (p.currentIs(token.SELECT) || p.currentIs(token.WITH)) {
 		subquery := p.parseSelectWithUnion()
-		fn.Arguments = []ast.Expression{&ast.Subquery{Position: pos, Query: subquery}}
+		fn.Arguments = []ast.Expression{&ast.Subquery{Position: subquery.Pos(), EndPosition: subquery.End(), Query: subquery}}
 	} else if !p.currentIs(token.RPAREN) && !(p.currentIs(token.SETTINGS) && !p.peekIs(token.LBRACKET)) {
 		// Parse arguments, but allow Settings['key'] map access (SETTINGS followed by [)
 		fn.Arguments = p.parseFunctionArgumentList()
@@ -843,6 +854,7 @@ func (p *Parser) parseFunctionCall(name string, pos token.Position) *ast.Functio
 	// Note: AS alias is handled by the expression parser's infix handling (parseAlias)
 	// to respect precedence levels when called from contexts like WITH clauses
 
+	p.finish(fn)
 	return fn
 }
 
@@ -855,10 +867,12 @@ func (p *Parser) parseWindowSpec() *ast.WindowSpec {
 		// Window name reference (OVER w0)
 		spec.Name = p.current.Value
 		p.nextToken()
+		p.finish(spec)
 		return spec
 	}
 
 	if !p.expect(token.LPAREN) {
+		p.finish(spec)
 		return spec
 	}
 
@@ -900,6 +914,7 @@ func (p *Parser) parseWindowSpec() *ast.WindowSpec {
 	}
 
 	p.expect(token.RPAREN)
+	p.finish(spec)
 	return spec
 }
 
@@ -929,6 +944,7 @@ func (p *Parser) parseWindowFrame() *ast.WindowFrame {
 		frame.StartBound = p.parseFrameBound()
 	}
 
+	p.finish(frame)
 	return frame
 }
 
@@ -943,6 +959,7 @@ func (p *Parser) parseFrameBound() *ast.FrameBound {
 			p.nextToken()
 		}
 		bound.Type = ast.BoundCurrentRow
+		p.finish(bound)
 		return bound
 	}
 
@@ -957,6 +974,7 @@ func (p *Parser) parseFrameBound() *ast.FrameBound {
 			}
 			p.nextToken()
 		}
+		p.finish(bound)
 		return bound
 	}
 
@@ -972,6 +990,7 @@ func (p *Parser) parseFrameBound() *ast.FrameBound {
 		p.nextToken()
 	}
 
+	p.finish(bound)
 	return bound
 }
 
@@ -980,9 +999,15 @@ func (p *Parser) parseNumber() ast.Expression {
 		Position: p.current.Pos,
 	}
 
-	value := p.current.Value
+	setNumberValue(lit, p.current.Value)
 	p.nextToken()
 
+	p.finish(lit)
+	return lit
+}
+
+// setNumberValue sets the type and value of lit from the text of a number.
+func setNumberValue(lit *ast.Literal, value string) {
 	// Check if this is a hex, binary, or octal number
 	isHex := strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X")
 	isBin := strings.HasPrefix(value, "0b") || strings.HasPrefix(value, "0B")
@@ -1070,8 +1095,6 @@ func (p *Parser) parseNumber() ast.Expression {
 			lit.Value = i
 		}
 	}
-
-	return lit
 }
 
 func (p *Parser) parseString() ast.Expression {
@@ -1127,9 +1150,10 @@ func (p *Parser) parseUnaryMinus() ast.Expression {
 	if p.currentIs(token.INF) {
 		p.nextToken() // skip INF
 		return &ast.Literal{
-			Position: pos,
-			Type:     ast.LiteralFloat,
-			Value:    math.Inf(-1),
+			Position:    pos,
+			EndPosition: p.prevEnd,
+			Type:        ast.LiteralFloat,
+			Value:       math.Inf(-1),
 		}
 	}
 
@@ -1163,6 +1187,7 @@ func (p *Parser) parseUnaryMinus() ast.Expression {
 			}
 		}
 		p.nextToken() // move past number
+		p.finish(lit)
 		// Apply postfix operators like :: using the expression parsing loop
 		// Use MUL_PREC as the threshold to allow casts (::) and member access (.)
 		// but stop before operators like AND which has lower precedence
@@ -1197,9 +1222,10 @@ func (p *Parser) parseUnaryPlus() ast.Expression {
 	if p.currentIs(token.INF) {
 		p.nextToken() // skip INF
 		return &ast.Literal{
-			Position: pos,
-			Type:     ast.LiteralFloat,
-			Value:    math.Inf(1),
+			Position:    pos,
+			EndPosition: p.prevEnd,
+			Type:        ast.LiteralFloat,
+			Value:       math.Inf(1),
 		}
 	}
 
@@ -1317,6 +1343,8 @@ func (p *Parser) parseGroupedOrTuple() ast.Expression {
 		lit.Parenthesized = true
 	}
 
+	// The expression spans its parentheses
+	setPos(first, pos)
 	return first
 }
 
@@ -1366,6 +1394,7 @@ func (p *Parser) parseArrayLiteral() ast.Expression {
 	lit.SpacedBrackets = spacedBrackets
 
 	p.expect(token.RBRACKET)
+	p.finish(lit)
 	return lit
 }
 
@@ -1402,6 +1431,7 @@ func (p *Parser) parseCase() ast.Expression {
 		}
 
 		when.Result = p.parseExpression(LOWEST)
+		p.finish(when)
 		expr.Whens = append(expr.Whens, when)
 	}
 
@@ -1451,20 +1481,21 @@ func (p *Parser) parseCast() ast.Expression {
 				// "AS alias AS Type" pattern
 				alias := p.current.Value
 				p.nextToken() // skip alias
-				p.nextToken() // skip AS
 				expr.Expr = p.wrapWithAlias(expr.Expr, alias)
+				p.nextToken() // skip AS
 				expr.Type = p.parseDataType()
 				expr.UsedASSyntax = true
 			} else if p.peekIs(token.COMMA) {
 				// "AS alias, 'Type'" pattern - comma-style with aliased expression
 				alias := p.current.Value
 				p.nextToken() // skip alias
-				p.nextToken() // skip comma
 				expr.Expr = p.wrapWithAlias(expr.Expr, alias)
+				p.nextToken() // skip comma
 				// Parse type (which may also have an alias)
 				if p.currentIs(token.STRING) {
 					typeStr := p.current.Value
 					typePos := p.current.Pos
+					typeEnd := p.current.End
 					p.nextToken()
 					// Check for alias on the type string
 					if p.currentIs(token.AS) {
@@ -1473,24 +1504,26 @@ func (p *Parser) parseCast() ast.Expression {
 							typeAlias := p.current.Value
 							p.nextToken()
 							expr.TypeExpr = &ast.AliasedExpr{
-								Position: typePos,
-								Expr:     &ast.Literal{Position: typePos, Type: ast.LiteralString, Value: typeStr},
-								Alias:    typeAlias,
+								Position:    typePos,
+								EndPosition: p.prevEnd,
+								Expr:        &ast.Literal{Position: typePos, EndPosition: typeEnd, Type: ast.LiteralString, Value: typeStr},
+								Alias:       typeAlias,
 							}
 						} else {
-							expr.Type = &ast.DataType{Position: typePos, Name: typeStr}
+							expr.Type = &ast.DataType{Position: typePos, EndPosition: typeEnd, Name: typeStr}
 						}
 					} else if p.currentIs(token.IDENT) && !p.peekIs(token.LPAREN) && !p.peekIs(token.COMMA) {
 						// Implicit alias: cast('1234' AS lhs, 'UInt32' rhs)
 						typeAlias := p.current.Value
 						p.nextToken()
 						expr.TypeExpr = &ast.AliasedExpr{
-							Position: typePos,
-							Expr:     &ast.Literal{Position: typePos, Type: ast.LiteralString, Value: typeStr},
-							Alias:    typeAlias,
+							Position:    typePos,
+							EndPosition: p.prevEnd,
+							Expr:        &ast.Literal{Position: typePos, EndPosition: typeEnd, Type: ast.LiteralString, Value: typeStr},
+							Alias:       typeAlias,
 						}
 					} else {
-						expr.Type = &ast.DataType{Position: typePos, Name: typeStr}
+						expr.Type = &ast.DataType{Position: typePos, EndPosition: typeEnd, Name: typeStr}
 					}
 				} else {
 					expr.TypeExpr = p.parseExpression(LOWEST)
@@ -1509,20 +1542,21 @@ func (p *Parser) parseCast() ast.Expression {
 		// Handle "expr alias AS Type" pattern (alias without AS keyword)
 		alias := p.current.Value
 		p.nextToken() // skip alias
-		p.nextToken() // skip AS
 		expr.Expr = p.wrapWithAlias(expr.Expr, alias)
+		p.nextToken() // skip AS
 		expr.Type = p.parseDataType()
 		expr.UsedASSyntax = true
 	} else if (p.currentIs(token.IDENT) || p.current.Token.IsKeyword()) && p.peekIs(token.COMMA) {
 		// Handle "expr alias, 'Type'" pattern (alias without AS keyword, comma-style)
 		alias := p.current.Value
 		p.nextToken() // skip alias
-		p.nextToken() // skip comma
 		expr.Expr = p.wrapWithAlias(expr.Expr, alias)
+		p.nextToken() // skip comma
 		// Parse type (which may also have an alias)
 		if p.currentIs(token.STRING) {
 			typeStr := p.current.Value
 			typePos := p.current.Pos
+			typeEnd := p.current.End
 			p.nextToken()
 			// Check for alias on the type string
 			if p.currentIs(token.AS) {
@@ -1531,24 +1565,26 @@ func (p *Parser) parseCast() ast.Expression {
 					typeAlias := p.current.Value
 					p.nextToken()
 					expr.TypeExpr = &ast.AliasedExpr{
-						Position: typePos,
-						Expr:     &ast.Literal{Position: typePos, Type: ast.LiteralString, Value: typeStr},
-						Alias:    typeAlias,
+						Position:    typePos,
+						EndPosition: p.prevEnd,
+						Expr:        &ast.Literal{Position: typePos, EndPosition: typeEnd, Type: ast.LiteralString, Value: typeStr},
+						Alias:       typeAlias,
 					}
 				} else {
-					expr.Type = &ast.DataType{Position: typePos, Name: typeStr}
+					expr.Type = &ast.DataType{Position: typePos, EndPosition: typeEnd, Name: typeStr}
 				}
 			} else if p.currentIs(token.IDENT) && !p.peekIs(token.LPAREN) && !p.peekIs(token.COMMA) {
 				// Implicit alias: cast('1234' lhs, 'UInt32' rhs)
 				typeAlias := p.current.Value
 				p.nextToken()
 				expr.TypeExpr = &ast.AliasedExpr{
-					Position: typePos,
-					Expr:     &ast.Literal{Position: typePos, Type: ast.LiteralString, Value: typeStr},
-					Alias:    typeAlias,
+					Position:    typePos,
+					EndPosition: p.prevEnd,
+					Expr:        &ast.Literal{Position: typePos, EndPosition: typeEnd, Type: ast.LiteralString, Value: typeStr},
+					Alias:       typeAlias,
 				}
 			} else {
-				expr.Type = &ast.DataType{Position: typePos, Name: typeStr}
+				expr.Type = &ast.DataType{Position: typePos, EndPosition: typeEnd, Name: typeStr}
 			}
 		} else {
 			expr.TypeExpr = p.parseExpression(LOWEST)
@@ -1562,6 +1598,7 @@ func (p *Parser) parseCast() ast.Expression {
 			// Simple string literal type, not part of an expression
 			typeStr := p.current.Value
 			typePos := p.current.Pos
+			typeEnd := p.current.End
 			p.nextToken()
 			// Check for alias on the type string
 			if p.currentIs(token.AS) {
@@ -1571,32 +1608,36 @@ func (p *Parser) parseCast() ast.Expression {
 					p.nextToken()
 					// Store as aliased literal in TypeExpr
 					expr.TypeExpr = &ast.AliasedExpr{
-						Position: typePos,
+						Position:    typePos,
+						EndPosition: p.prevEnd,
 						Expr: &ast.Literal{
-							Position: typePos,
-							Type:     ast.LiteralString,
-							Value:    typeStr,
+							Position:    typePos,
+							EndPosition: typeEnd,
+							Type:        ast.LiteralString,
+							Value:       typeStr,
 						},
 						Alias: alias,
 					}
 				} else {
-					expr.Type = &ast.DataType{Position: typePos, Name: typeStr}
+					expr.Type = &ast.DataType{Position: typePos, EndPosition: typeEnd, Name: typeStr}
 				}
 			} else if p.currentIs(token.IDENT) && !p.peekIs(token.LPAREN) && !p.peekIs(token.COMMA) {
 				// Implicit alias (no AS keyword): cast('1234', 'UInt32' rhs)
 				alias := p.current.Value
 				p.nextToken()
 				expr.TypeExpr = &ast.AliasedExpr{
-					Position: typePos,
+					Position:    typePos,
+					EndPosition: p.prevEnd,
 					Expr: &ast.Literal{
-						Position: typePos,
-						Type:     ast.LiteralString,
-						Value:    typeStr,
+						Position:    typePos,
+						EndPosition: typeEnd,
+						Type:        ast.LiteralString,
+						Value:       typeStr,
 					},
 					Alias: alias,
 				}
 			} else {
-				expr.Type = &ast.DataType{Position: typePos, Name: typeStr}
+				expr.Type = &ast.DataType{Position: typePos, EndPosition: typeEnd, Name: typeStr}
 			}
 		} else {
 			// Parse as expression for dynamic type casting or expressions like 'Str'||'ing'
@@ -1606,6 +1647,7 @@ func (p *Parser) parseCast() ast.Expression {
 
 	p.expect(token.RPAREN)
 
+	p.finish(expr)
 	return expr
 }
 
@@ -1615,21 +1657,20 @@ func (p *Parser) wrapWithAlias(expr ast.Expression, alias string) ast.Expression
 	switch e := expr.(type) {
 	case *ast.Identifier:
 		e.Alias = alias
-		return e
 	case *ast.FunctionCall:
 		e.Alias = alias
-		return e
 	case *ast.AliasedExpr:
 		// Replace the alias instead of double-wrapping
 		e.Alias = alias
-		return e
 	default:
-		return &ast.AliasedExpr{
+		expr = &ast.AliasedExpr{
 			Position: expr.Pos(),
 			Expr:     expr,
 			Alias:    alias,
 		}
 	}
+	p.finish(expr)
+	return expr
 }
 
 func (p *Parser) parseExtract() ast.Expression {
@@ -1653,6 +1694,7 @@ func (p *Parser) parseExtract() ast.Expression {
 			"TIMEZONE_HOUR": true, "TIMEZONE_MINUTE": true,
 		}
 		if dateTimeFields[field] {
+			fieldPos, fieldEnd := p.current.Pos, p.current.End
 			p.nextToken()
 			// Check for FROM keyword - if present, it's the EXTRACT(field FROM expr) form
 			if p.currentIs(token.FROM) {
@@ -1669,7 +1711,7 @@ func (p *Parser) parseExtract() ast.Expression {
 			}
 			// Not FROM, so create args starting with the field as identifier
 			args := []ast.Expression{
-				&ast.Identifier{Position: pos, Parts: []string{strings.ToLower(field)}},
+				&ast.Identifier{Position: fieldPos, EndPosition: fieldEnd, Parts: []string{strings.ToLower(field)}},
 			}
 			if p.currentIs(token.COMMA) {
 				p.nextToken()
@@ -1795,7 +1837,12 @@ func (p *Parser) parseParameter() ast.Expression {
 	parts := strings.SplitN(value, ":", 2)
 	param.Name = strings.TrimSpace(parts[0])
 	if len(parts) > 1 {
-		param.Type = &ast.DataType{Name: strings.TrimSpace(parts[1])}
+		// The type is part of the parameter token, so it shares its span
+		param.Type = &ast.DataType{
+			Position:    param.Position,
+			EndPosition: p.prevEnd,
+			Name:        strings.TrimSpace(parts[1]),
+		}
 	}
 
 	return param
@@ -2018,7 +2065,7 @@ func (p *Parser) parseTrim() ast.Expression {
 
 func (p *Parser) parseBinaryExpression(left ast.Expression) ast.Expression {
 	expr := &ast.BinaryExpr{
-		Position: p.current.Pos,
+		Position: left.Pos(),
 		Left:     left,
 		Op:       p.current.Value,
 	}
@@ -2036,8 +2083,10 @@ func (p *Parser) parseBinaryExpression(left ast.Expression) ast.Expression {
 		expr.Op == "<" || expr.Op == "<=" || expr.Op == ">" || expr.Op == ">="
 	if isComparisonOp && (p.currentIs(token.ANY) || p.currentIs(token.ALL)) {
 		modifier := strings.ToLower(p.current.Value)
+		modifierPos := p.current.Pos
 		p.nextToken()
 		if p.currentIs(token.LPAREN) {
+			subqueryPos := p.current.Pos
 			p.nextToken()
 			// Parse the subquery
 			if p.currentIs(token.SELECT) || p.currentIs(token.WITH) {
@@ -2052,7 +2101,7 @@ func (p *Parser) parseBinaryExpression(left ast.Expression) ast.Expression {
 					Name:     fnName,
 					Arguments: []ast.Expression{
 						left,
-						&ast.Subquery{Position: expr.Position, Query: subquery},
+						&ast.Subquery{Position: subqueryPos, EndPosition: p.prevEnd, Query: subquery},
 					},
 				}
 			}
@@ -2064,9 +2113,10 @@ func (p *Parser) parseBinaryExpression(left ast.Expression) ast.Expression {
 				Left:     left,
 				Op:       expr.Op,
 				Right: &ast.FunctionCall{
-					Position:  expr.Position,
-					Name:      strings.ToLower(modifier),
-					Arguments: args,
+					Position:    modifierPos,
+					EndPosition: p.prevEnd,
+					Name:        strings.ToLower(modifier),
+					Arguments:   args,
 				},
 			}
 		}
@@ -2099,7 +2149,7 @@ func operatorToName(op string) string {
 
 func (p *Parser) parseLikeExpression(left ast.Expression, not bool) ast.Expression {
 	expr := &ast.LikeExpr{
-		Position: p.current.Pos,
+		Position: left.Pos(),
 		Expr:     left,
 		Not:      not,
 	}
@@ -2115,7 +2165,7 @@ func (p *Parser) parseLikeExpression(left ast.Expression, not bool) ast.Expressi
 }
 
 func (p *Parser) parseRegexpExpression(left ast.Expression, not bool) ast.Expression {
-	pos := p.current.Pos
+	pos := left.Pos()
 	p.nextToken() // skip REGEXP
 
 	pattern := p.parseExpression(COMPARE)
@@ -2140,7 +2190,7 @@ func (p *Parser) parseRegexpExpression(left ast.Expression, not bool) ast.Expres
 
 func (p *Parser) parseInExpression(left ast.Expression, not bool) ast.Expression {
 	expr := &ast.InExpr{
-		Position: p.current.Pos,
+		Position: left.Pos(),
 		Expr:     left,
 		Not:      not,
 	}
@@ -2219,7 +2269,7 @@ func (p *Parser) parseInList() ([]ast.Expression, bool) {
 
 func (p *Parser) parseBetweenExpression(left ast.Expression, not bool) ast.Expression {
 	expr := &ast.BetweenExpr{
-		Position: p.current.Pos,
+		Position: left.Pos(),
 		Expr:     left,
 		Not:      not,
 	}
@@ -2237,7 +2287,7 @@ func (p *Parser) parseBetweenExpression(left ast.Expression, not bool) ast.Expre
 }
 
 func (p *Parser) parseIsExpression(left ast.Expression) ast.Expression {
-	pos := p.current.Pos
+	pos := left.Pos()
 	p.nextToken() // skip IS
 
 	not := false
@@ -2261,16 +2311,18 @@ func (p *Parser) parseIsExpression(left ast.Expression) ast.Expression {
 		if not {
 			value = !value
 		}
+		lit := &ast.Literal{
+			Position: p.current.Pos,
+			Type:     ast.LiteralBoolean,
+			Value:    value,
+		}
 		p.nextToken()
+		p.finish(lit)
 		return &ast.BinaryExpr{
 			Position: pos,
 			Left:     left,
 			Op:       "=",
-			Right: &ast.Literal{
-				Position: pos,
-				Type:     ast.LiteralBoolean,
-				Value:    value,
-			},
+			Right:    lit,
 		}
 	}
 
@@ -2292,15 +2344,17 @@ func (p *Parser) parseIsExpression(left ast.Expression) ast.Expression {
 				}
 			}
 			// IS DISTINCT FROM is NOT(IS NOT DISTINCT FROM)
+			operand := &ast.BinaryExpr{
+				Position: pos,
+				Left:     left,
+				Op:       "<=>",
+				Right:    right,
+			}
+			p.finish(operand)
 			return &ast.UnaryExpr{
 				Position: pos,
 				Op:       "NOT",
-				Operand: &ast.BinaryExpr{
-					Position: pos,
-					Left:     left,
-					Op:       "<=>",
-					Right:    right,
-				},
+				Operand:  operand,
 			}
 		}
 	}
@@ -2309,7 +2363,7 @@ func (p *Parser) parseIsExpression(left ast.Expression) ast.Expression {
 }
 
 func (p *Parser) parseArrayAccess(left ast.Expression) ast.Expression {
-	pos := p.current.Pos
+	pos := left.Pos()
 	p.nextToken() // skip [
 
 	// Check for empty brackets [] - this is JSON array path notation
@@ -2395,15 +2449,26 @@ func (p *Parser) parseTupleAccessFromNumber(left ast.Expression) ast.Expression
 			return result
 		}
 
+		// Each index spans its digits, after the dot before it
+		pos.Offset++
+		pos.Column++
+		end := pos
+		end.Offset += len(part)
+		end.Column += len(part)
+
+		index := &ast.Literal{
+			Position:    pos,
+			EndPosition: end,
+			Type:        ast.LiteralInteger,
+			Value:       idx,
+		}
 		result = &ast.TupleAccess{
-			Position: pos,
-			Tuple:    result,
-			Index: &ast.Literal{
-				Position: pos,
-				Type:     ast.LiteralInteger,
-				Value:    idx,
-			},
+			Position:    left.Pos(),
+			EndPosition: end,
+			Tuple:       result,
+			Index:       index,
 		}
+		pos = end
 	}
 
 	return result
@@ -2444,10 +2509,11 @@ func (p *Parser) parseDotAccess(left ast.Expression) ast.Expression {
 	// Check for tuple access with number
 	if p.currentIs(token.NUMBER) {
 		expr := &ast.TupleAccess{
-			Position: p.current.Pos,
+			Position: left.Pos(),
 			Tuple:    left,
 			Index:    p.parseNumber(),
 		}
+		p.finish(expr)
 		return expr
 	}
 
@@ -2494,18 +2560,18 @@ func (p *Parser) parseDotAccess(left ast.Expression) ast.Expression {
 		// For non-identifier expressions (ArrayAccess, FunctionCall, etc.) or
 		// parenthesized identifiers like (t), create TupleAccess with the field name.
 		// This handles: array[1].field, func().field, (t).field
-		fieldName := p.current.Value
-		pos := p.current.Pos
+		index := &ast.Literal{
+			Position: p.current.Pos,
+			Type:     ast.LiteralString,
+			Value:    p.current.Value,
+		}
 		p.nextToken()
+		p.finish(index)
 
 		return &ast.TupleAccess{
-			Position: pos,
+			Position: left.Pos(),
 			Tuple:    left,
-			Index: &ast.Literal{
-				Position: pos,
-				Type:     ast.LiteralString,
-				Value:    fieldName,
-			},
+			Index:    index,
 		}
 	}
 
@@ -2565,7 +2631,7 @@ func (p *Parser) parseAlias(left ast.Expression) ast.Expression {
 
 func (p *Parser) parseCastOperator(left ast.Expression) ast.Expression {
 	expr := &ast.CastExpr{
-		Position:       p.current.Pos,
+		Position:       left.Pos(),
 		Expr:           left,
 		OperatorSyntax: true,
 	}
@@ -2573,12 +2639,13 @@ func (p *Parser) parseCastOperator(left ast.Expression) ast.Expression {
 	p.nextToken() // skip ::
 
 	expr.Type = p.parseDataType()
+	p.finish(expr)
 	return expr
 }
 
 func (p *Parser) parseLambda(left ast.Expression) ast.Expression {
 	lambda := &ast.Lambda{
-		Position: p.current.Pos,
+		Position: left.Pos(),
 	}
 
 	// Extract parameter names from left expression
@@ -2607,7 +2674,7 @@ func (p *Parser) parseLambda(left ast.Expression) ast.Expression {
 
 func (p *Parser) parseTernary(condition ast.Expression) ast.Expression {
 	ternary := &ast.TernaryExpr{
-		Position:  p.current.Pos,
+		Position:  condition.Pos(),
 		Condition: condition,
 	}
 
@@ -2840,7 +2907,7 @@ func (p *Parser) parseKeywordAsFunction() ast.Expression {
 	// Handle view() and similar functions that take a subquery as argument
 	if name == "view" && (p.currentIs(token.SELECT) || p.currentIs(token.WITH)) {
 		subquery := p.parseSelectWithUnion()
-		fn.Arguments = []ast.Expression{&ast.Subquery{Position: pos, Query: subquery}}
+		fn.Arguments = []ast.Expression{&ast.Subquery{Position: subquery.Pos(), EndPosition: subquery.End(), Query: subquery}}
 	} else if !p.currentIs(token.RPAREN) {
 		fn.Arguments = p.parseExpressionList()
 	}
@@ -2912,8 +2979,17 @@ func (p *Parser) parseKeywordAsIdentifier() ast.Expression {
 	}
 }
 
+// finishTransformers sets the end position of column transformers that
+// were parsed by the caller.
+func (p *Parser) finishTransformers(transformers []*ast.ColumnTransformer) {
+	for _, t := range transformers {
+		p.finish(t)
+	}
+}
+
 func (p *Parser) parseAsteriskExcept(asterisk *ast.Asterisk) ast.Expression {
 	pos := p.current.Pos
+	first := len(asterisk.Transformers)
 	p.nextToken() // skip EXCEPT
 
 	// Check for STRICT modifier
@@ -2939,6 +3015,7 @@ func (p *Parser) parseAsteriskExcept(asterisk *ast.Asterisk) ast.Expression {
 		if hasParens {
 			p.expect(token.RPAREN)
 		}
+		p.finishTransformers(asterisk.Transformers[first:])
 		return asterisk
 	}
 
@@ -2970,11 +3047,13 @@ func (p *Parser) parseAsteriskExcept(asterisk *ast.Asterisk) ast.Expression {
 		p.expect(token.RPAREN)
 	}
 
+	p.finishTransformers(asterisk.Transformers[first:])
 	return asterisk
 }
 
 func (p *Parser) parseAsteriskReplace(asterisk *ast.Asterisk) ast.Expression {
 	pos := p.current.Pos
+	first := len(asterisk.Transformers)
 	p.nextToken() // skip REPLACE
 
 	// Check for STRICT modifier
@@ -3015,6 +3094,7 @@ func (p *Parser) parseAsteriskReplace(asterisk *ast.Asterisk) ast.Expression {
 			}
 		}
 
+		p.finish(replace)
 		asterisk.Replace = append(asterisk.Replace, replace)
 		replaces = append(replaces, replace)
 
@@ -3042,11 +3122,13 @@ func (p *Parser) parseAsteriskReplace(asterisk *ast.Asterisk) ast.Expression {
 		p.expect(token.RPAREN)
 	}
 
+	p.finishTransformers(asterisk.Transformers[first:])
 	return asterisk
 }
 
 func (p *Parser) parseAsteriskApply(asterisk *ast.Asterisk) ast.Expression {
 	pos := p.current.Pos
+	first := len(asterisk.Transformers)
 	p.nextToken() // skip APPLY
 
 	// APPLY can have optional parentheses: * APPLY(func) or * APPLY func
@@ -3100,11 +3182,13 @@ func (p *Parser) parseAsteriskApply(asterisk *ast.Asterisk) ast.Expression {
 		p.expect(token.RPAREN)
 	}
 
+	p.finishTransformers(asterisk.Transformers[first:])
 	return asterisk
 }
 
 func (p *Parser) parseColumnsApply(matcher *ast.ColumnsMatcher) ast.Expression {
 	pos := p.current.Pos
+	first := len(matcher.Transformers)
 	p.nextToken() // skip APPLY
 
 	// APPLY can have optional parentheses: COLUMNS(...) APPLY(func) or COLUMNS(...) APPLY func
@@ -3158,11 +3242,13 @@ func (p *Parser) parseColumnsApply(matcher *ast.ColumnsMatcher) ast.Expression {
 		p.expect(token.RPAREN)
 	}
 
+	p.finishTransformers(matcher.Transformers[first:])
 	return matcher
 }
 
 func (p *Parser) parseColumnsExcept(matcher *ast.ColumnsMatcher) ast.Expression {
 	pos := p.current.Pos
+	first := len(matcher.Transformers)
 	p.nextToken() // skip EXCEPT
 
 	// Check for STRICT modifier
@@ -3204,11 +3290,13 @@ func (p *Parser) parseColumnsExcept(matcher *ast.ColumnsMatcher) ast.Expression
 		p.expect(token.RPAREN)
 	}
 
+	p.finishTransformers(matcher.Transformers[first:])
 	return matcher
 }
 
 func (p *Parser) parseColumnsReplace(matcher *ast.ColumnsMatcher) ast.Expression {
 	pos := p.current.Pos
+	first := len(matcher.Transformers)
 	p.nextToken() // skip REPLACE
 
 	// Check for STRICT modifier
@@ -3250,6 +3338,7 @@ func (p *Parser) parseColumnsReplace(matcher *ast.ColumnsMatcher) ast.Expression
 			}
 		}
 
+		p.finish(replace)
 		matcher.Replace = append(matcher.Replace, replace)
 		replaces = append(replaces, replace)
 
@@ -3276,5 +3365,6 @@ func (p *Parser) parseColumnsReplace(matcher *ast.ColumnsMatcher) ast.Expression
 		p.expect(token.RPAREN)
 	}
 
+	p.finishTransformers(matcher.Transformers[first:])
 	return matcher
 }
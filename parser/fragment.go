@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/sqlc-dev/doubleclick/ast"
+	"github.com/sqlc-dev/doubleclick/token"
+)
+
+// ParseExpression parses a single expression, such as a column DEFAULT
+// expression.
+func ParseExpression(s string) (ast.Expression, error) {
+	var expr ast.Expression
+	err := parseFragment(s, func(p *Parser) bool {
+		expr = p.parseExpression(LOWEST)
+		return expr != nil
+	})
+	return expr, err
+}
+
+// ParseDataType parses a data type, such as the type column of
+// system.columns: Map(String, Array(Nullable(Decimal(18, 4)))).
+func ParseDataType(s string) (*ast.DataType, error) {
+	var dt *ast.DataType
+	err := parseFragment(s, func(p *Parser) bool {
+		dt = p.parseDataType()
+		return dt != nil
+	})
+	return dt, err
+}
+
+// ParseSettings parses a comma-separated list of settings as it follows
+// the SETTINGS keyword, such as max_threads = 8, readonly = 1.
+func ParseSettings(s string) ([]*ast.SettingExpr, error) {
+	var settings []*ast.SettingExpr
+	err := parseFragment(s, func(p *Parser) bool {
+		settings = p.parseSettingsList()
+		return len(settings) > 0
+	})
+	return settings, err
+}
+
+// ParseOrderBy parses a table sorting key as it follows ORDER BY in
+// CREATE TABLE: a comma-separated list of expressions, each optionally
+// followed by ASC or DESC. hasModifiers reports whether any ASC or DESC
+// was present.
+func ParseOrderBy(s string) (exprs []ast.Expression, hasModifiers bool, err error) {
+	err = parseFragment(s, func(p *Parser) bool {
+		exprs, hasModifiers = p.parseCreateOrderByExpressions()
+		return len(exprs) > 0
+	})
+	return exprs, hasModifiers, err
+}
+
+// parseFragment runs parse on s, which reports whether it parsed anything.
+// It is an error if parse records an error, parses nothing, or leaves
+// input other than comments and whitespace.
+func parseFragment(s string, parse func(p *Parser) bool) error {
+	p := New(strings.NewReader(s))
+	ok := parse(p)
+	if len(p.errors) == 0 {
+		if !ok {
+			p.unexpected()
+		} else if !p.currentIs(token.EOF) {
+			p.unexpected(token.EOF)
+		}
+	}
+	return p.errors.Err()
+}
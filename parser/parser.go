@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"iter"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -33,19 +35,50 @@ func isIntervalUnit(s string) bool {
 	return intervalUnits[strings.ToUpper(s)]
 }
 
+// Mode is a set of flags controlling optional parser behavior.
+type Mode uint
+
+const (
+	// RecoverErrors makes the parser skip to the next semicolon after a
+	// syntax error and return an *ast.BadStatement in place of the failed
+	// statement, so the remaining statements are still parsed.
+	RecoverErrors Mode = 1 << iota
+
+	// SkipComments makes the parser discard comments instead of keeping
+	// them for Comments, so that parsing with Next or All holds no more
+	// than the statement being parsed.
+	SkipComments
+)
+
 // Parser parses ClickHouse SQL statements.
 type Parser struct {
-	lexer    *lexer.Lexer
-	current  lexer.Item
-	peek     lexer.Item
-	peekPeek lexer.Item // Third lookahead token for special cases
-	errors   []error
+	lexer     *lexer.Lexer
+	mode      Mode
+	current   lexer.Item
+	peek      lexer.Item
+	peekPeek  lexer.Item     // Third lookahead token for special cases
+	prev      lexer.Item     // Most recently consumed token
+	prevEnd   token.Position // End of the most recently consumed token
+	comments  []*ast.Comment // Comments skipped so far, in source order
+	rawTokens int            // Tokens to consume before the lexer stops keeping raw input
+	statement int            // Index of the statement being parsed, for errors
+	errors    ErrorList
+
+	onValuesRow func(row *ValuesRow) error // Set by OnValuesRow
+	valuesErr   error                      // Error returned by onValuesRow
+	plainValues []plainValue               // Plain literals of the current VALUES row
 }
 
 // New creates a new Parser from an io.Reader.
 func New(r io.Reader) *Parser {
+	return NewWithMode(r, 0)
+}
+
+// NewWithMode creates a new Parser from an io.Reader with the given mode.
+func NewWithMode(r io.Reader, mode Mode) *Parser {
 	p := &Parser{
 		lexer: lexer.New(r),
+		mode:  mode,
 	}
 	// Read three tokens to initialize current, peek, and peekPeek
 	p.nextToken()
@@ -55,16 +88,53 @@ func New(r io.Reader) *Parser {
 }
 
 func (p *Parser) nextToken() {
+	if p.current.Token != token.EOF {
+		p.prev = p.current
+		p.prevEnd = p.current.End
+	}
 	p.current = p.peek
 	p.peek = p.peekPeek
+	// Keep the raw input after the last consumed token for readInlineData
+	if p.rawTokens > 0 {
+		p.rawTokens--
+		if p.rawTokens == 0 {
+			p.lexer.DropRaw()
+		} else {
+			p.lexer.Release(p.prevEnd.Offset)
+		}
+	}
 	for {
 		p.peekPeek = p.lexer.NextToken()
-		// Skip whitespace and comments
-		if p.peekPeek.Token == token.WHITESPACE || p.peekPeek.Token == token.LINE_COMMENT {
+		// Skip whitespace and comments, keeping the comments for Comments
+		if p.peekPeek.Token == token.LINE_COMMENT {
+			if p.mode&SkipComments != 0 {
+				continue
+			}
+			p.comments = append(p.comments, &ast.Comment{
+				Position:    p.peekPeek.Pos,
+				EndPosition: p.peekPeek.End,
+				Text:        p.peekPeek.Value,
+			})
+			continue
+		}
+		if p.peekPeek.Token == token.WHITESPACE {
 			continue
 		}
 		break
 	}
+	// Inline data starts after the name following FORMAT, and is read
+	// before the token after the name is consumed, five tokens from now
+	if p.peekPeek.Token == token.FORMAT {
+		p.lexer.KeepRaw()
+		p.rawTokens = 5
+	}
+}
+
+// Comments returns the comments read so far, in source order, or nil in
+// SkipComments mode. Use ast.NewCommentMap to associate them with the
+// parsed statements.
+func (p *Parser) Comments() []*ast.Comment {
+	return p.comments
 }
 
 func (p *Parser) currentIs(t token.Token) bool {
@@ -79,6 +149,25 @@ func (p *Parser) peekPeekIs(t token.Token) bool {
 	return p.peekPeek.Token == t
 }
 
+// currentIsIdent reports whether the current token is the unquoted
+// identifier word, ignoring case. It is used for words that are not
+// keywords of the lexer, such as ROLE or OPTION.
+func (p *Parser) currentIsIdent(word string) bool {
+	return isIdentWord(p.current, word)
+}
+
+func (p *Parser) peekIsIdent(word string) bool {
+	return isIdentWord(p.peek, word)
+}
+
+func (p *Parser) peekPeekIsIdent(word string) bool {
+	return isIdentWord(p.peekPeek, word)
+}
+
+func isIdentWord(item lexer.Item, word string) bool {
+	return item.Token == token.IDENT && !item.Quoted && strings.EqualFold(item.Value, word)
+}
+
 // isColumnsFunction checks if current token is COLUMNS function (for column expressions)
 func (p *Parser) isColumnsFunction() bool {
 	return p.currentIs(token.COLUMNS) && p.peekIs(token.LPAREN)
@@ -123,8 +212,7 @@ func (p *Parser) expect(t token.Token) bool {
 		p.nextToken()
 		return true
 	}
-	p.errors = append(p.errors, fmt.Errorf("expected %s, got %s at line %d, column %d",
-		t, p.current.Token, p.current.Pos.Line, p.current.Pos.Column))
+	p.errorAt(p.current, fmt.Sprintf("expected %s, got %s", t, p.current.Token), t)
 	return false
 }
 
@@ -133,8 +221,7 @@ func (p *Parser) expectPeek(t token.Token) bool {
 		p.nextToken()
 		return true
 	}
-	p.errors = append(p.errors, fmt.Errorf("expected %s, got %s at line %d, column %d",
-		t, p.peek.Token, p.peek.Pos.Line, p.peek.Pos.Column))
+	p.errorAt(p.peek, fmt.Sprintf("expected %s, got %s", t, p.peek.Token), t)
 	return false
 }
 
@@ -147,11 +234,42 @@ func Parse(ctx context.Context, r io.Reader) ([]ast.Statement, error) {
 // ParseStatements parses multiple SQL statements.
 func (p *Parser) ParseStatements(ctx context.Context) ([]ast.Statement, error) {
 	var statements []ast.Statement
+	var errs ErrorList
+
+	for {
+		stmt, err := p.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if list, ok := err.(ErrorList); ok {
+			errs = append(errs, list...)
+		} else if err != nil {
+			return statements, err
+		}
+		if stmt != nil {
+			statements = append(statements, stmt)
+		}
+	}
+
+	return statements, errs.Err()
+}
+
+// Next parses and returns the next statement. It returns io.EOF when the
+// input is exhausted, and an ErrorList if the statement has syntax errors,
+// together with whatever part of the statement was parsed (or an
+// *ast.BadStatement in RecoverErrors mode). Parsing can continue with the
+// following statement after an error.
+//
+// Only the statement being parsed is held in memory, so Next can process
+// arbitrarily large inputs. Comments are still collected for Comments
+// unless the parser is in SkipComments mode.
+func (p *Parser) Next(ctx context.Context) (ast.Statement, error) {
+	p.errors = nil
 
-	for !p.currentIs(token.EOF) {
+	for {
 		select {
 		case <-ctx.Done():
-			return statements, ctx.Err()
+			return nil, ctx.Err()
 		default:
 		}
 
@@ -160,28 +278,60 @@ func (p *Parser) ParseStatements(ctx context.Context) ([]ast.Statement, error) {
 			p.nextToken()
 		}
 		if p.currentIs(token.EOF) {
-			break
+			return nil, io.EOF
 		}
 
+		start := p.current
 		stmt := p.parseStatement()
 		if stmt != nil {
 			// Check for PARALLEL WITH to chain statements
 			if p.currentIs(token.PARALLEL) && p.peekIs(token.WITH) {
 				stmt = p.parseParallelWith(stmt)
 			}
-			statements = append(statements, stmt)
+		}
+		if p.mode&RecoverErrors != 0 {
+			stmt = p.recoverStatement(start, stmt)
 		}
 
 		// Skip semicolons between statements
+		if p.currentIs(token.SEMICOLON) {
+			p.statement++
+		}
 		for p.currentIs(token.SEMICOLON) {
 			p.nextToken()
 		}
+
+		if err := p.valuesErr; err != nil {
+			p.valuesErr = nil
+			p.errors = nil
+			return stmt, err
+		}
+		if stmt != nil || len(p.errors) > 0 {
+			errs := p.errors
+			p.errors = nil
+			return stmt, errs.Err()
+		}
 	}
+}
 
-	if len(p.errors) > 0 {
-		return statements, fmt.Errorf("parse errors: %v", p.errors)
+// All returns an iterator over the remaining statements and their errors,
+// as returned by Next. Iteration stops at the end of the input or when ctx
+// is done.
+func (p *Parser) All(ctx context.Context) iter.Seq2[ast.Statement, error] {
+	return func(yield func(ast.Statement, error) bool) {
+		for {
+			stmt, err := p.Next(ctx)
+			if err == io.EOF {
+				return
+			}
+			if !yield(stmt, err) {
+				return
+			}
+			if err != nil && err == ctx.Err() {
+				return
+			}
+		}
 	}
-	return statements, nil
 }
 
 // parseParallelWith parses PARALLEL WITH clauses to chain statements
@@ -200,10 +350,18 @@ func (p *Parser) parseParallelWith(first ast.Statement) *ast.ParallelWithQuery {
 		}
 	}
 
+	p.finish(parallel)
 	return parallel
 }
 
 func (p *Parser) parseStatement() ast.Statement {
+	stmt := p.parseStatementByKeyword()
+	p.finish(stmt)
+	return stmt
+}
+
+// parseStatementByKeyword dispatches on the leading keyword of a statement.
+func (p *Parser) parseStatementByKeyword() ast.Statement {
 	switch p.current.Token {
 	case token.SELECT:
 		return p.parseSelectWithUnion()
@@ -240,6 +398,14 @@ func (p *Parser) parseStatement() ast.Statement {
 		if p.peek.Token == token.IDENT && strings.ToUpper(p.peek.Value) == "ROLE" {
 			return p.parseDropRole()
 		}
+		// Check for DROP USER
+		if p.peekIs(token.USER) {
+			return p.parseDropUser()
+		}
+		// Check for DROP QUOTA
+		if p.peekIsIdent("QUOTA") {
+			return p.parseDropQuota()
+		}
 		// Check for DROP RESOURCE
 		if p.peek.Token == token.IDENT && strings.ToUpper(p.peek.Value) == "RESOURCE" {
 			return p.parseDropResource()
@@ -274,6 +440,10 @@ func (p *Parser) parseStatement() ast.Statement {
 		if p.peek.Token == token.IDENT && strings.ToUpper(p.peek.Value) == "ROLE" {
 			return p.parseAlterRole()
 		}
+		// Check for ALTER QUOTA
+		if p.peekIsIdent("QUOTA") {
+			return p.parseAlterQuota()
+		}
 		// Check for ALTER NAMED COLLECTION
 		if p.peek.Token == token.IDENT && strings.ToUpper(p.peek.Value) == "NAMED" {
 			return p.parseAlterNamedCollection()
@@ -296,8 +466,8 @@ func (p *Parser) parseStatement() ast.Statement {
 		if p.peekIs(token.TRANSACTION) {
 			return p.parseTransactionControl()
 		}
-		// Check for SET DEFAULT ROLE
-		if p.peekIs(token.DEFAULT) {
+		// Check for SET DEFAULT ROLE and SET ROLE, but not a setting named role
+		if p.peekIs(token.DEFAULT) || p.peekIsIdent("ROLE") && !p.peekPeekIs(token.EQ) {
 			return p.parseSetRole()
 		}
 		return p.parseSet()
@@ -322,10 +492,8 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseAttach()
 	case token.CHECK:
 		return p.parseCheck()
-	case token.GRANT:
+	case token.GRANT, token.REVOKE:
 		return p.parseGrant()
-	case token.REVOKE:
-		return p.parseRevoke()
 	case token.BEGIN:
 		return p.parseTransactionControl()
 	case token.COMMIT:
@@ -339,8 +507,7 @@ func (p *Parser) parseStatement() ast.Statement {
 	case token.KILL:
 		return p.parseKill()
 	default:
-		p.errors = append(p.errors, fmt.Errorf("unexpected token %s at line %d, column %d",
-			p.current.Token, p.current.Pos.Line, p.current.Pos.Column))
+		p.unexpected(statementStarts...)
 		p.nextToken()
 		return nil
 	}
@@ -372,6 +539,7 @@ func (p *Parser) parseWithStatement() ast.Statement {
 			// Store the WITH clause in InsertQuery.With for explain to handle
 			// Don't propagate to SelectQuery.With - the explain code will output
 			// the inherited WITH at the end of each SelectQuery's children
+			ins.Position = pos
 			ins.With = with
 		}
 		return ins
@@ -393,6 +561,8 @@ func (p *Parser) parseSelectWithUnionWithParsedWith(pos token.Position, with []a
 	if sel == nil {
 		return nil
 	}
+	// The first SELECT owns the WITH clause, so it starts at the WITH keyword
+	sel.Position = pos
 
 	// Check for INTERSECT/EXCEPT
 	if p.isIntersectExceptWithWrapper() {
@@ -485,20 +655,11 @@ func (p *Parser) parseSelectWithUnionWithParsedWith(pos token.Position, with []a
 			} else if p.currentIs(token.FORMAT) {
 				p.nextToken()
 				formatParsed = true
-				if len(query.Selects) > 0 {
-					if sq, ok := query.Selects[0].(*ast.SelectQuery); ok {
-						if p.currentIs(token.NULL) {
-							sq.Format = &ast.Identifier{Position: p.current.Pos, Parts: []string{"Null"}}
-							p.nextToken()
-						} else if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-							sq.Format = &ast.Identifier{Position: p.current.Pos, Parts: []string{p.current.Value}}
-							p.nextToken()
-						}
-					}
-				}
+				query.Format = p.parseFormatIdentifier()
 			}
 		}
 
+		p.finish(query)
 		return query
 	}
 
@@ -551,20 +712,11 @@ func (p *Parser) parseSelectWithUnionWithParsedWith(pos token.Position, with []a
 		} else if p.currentIs(token.FORMAT) {
 			p.nextToken()
 			formatParsed = true
-			if len(query.Selects) > 0 {
-				if sq, ok := query.Selects[0].(*ast.SelectQuery); ok {
-					if p.currentIs(token.NULL) {
-						sq.Format = &ast.Identifier{Position: p.current.Pos, Parts: []string{"Null"}}
-						p.nextToken()
-					} else if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-						sq.Format = &ast.Identifier{Position: p.current.Pos, Parts: []string{p.current.Value}}
-						p.nextToken()
-					}
-				}
-			}
+			query.Format = p.parseFormatIdentifier()
 		}
 	}
 
+	p.finish(query)
 	return query
 }
 
@@ -740,21 +892,11 @@ func (p *Parser) parseSelectWithUnion() *ast.SelectWithUnionQuery {
 		} else if p.currentIs(token.FORMAT) {
 			p.nextToken()
 			formatParsed = true
-			// Get the format name and attach to first SELECT
-			if len(query.Selects) > 0 {
-				if sq, ok := query.Selects[0].(*ast.SelectQuery); ok {
-					if p.currentIs(token.NULL) {
-						sq.Format = &ast.Identifier{Position: p.current.Pos, Parts: []string{"Null"}}
-						p.nextToken()
-					} else if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-						sq.Format = &ast.Identifier{Position: p.current.Pos, Parts: []string{p.current.Value}}
-						p.nextToken()
-					}
-				}
-			}
+			query.Format = p.parseFormatIdentifier()
 		}
 	}
 
+	p.finish(query)
 	return query
 }
 
@@ -780,6 +922,7 @@ func (p *Parser) parseIntersectExceptWithFirstOperand(unionQuery *ast.SelectWith
 		// EXCEPT has lower precedence than UNION
 		// Use the entire union as the first operand
 		firstOperand = unionQuery
+		p.finish(firstOperand)
 		// Create a new query to hold the result
 		unionQuery = &ast.SelectWithUnionQuery{
 			Position: unionQuery.Position,
@@ -876,6 +1019,7 @@ func (p *Parser) parseIntersectExceptWithFirstOperand(unionQuery *ast.SelectWith
 		}
 	}
 
+	p.finish(unionQuery)
 	return unionQuery
 }
 
@@ -934,6 +1078,7 @@ func (p *Parser) parseSelectWithUnionOnly() ast.Statement {
 		}
 	}
 
+	p.finish(query)
 	return query
 }
 
@@ -989,8 +1134,10 @@ func buildIntersectExceptTree(stmts []ast.Statement, ops []string) ast.Statement
 		} else {
 			// Multiple statements connected by INTERSECT
 			groupStmt = &ast.SelectIntersectExceptQuery{
-				Selects:   groupStmts,
-				Operators: groupOps,
+				Position:    groupStmts[0].Pos(),
+				EndPosition: groupStmts[len(groupStmts)-1].End(),
+				Selects:     groupStmts,
+				Operators:   groupOps,
 			}
 		}
 		groups = append(groups, groupStmt)
@@ -1018,8 +1165,10 @@ func buildIntersectExceptTree(stmts []ast.Statement, ops []string) ast.Statement
 	result := groups[0]
 	for j := 0; j < len(exceptOps); j++ {
 		result = &ast.SelectIntersectExceptQuery{
-			Selects:   []ast.Statement{result, groups[j+1]},
-			Operators: []string{exceptOps[j]},
+			Position:    result.Pos(),
+			EndPosition: groups[j+1].End(),
+			Selects:     []ast.Statement{result, groups[j+1]},
+			Operators:   []string{exceptOps[j]},
 		}
 	}
 	return result
@@ -1361,6 +1510,7 @@ func (p *Parser) parseSelectInternal(preParsedWith []ast.Expression) *ast.Select
 					sel.IntoOutfile.Truncate = true
 					p.nextToken()
 				}
+				p.finish(sel.IntoOutfile)
 			}
 		}
 	}
@@ -1375,6 +1525,7 @@ func (p *Parser) parseSelectInternal(preParsedWith []ast.Expression) *ast.Select
 				Parts:    []string{p.current.Value},
 			}
 			p.nextToken()
+			p.finish(sel.Format)
 		}
 		// Skip any inline data after FORMAT (e.g., FORMAT JSONEachRow {"x": 1}, {"y": 2})
 		// This can happen in INSERT ... SELECT ... FORMAT ... statements
@@ -1389,6 +1540,7 @@ func (p *Parser) parseSelectInternal(preParsedWith []ast.Expression) *ast.Select
 		}
 	}
 
+	p.finish(sel)
 	return sel
 }
 
@@ -1412,11 +1564,13 @@ func (p *Parser) parseWithClause() []ast.Expression {
 			// If IDENT AS IDENT -> scalar WITH (first ident is expression, second is alias)
 			name := p.current.Value
 			pos := p.current.Pos
+			nameEnd := p.current.End
 			p.nextToken() // skip identifier
 			p.nextToken() // skip AS
 
 			if p.currentIs(token.LPAREN) {
 				// Could be CTE: name AS (subquery) OR could be name AS (expr)
+				lparenPos := p.current.Pos
 				p.nextToken()
 				if p.currentIs(token.SELECT) || p.currentIs(token.WITH) {
 					// Standard CTE: name AS (SELECT...)
@@ -1425,7 +1579,8 @@ func (p *Parser) parseWithClause() []ast.Expression {
 						return nil
 					}
 					elem.Name = name
-					elem.Query = &ast.Subquery{Query: subquery}
+					elem.Query = &ast.Subquery{Position: lparenPos, Query: subquery}
+					p.finish(elem.Query)
 				} else {
 					// It's an expression in parentheses, use name as alias
 					// e.g., WITH x AS (1 + 2)
@@ -1440,12 +1595,12 @@ func (p *Parser) parseWithClause() []ast.Expression {
 				alias := p.current.Value
 				p.nextToken()
 				elem.Name = alias
-				elem.Query = &ast.Identifier{Position: pos, Parts: []string{name}}
+				elem.Query = &ast.Identifier{Position: pos, Parts: []string{name}, EndPosition: nameEnd}
 			} else {
 				// Scalar expression where the first identifier is used directly
 				// This is likely "name AS name" which means the CTE name is name with scalar value name
 				elem.Name = name
-				elem.Query = &ast.Identifier{Position: pos, Parts: []string{name}}
+				elem.Query = &ast.Identifier{Position: pos, Parts: []string{name}, EndPosition: nameEnd}
 			}
 		} else {
 			// Scalar WITH: expr AS name (ClickHouse style)
@@ -1470,6 +1625,7 @@ func (p *Parser) parseWithClause() []ast.Expression {
 			}
 		}
 
+		p.finish(elem)
 		elements = append(elements, elem)
 
 		if !p.currentIs(token.COMMA) {
@@ -1502,6 +1658,7 @@ func (p *Parser) parseTablesInSelect() *ast.TablesInSelectQuery {
 		tables.Tables = append(tables.Tables, elem)
 	}
 
+	p.finish(tables)
 	return tables
 }
 
@@ -1523,6 +1680,7 @@ func (p *Parser) parseTableElement() *ast.TablesInSelectQueryElement {
 	}
 
 	elem.Table = p.parseTableExpression()
+	p.finish(elem)
 	return elem
 }
 
@@ -1531,22 +1689,27 @@ func (p *Parser) parseTableElementWithJoin() *ast.TablesInSelectQueryElement {
 		Position: p.current.Pos,
 	}
 
-	// Handle comma join (implicit cross join)
+	// Handle comma join (implicit cross join), which starts at the table
+	// after the comma
 	if p.currentIs(token.COMMA) {
 		p.nextToken()
+		elem.Position = p.current.Pos
 		elem.Table = p.parseTableExpression()
 		// ClickHouse adds an empty TableJoin node for comma joins
 		if elem.Table != nil {
 			elem.Join = &ast.TableJoin{
-				Position: elem.Position,
+				Position:    elem.Position,
+				EndPosition: elem.Position,
 			}
 		}
+		p.finish(elem)
 		return elem
 	}
 
 	// Handle ARRAY JOIN or LEFT ARRAY JOIN
 	if p.currentIs(token.ARRAY) || (p.currentIs(token.LEFT) && p.peekIs(token.ARRAY)) {
 		elem.ArrayJoin = p.parseArrayJoin()
+		p.finish(elem)
 		return elem
 	}
 
@@ -1660,7 +1823,9 @@ func (p *Parser) parseTableElementWithJoin() *ast.TablesInSelectQueryElement {
 		}
 	}
 
+	p.finish(join)
 	elem.Join = join
+	p.finish(elem)
 	return elem
 }
 
@@ -1671,24 +1836,28 @@ func (p *Parser) parseTableExpression() *ast.TableExpression {
 
 	// Handle subquery
 	if p.currentIs(token.LPAREN) {
+		pos := p.current.Pos
 		p.nextToken()
 		if p.currentIs(token.SELECT) || p.currentIs(token.WITH) || p.currentIs(token.LPAREN) {
 			// SELECT, WITH, or nested (SELECT...) for UNION queries like ((SELECT 1) UNION ALL SELECT 2)
 			subquery := p.parseSelectWithUnion()
-			expr.Table = &ast.Subquery{Query: subquery}
+			expr.Table = &ast.Subquery{Position: pos, Query: subquery}
 		} else if p.currentIs(token.FROM) {
 			// FROM ... SELECT (ClickHouse extension) - e.g., FROM (FROM numbers(1) SELECT *)
 			subquery := p.parseFromSelectSyntax()
-			expr.Table = &ast.Subquery{Query: subquery}
+			expr.Table = &ast.Subquery{Position: pos, Query: subquery}
 		} else if p.currentIs(token.EXPLAIN) {
 			// EXPLAIN as subquery in FROM clause
 			explain := p.parseExplain()
-			expr.Table = &ast.Subquery{Query: explain}
+			expr.Table = &ast.Subquery{Position: pos, Query: explain}
 		} else {
 			// Table function or expression
 			expr.Table = p.parseExpression(LOWEST)
 		}
 		p.expect(token.RPAREN)
+		if _, ok := expr.Table.(*ast.Subquery); ok {
+			p.finish(expr.Table)
+		}
 	} else if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() || p.currentIs(token.NUMBER) {
 		// Table identifier or function (keywords can be table names like "system")
 		// Table names can also start with numbers in ClickHouse
@@ -1707,11 +1876,13 @@ func (p *Parser) parseTableExpression() *ast.TableExpression {
 				Database: ident,
 				Table:    tableName,
 			}
+			p.finish(expr.Table)
 		} else {
 			expr.Table = &ast.TableIdentifier{
 				Position: pos,
 				Table:    ident,
 			}
+			p.finish(expr.Table)
 		}
 	}
 
@@ -1727,6 +1898,7 @@ func (p *Parser) parseTableExpression() *ast.TableExpression {
 	} else if (p.currentIs(token.IDENT) || p.current.Token.IsKeyword()) && !p.isKeywordForClause() && !p.currentIs(token.FINAL) && !p.currentIs(token.SAMPLE) {
 		// Don't consume PARALLEL as alias if followed by WITH (parallel query syntax)
 		if p.currentIs(token.PARALLEL) && p.peekIs(token.WITH) {
+			p.finish(expr)
 			return expr
 		}
 		// Don't consume FINAL or SAMPLE as alias
@@ -1742,17 +1914,19 @@ func (p *Parser) parseTableExpression() *ast.TableExpression {
 
 	// Handle SAMPLE
 	if p.currentIs(token.SAMPLE) {
-		p.nextToken()
 		expr.Sample = &ast.SampleClause{
 			Position: p.current.Pos,
-			Ratio:    p.parseExpression(LOWEST),
 		}
+		p.nextToken()
+		expr.Sample.Ratio = p.parseExpression(LOWEST)
 		if p.currentIs(token.OFFSET) {
 			p.nextToken()
 			expr.Sample.Offset = p.parseExpression(LOWEST)
 		}
+		p.finish(expr.Sample)
 	}
 
+	p.finish(expr)
 	return expr
 }
 
@@ -1789,9 +1963,9 @@ func (p *Parser) parseOrderByList() []*ast.OrderByElement {
 
 	for {
 		elem := &ast.OrderByElement{
-			Position:   p.current.Pos,
-			Expression: p.parseExpression(LOWEST),
+			Position: p.current.Pos,
 		}
+		elem.Expression = p.parseExpression(LOWEST)
 
 		// Handle ASC/DESC
 		if p.currentIs(token.ASC) {
@@ -1855,6 +2029,7 @@ func (p *Parser) parseOrderByList() []*ast.OrderByElement {
 			}
 		}
 
+		p.finish(elem)
 		elements = append(elements, elem)
 
 		if !p.currentIs(token.COMMA) {
@@ -1898,6 +2073,7 @@ func (p *Parser) parseInterpolateList() []*ast.InterpolateElement {
 			elem.Value = p.parseExpression(LOWEST)
 		}
 
+		p.finish(elem)
 		elements = append(elements, elem)
 
 		if !p.currentIs(token.COMMA) {
@@ -1937,11 +2113,13 @@ func (p *Parser) parseSettingsList() []*ast.SettingExpr {
 		} else {
 			// Boolean setting without value - defaults to true
 			setting.Value = &ast.Literal{
-				Position: setting.Position,
-				Type:     ast.LiteralBoolean,
-				Value:    true,
+				Position:    setting.Position,
+				EndPosition: setting.Position,
+				Type:        ast.LiteralBoolean,
+				Value:       true,
 			}
 		}
+		p.finish(setting)
 		settings = append(settings, setting)
 
 		if !p.currentIs(token.COMMA) {
@@ -2025,8 +2203,9 @@ func (p *Parser) parseInsert() *ast.InsertQuery {
 						}
 					}
 					ins.Columns = append(ins.Columns, &ast.Identifier{
-						Position: pos,
-						Parts:    []string{colName},
+						Position:    pos,
+						EndPosition: p.prevEnd,
+						Parts:       []string{colName},
 					})
 				}
 				if p.currentIs(token.COMMA) {
@@ -2081,34 +2260,7 @@ func (p *Parser) parseInsert() *ast.InsertQuery {
 	if p.currentIs(token.VALUES) {
 		p.nextToken()
 		// Parse VALUES rows: (expr, expr, ...), (expr, expr, ...), ...
-		for {
-			if !p.currentIs(token.LPAREN) {
-				break
-			}
-			p.nextToken() // skip (
-			var row []ast.Expression
-			for !p.currentIs(token.RPAREN) && !p.currentIs(token.EOF) {
-				expr := p.parseExpression(LOWEST)
-				if expr != nil {
-					row = append(row, expr)
-				}
-				if p.currentIs(token.COMMA) {
-					p.nextToken()
-				} else {
-					break
-				}
-			}
-			if p.currentIs(token.RPAREN) {
-				p.nextToken() // skip )
-			}
-			ins.Values = append(ins.Values, row)
-			// Check for more rows
-			if p.currentIs(token.COMMA) {
-				p.nextToken()
-			} else {
-				break
-			}
-		}
+		p.parseValues(ins)
 	} else if p.currentIs(token.SELECT) || p.currentIs(token.WITH) {
 		ins.Select = p.parseSelectWithUnion()
 		// If the SELECT has settings, mark the INSERT as having settings too
@@ -2133,17 +2285,66 @@ func (p *Parser) parseInsert() *ast.InsertQuery {
 				Parts:    []string{p.current.Value},
 			}
 			p.nextToken()
+			p.finish(ins.Format)
 		}
-		// Skip any inline data after FORMAT (e.g., FORMAT JSONEachRow {"x": 1}, {"y": 2})
-		// The data is raw and should not be parsed as SQL
-		for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-			p.nextToken()
+		if ins.Format != nil && ins.Infile == "" {
+			// Inline data (e.g., FORMAT JSONEachRow {"x": 1}, {"y": 2}) is raw
+			// and runs to the end of the input
+			ins.Data, ins.DataPosition, ins.DataEndPosition = p.readInlineData()
+		} else {
+			for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+				p.nextToken()
+			}
 		}
 	}
 
 	return ins
 }
 
+// readInlineData reads the rest of the input as the raw data of an INSERT,
+// starting after the format name, which must be the last consumed token.
+// Like ClickHouse, it skips the blanks after the format name and at most
+// one line break before the data.
+func (p *Parser) readInlineData() (string, token.Position, token.Position) {
+	from := p.prevEnd
+	start := from
+	data, end, err := p.lexer.ReadRaw(from)
+	if err != nil {
+		p.errorAt(p.current, err.Error())
+		return "", start, start
+	}
+
+	skip := len(data) - len(strings.TrimLeft(data, " \t\f"))
+	start.Offset += skip
+	start.Column += skip
+	if strings.HasPrefix(data[skip:], "\r") {
+		skip++
+		start.Offset++
+		start.Column++
+	}
+	if strings.HasPrefix(data[skip:], "\n") {
+		skip++
+		start.Offset++
+		start.Line++
+		start.Column = 1
+	}
+	data = data[skip:]
+
+	// The tokens read ahead were part of the data
+	eof := lexer.Item{Token: token.EOF, Pos: end, End: end}
+	p.current, p.peek, p.peekPeek = eof, eof, eof
+	for i, c := range p.comments {
+		if c.Pos().Offset >= from.Offset {
+			p.comments = p.comments[:i]
+			break
+		}
+	}
+	if data != "" {
+		p.prevEnd = end
+	}
+	return data, start, end
+}
+
 func (p *Parser) parseCreate() ast.Statement {
 	pos := p.current.Pos
 	p.nextToken() // skip CREATE
@@ -2160,34 +2361,34 @@ func (p *Parser) parseCreate() ast.Statement {
 		}
 	}
 
-	create := &ast.CreateQuery{
-		Position: pos,
-	}
-
 	// Handle OR REPLACE
+	orReplace := false
 	if p.currentIs(token.OR) {
 		p.nextToken()
 		if p.currentIs(token.REPLACE) {
-			create.OrReplace = true
+			orReplace = true
 			p.nextToken()
 		}
 	}
 
 	// Handle TEMPORARY
+	temporary := false
 	if p.currentIs(token.TEMPORARY) {
-		create.Temporary = true
+		temporary = true
 		p.nextToken()
 	}
 
 	// Handle MATERIALIZED
+	materialized := false
 	if p.currentIs(token.MATERIALIZED) {
-		create.Materialized = true
+		materialized = true
 		p.nextToken()
 	}
 
 	// Handle WINDOW (for WINDOW VIEW)
+	window := false
 	if p.currentIs(token.WINDOW) {
-		create.WindowView = true
+		window = true
 		p.nextToken()
 	}
 
@@ -2195,82 +2396,94 @@ func (p *Parser) parseCreate() ast.Statement {
 	switch p.current.Token {
 	case token.TABLE:
 		p.nextToken()
-		p.parseCreateTable(create)
+		return p.parseCreateTable(pos, orReplace, temporary)
 	case token.DATABASE:
-		create.CreateDatabase = true
 		p.nextToken()
-		p.parseCreateDatabase(create)
+		return p.parseCreateDatabase(pos)
 	case token.VIEW:
 		p.nextToken()
-		p.parseCreateView(create)
+		return p.parseCreateView(pos, orReplace, materialized, window)
 	case token.FUNCTION:
 		// CREATE FUNCTION name AS lambda_expr
-		create.CreateFunction = true
 		p.nextToken()
-		p.parseCreateFunction(create)
+		return p.parseCreateFunction(pos, orReplace)
 	case token.USER:
 		// CREATE USER name ...
-		create.CreateUser = true
-		p.nextToken()
-		p.parseCreateUser(create)
+		return p.parseCreateUser(pos, orReplace)
 	case token.SETTINGS:
 		// CREATE SETTINGS PROFILE
-		return p.parseCreateSettingsProfile(pos)
+		return p.parseCreateSettingsProfile(pos, orReplace)
 	case token.IDENT:
 		// Handle CREATE DICTIONARY, CREATE RESOURCE, CREATE WORKLOAD, CREATE NAMED COLLECTION, etc.
 		identUpper := strings.ToUpper(p.current.Value)
 		switch identUpper {
 		case "DICTIONARY":
-			create.CreateDictionary = true
 			p.nextToken()
-			p.parseCreateDictionary(create)
+			return p.parseCreateDictionary(pos, orReplace)
 		case "NAMED":
 			// CREATE NAMED COLLECTION name AS key=value, ...
 			return p.parseCreateNamedCollection(pos)
 		case "PROFILE":
 			// CREATE PROFILE (without SETTINGS keyword)
-			return p.parseCreateSettingsProfile(pos)
+			return p.parseCreateSettingsProfile(pos, orReplace)
 		case "ROW":
 			// CREATE ROW POLICY
-			return p.parseCreateRowPolicy(pos)
+			return p.parseCreateRowPolicy(pos, orReplace)
 		case "POLICY":
 			// CREATE POLICY (without ROW keyword)
-			return p.parseCreateRowPolicy(pos)
+			return p.parseCreateRowPolicy(pos, orReplace)
 		case "ROLE":
 			// CREATE ROLE
-			return p.parseCreateRole(pos)
+			return p.parseCreateRole(pos, orReplace)
 		case "RESOURCE":
 			// CREATE RESOURCE
-			return p.parseCreateResource(pos)
+			return p.parseCreateResource(pos, orReplace)
 		case "WORKLOAD":
 			// CREATE WORKLOAD
-			return p.parseCreateWorkload(pos)
+			return p.parseCreateWorkload(pos, orReplace)
 		case "QUOTA":
 			// CREATE QUOTA
-			return p.parseCreateQuota(pos)
-		default:
-			p.errors = append(p.errors, fmt.Errorf("expected TABLE, DATABASE, VIEW, FUNCTION, USER after CREATE"))
-			return nil
+			return p.parseCreateQuota(pos, orReplace)
 		}
-	default:
-		p.errors = append(p.errors, fmt.Errorf("expected TABLE, DATABASE, VIEW, FUNCTION, USER after CREATE"))
-		return nil
 	}
+	p.errorAt(p.current, "expected TABLE, DATABASE, VIEW, FUNCTION, USER after CREATE", createKinds...)
+	return nil
+}
 
-	// Handle FORMAT clause (for things like CREATE TABLE ... FORMAT Null)
-	if p.currentIs(token.FORMAT) {
+// parseFormatClause parses the FORMAT clause that may end a CREATE or DROP
+// statement, as in CREATE TABLE ... FORMAT Null, returning the format name.
+func (p *Parser) parseFormatClause() string {
+	if !p.currentIs(token.FORMAT) {
+		return ""
+	}
+	p.nextToken()
+	switch {
+	case p.currentIs(token.NULL):
 		p.nextToken()
-		// Store format name (Null, etc.)
-		if p.currentIs(token.NULL) {
-			create.Format = "Null"
-			p.nextToken()
-		} else if p.currentIs(token.IDENT) {
-			create.Format = p.current.Value
-			p.nextToken()
-		}
+		return "Null"
+	case p.currentIs(token.IDENT):
+		format := p.current.Value
+		p.nextToken()
+		return format
 	}
+	return ""
+}
 
-	return create
+// parseFormatIdentifier parses the format name after FORMAT at the end of
+// a union of SELECTs. The name may be a keyword, as in FORMAT Null.
+func (p *Parser) parseFormatIdentifier() *ast.Identifier {
+	var format *ast.Identifier
+	switch {
+	case p.currentIs(token.NULL):
+		format = &ast.Identifier{Position: p.current.Pos, Parts: []string{"Null"}}
+	case p.currentIs(token.IDENT) || p.current.Token.IsKeyword():
+		format = &ast.Identifier{Position: p.current.Pos, Parts: []string{p.current.Value}}
+	default:
+		return nil
+	}
+	p.nextToken()
+	p.finish(format)
+	return format
 }
 
 // parseReplace handles REPLACE TABLE/DICTIONARY syntax, which is equivalent to CREATE OR REPLACE
@@ -2281,28 +2494,13 @@ func (p *Parser) parseReplace() ast.Statement {
 	// REPLACE TABLE name ...
 	if p.currentIs(token.TABLE) {
 		p.nextToken() // skip TABLE
-
-		create := &ast.CreateQuery{
-			Position:  pos,
-			OrReplace: true, // REPLACE TABLE implies OR REPLACE
-		}
-
-		p.parseCreateTable(create)
-		return create
+		return p.parseCreateTable(pos, true, false)
 	}
 
 	// REPLACE DICTIONARY name ...
 	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "DICTIONARY" {
 		p.nextToken() // skip DICTIONARY
-
-		create := &ast.CreateQuery{
-			Position:         pos,
-			OrReplace:        true,
-			CreateDictionary: true,
-		}
-
-		p.parseCreateDictionary(create)
-		return create
+		return p.parseCreateDictionary(pos, true)
 	}
 
 	return nil
@@ -2392,7 +2590,13 @@ func (p *Parser) parseCreateIndex(pos token.Position) *ast.CreateIndexQuery {
 	return query
 }
 
-func (p *Parser) parseCreateTable(create *ast.CreateQuery) {
+func (p *Parser) parseCreateTable(pos token.Position, orReplace, temporary bool) *ast.CreateTableQuery {
+	create := &ast.CreateTableQuery{
+		Position:  pos,
+		OrReplace: orReplace,
+		Temporary: temporary,
+	}
+
 	// Handle IF NOT EXISTS
 	if p.currentIs(token.IF) {
 		p.nextToken()
@@ -2463,23 +2667,26 @@ func (p *Parser) parseCreateTable(create *ast.CreateQuery) {
 				}
 			} else if p.currentIs(token.CONSTRAINT) {
 				// Parse CONSTRAINT name CHECK/ASSUME (expression)
+				constraintPos := p.current.Pos
 				p.nextToken() // skip CONSTRAINT
 				constraintName := p.parseIdentifierName() // constraint name
 				if p.currentIs(token.CHECK) {
 					p.nextToken() // skip CHECK
 					constraint := &ast.Constraint{
-						Position:   p.current.Pos,
-						Name:       constraintName,
-						Expression: p.parseExpression(LOWEST),
+						Position: constraintPos,
+						Name:     constraintName,
 					}
+					constraint.Expression = p.parseExpression(LOWEST)
+					p.finish(constraint)
 					create.Constraints = append(create.Constraints, constraint)
 				} else if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ASSUME" {
 					p.nextToken() // skip ASSUME
 					constraint := &ast.Constraint{
-						Position:   p.current.Pos,
-						Name:       constraintName,
-						Expression: p.parseExpression(LOWEST),
+						Position: constraintPos,
+						Name:     constraintName,
 					}
+					constraint.Expression = p.parseExpression(LOWEST)
+					p.finish(constraint)
 					create.Constraints = append(create.Constraints, constraint)
 				} else {
 					// Skip other constraint types we don't know about
@@ -2544,7 +2751,8 @@ func (p *Parser) parseCreateTable(create *ast.CreateQuery) {
 	}
 
 	// Parse table options in flexible order (PARTITION BY, ORDER BY, PRIMARY KEY, etc.)
-	p.parseTableOptions(create)
+	var opts tableOptions
+	p.parseTableOptions(&opts)
 
 	// Parse AS SELECT or AS (subquery) or AS table_function() or AS database.table
 	if p.currentIs(token.AS) {
@@ -2554,6 +2762,7 @@ func (p *Parser) parseCreateTable(create *ast.CreateQuery) {
 			create.AsSelect = p.parseSelectWithUnion()
 		} else if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
 			// AS table_function(...) or AS database.table
+			namePos := p.current.Pos
 			name := p.parseIdentifierName()
 			if p.currentIs(token.DOT) {
 				// AS database.table - skip the table name
@@ -2561,7 +2770,7 @@ func (p *Parser) parseCreateTable(create *ast.CreateQuery) {
 				p.parseIdentifierName()
 			} else if p.currentIs(token.LPAREN) {
 				// AS function(...) - parse as a function call
-				fn := &ast.FunctionCall{Name: name}
+				fn := &ast.FunctionCall{Position: namePos, Name: name}
 				p.nextToken() // skip (
 				if !p.currentIs(token.RPAREN) {
 					fn.Arguments = p.parseExpressionList()
@@ -2569,6 +2778,7 @@ func (p *Parser) parseCreateTable(create *ast.CreateQuery) {
 				if p.currentIs(token.RPAREN) {
 					p.nextToken()
 				}
+				p.finish(fn)
 				create.AsTableFunction = fn
 			}
 			_ = name // Use name for future AS table support
@@ -2585,18 +2795,46 @@ func (p *Parser) parseCreateTable(create *ast.CreateQuery) {
 	}
 
 	// Parse table options after AS ... ENGINE (PARTITION BY, ORDER BY, etc.)
-	p.parseTableOptions(create)
+	p.parseTableOptions(&opts)
+
+	create.PartitionBy = opts.partitionBy
+	create.OrderBy = opts.orderBy
+	create.OrderByHasModifiers = opts.orderByHasModifiers
+	create.PrimaryKey = opts.primaryKey
+	create.SampleBy = opts.sampleBy
+	create.TTL = opts.ttl
+	create.Settings = opts.settings
+	create.QuerySettings = opts.querySettings
+	create.SettingsBeforeComment = opts.settingsBeforeComment
+	create.Comment = opts.comment
+	create.Format = p.parseFormatClause()
+	return create
+}
+
+// tableOptions holds the clauses that follow the columns and engine of a
+// table or materialized view, which parseTableOptions reads in any order.
+type tableOptions struct {
+	partitionBy           ast.Expression
+	orderBy               []ast.Expression
+	orderByHasModifiers   bool // ORDER BY has ASC/DESC modifiers
+	primaryKey            []ast.Expression
+	sampleBy              ast.Expression
+	ttl                   *ast.TTLClause
+	settings              []*ast.SettingExpr
+	querySettings         []*ast.SettingExpr // Second SETTINGS clause
+	settingsBeforeComment bool
+	comment               string
 }
 
-// parseTableOptions parses table options: PARTITION BY, ORDER BY, PRIMARY KEY, SAMPLE BY, TTL, SETTINGS
-func (p *Parser) parseTableOptions(create *ast.CreateQuery) {
+// parseTableOptions parses table options: PARTITION BY, ORDER BY, PRIMARY KEY, SAMPLE BY, TTL, SETTINGS, COMMENT
+func (p *Parser) parseTableOptions(opts *tableOptions) {
 	for {
 		switch {
 		case p.currentIs(token.PARTITION):
 			p.nextToken()
 			if p.expect(token.BY) {
 				// Use ALIAS_PREC to avoid consuming AS keyword (for AS SELECT)
-				create.PartitionBy = p.parseExpression(ALIAS_PREC)
+				opts.partitionBy = p.parseExpression(ALIAS_PREC)
 			}
 		case p.currentIs(token.ORDER):
 			p.nextToken()
@@ -2607,13 +2845,14 @@ func (p *Parser) parseTableOptions(create *ast.CreateQuery) {
 					exprs, hasModifier := p.parseCreateOrderByExpressions()
 					p.expect(token.RPAREN)
 					// Track if any ASC/DESC modifiers were present
-					create.OrderByHasModifiers = hasModifier
+					opts.orderByHasModifiers = hasModifier
 					// Store tuple literal for ORDER BY with multiple exprs, empty tuple, or any with ASC/DESC modifiers
 					if len(exprs) == 0 || len(exprs) > 1 || hasModifier {
-						create.OrderBy = []ast.Expression{&ast.Literal{
-							Position: pos,
-							Type:     ast.LiteralTuple,
-							Value:    exprs,
+						opts.orderBy = []ast.Expression{&ast.Literal{
+							Position:    pos,
+							EndPosition: p.prevEnd,
+							Type:        ast.LiteralTuple,
+							Value:       exprs,
 						}}
 					} else {
 						// Single expression in parentheses without modifiers
@@ -2627,15 +2866,15 @@ func (p *Parser) parseTableOptions(create *ast.CreateQuery) {
 							// Continue parsing from this expression as left operand
 							expr = p.parseExpressionFrom(expr, LOWEST)
 						}
-						create.OrderBy = []ast.Expression{expr}
+						opts.orderBy = []ast.Expression{expr}
 					}
 				} else {
 					// Use ALIAS_PREC to avoid consuming AS keyword (for AS SELECT)
 					expr := p.parseExpression(ALIAS_PREC)
-					create.OrderBy = []ast.Expression{expr}
+					opts.orderBy = []ast.Expression{expr}
 					// Handle ASC/DESC modifier after single non-parenthesized ORDER BY expression
 					if p.currentIs(token.ASC) || p.currentIs(token.DESC) {
-						create.OrderByHasModifiers = true
+						opts.orderByHasModifiers = true
 						p.nextToken()
 					}
 				}
@@ -2650,70 +2889,72 @@ func (p *Parser) parseTableOptions(create *ast.CreateQuery) {
 					p.expect(token.RPAREN)
 					// Store tuple literal for PRIMARY KEY (expr1, expr2, ...) or PRIMARY KEY ()
 					if len(exprs) == 0 || len(exprs) > 1 {
-						create.PrimaryKey = []ast.Expression{&ast.Literal{
-							Position: pos,
-							Type:     ast.LiteralTuple,
-							Value:    exprs,
+						opts.primaryKey = []ast.Expression{&ast.Literal{
+							Position:    pos,
+							EndPosition: p.prevEnd,
+							Type:        ast.LiteralTuple,
+							Value:       exprs,
 						}}
 					} else {
 						// Single expression in parentheses - just extract it
-						create.PrimaryKey = exprs
+						opts.primaryKey = exprs
 					}
 				} else {
 					// Use ALIAS_PREC to avoid consuming AS keyword (for AS SELECT)
-					create.PrimaryKey = []ast.Expression{p.parseExpression(ALIAS_PREC)}
+					opts.primaryKey = []ast.Expression{p.parseExpression(ALIAS_PREC)}
 				}
 			}
 		case p.currentIs(token.SAMPLE):
 			p.nextToken()
 			if p.expect(token.BY) {
 				// Use ALIAS_PREC to avoid consuming AS keyword (for AS SELECT)
-				create.SampleBy = p.parseExpression(ALIAS_PREC)
+				opts.sampleBy = p.parseExpression(ALIAS_PREC)
 			}
 		case p.currentIs(token.TTL):
 			p.nextToken()
-			create.TTL = &ast.TTLClause{
+			opts.ttl = &ast.TTLClause{
 				Position: p.current.Pos,
 			}
 			// Parse TTL elements (comma-separated)
 			for {
 				elem := p.parseTTLElement()
-				create.TTL.Elements = append(create.TTL.Elements, elem)
+				opts.ttl.Elements = append(opts.ttl.Elements, elem)
 				if p.currentIs(token.COMMA) {
 					p.nextToken()
 				} else {
 					break
 				}
 			}
+			p.finish(opts.ttl)
 			// Keep backward compatibility with Expression/Expressions fields
-			if len(create.TTL.Elements) > 0 {
-				create.TTL.Expression = create.TTL.Elements[0].Expr
-				for i := 1; i < len(create.TTL.Elements); i++ {
-					create.TTL.Expressions = append(create.TTL.Expressions, create.TTL.Elements[i].Expr)
+			if len(opts.ttl.Elements) > 0 {
+				opts.ttl.Expression = opts.ttl.Elements[0].Expr
+				for i := 1; i < len(opts.ttl.Elements); i++ {
+					opts.ttl.Expressions = append(opts.ttl.Expressions, opts.ttl.Elements[i].Expr)
 				}
 			}
 		case p.currentIs(token.SETTINGS):
 			// Track if SETTINGS comes before COMMENT
-			if create.Comment == "" && len(create.Settings) == 0 {
-				create.SettingsBeforeComment = true
+			if opts.comment == "" && len(opts.settings) == 0 {
+				opts.settingsBeforeComment = true
 			}
 			p.nextToken()
 			settings := p.parseSettingsList()
 			// If Settings is already set, this is a second SETTINGS clause (query-level)
-			if len(create.Settings) > 0 {
-				create.QuerySettings = settings
+			if len(opts.settings) > 0 {
+				opts.querySettings = settings
 			} else {
-				create.Settings = settings
+				opts.settings = settings
 			}
 		case p.currentIs(token.COMMENT):
 			p.nextToken()
 			if p.currentIs(token.STRING) {
-				create.Comment = p.current.Value
+				opts.comment = p.current.Value
 				p.nextToken()
 			}
 			// If we see COMMENT but Settings wasn't set yet, clear the flag
-			if len(create.Settings) == 0 {
-				create.SettingsBeforeComment = false
+			if len(opts.settings) == 0 {
+				opts.settingsBeforeComment = false
 			}
 		default:
 			return
@@ -2721,7 +2962,11 @@ func (p *Parser) parseTableOptions(create *ast.CreateQuery) {
 	}
 }
 
-func (p *Parser) parseCreateDatabase(create *ast.CreateQuery) {
+func (p *Parser) parseCreateDatabase(pos token.Position) *ast.CreateDatabaseQuery {
+	create := &ast.CreateDatabaseQuery{
+		Position: pos,
+	}
+
 	// Handle IF NOT EXISTS
 	if p.currentIs(token.IF) {
 		p.nextToken()
@@ -2770,16 +3015,134 @@ func (p *Parser) parseCreateDatabase(create *ast.CreateQuery) {
 		p.nextToken()
 		create.Settings = p.parseSettingsList()
 	}
+
+	create.Format = p.parseFormatClause()
+	return create
 }
 
-func (p *Parser) parseCreateView(create *ast.CreateQuery) {
+// viewDefinition holds the clauses of a CREATE [MATERIALIZED | WINDOW] VIEW
+// statement, from which parseCreateView builds the node for the kind of view.
+type viewDefinition struct {
+	ifNotExists       bool
+	database          string
+	view              string
+	onCluster         string
+	hasRefresh        bool
+	refreshType       string
+	refreshInterval   ast.Expression
+	refreshUnit       string
+	refreshAppend     bool
+	empty             bool
+	toDatabase        string
+	to                string
+	columns           []*ast.ColumnDeclaration
+	indexes           []*ast.IndexDefinition
+	projections       []*ast.Projection
+	columnsPrimaryKey []ast.Expression
+	innerEngine       *ast.EngineClause
+	engine            *ast.EngineClause
+	opts              tableOptions
+	populate          bool
+	asSelect          ast.Statement
+	format            string
+}
+
+func (p *Parser) parseCreateView(pos token.Position, orReplace, materialized, window bool) ast.Statement {
+	var def viewDefinition
+	p.parseViewDefinition(&def, materialized || window)
+	if format := p.parseFormatClause(); format != "" {
+		def.format = format
+	}
+
+	switch {
+	case materialized:
+		return &ast.CreateMaterializedViewQuery{
+			Position:              pos,
+			OrReplace:             orReplace,
+			IfNotExists:           def.ifNotExists,
+			Database:              def.database,
+			View:                  def.view,
+			OnCluster:             def.onCluster,
+			HasRefresh:            def.hasRefresh,
+			RefreshType:           def.refreshType,
+			RefreshInterval:       def.refreshInterval,
+			RefreshUnit:           def.refreshUnit,
+			RefreshAppend:         def.refreshAppend,
+			Empty:                 def.empty,
+			ToDatabase:            def.toDatabase,
+			To:                    def.to,
+			Columns:               def.columns,
+			Indexes:               def.indexes,
+			Projections:           def.projections,
+			ColumnsPrimaryKey:     def.columnsPrimaryKey,
+			Engine:                def.engine,
+			OrderBy:               def.opts.orderBy,
+			OrderByHasModifiers:   def.opts.orderByHasModifiers,
+			PartitionBy:           def.opts.partitionBy,
+			PrimaryKey:            def.opts.primaryKey,
+			SampleBy:              def.opts.sampleBy,
+			TTL:                   def.opts.ttl,
+			Settings:              def.opts.settings,
+			QuerySettings:         def.opts.querySettings,
+			SettingsBeforeComment: def.opts.settingsBeforeComment,
+			Populate:              def.populate,
+			AsSelect:              def.asSelect,
+			Comment:               def.opts.comment,
+			Format:                def.format,
+		}
+	case window:
+		return &ast.CreateWindowViewQuery{
+			Position:              pos,
+			OrReplace:             orReplace,
+			IfNotExists:           def.ifNotExists,
+			Database:              def.database,
+			View:                  def.view,
+			OnCluster:             def.onCluster,
+			ToDatabase:            def.toDatabase,
+			To:                    def.to,
+			Columns:               def.columns,
+			InnerEngine:           def.innerEngine,
+			Engine:                def.engine,
+			OrderBy:               def.opts.orderBy,
+			OrderByHasModifiers:   def.opts.orderByHasModifiers,
+			PartitionBy:           def.opts.partitionBy,
+			PrimaryKey:            def.opts.primaryKey,
+			SampleBy:              def.opts.sampleBy,
+			TTL:                   def.opts.ttl,
+			Settings:              def.opts.settings,
+			QuerySettings:         def.opts.querySettings,
+			SettingsBeforeComment: def.opts.settingsBeforeComment,
+			Populate:              def.populate,
+			AsSelect:              def.asSelect,
+			Comment:               def.opts.comment,
+			Format:                def.format,
+		}
+	}
+	return &ast.CreateViewQuery{
+		Position:    pos,
+		OrReplace:   orReplace,
+		IfNotExists: def.ifNotExists,
+		Database:    def.database,
+		View:        def.view,
+		OnCluster:   def.onCluster,
+		Columns:     def.columns,
+		AsSelect:    def.asSelect,
+		Comment:     def.opts.comment,
+		Format:      def.format,
+	}
+}
+
+// parseViewDefinition parses a view definition after the VIEW keyword.
+// hasTarget is set for materialized and window views, which may have a TO
+// target table.
+func (p *Parser) parseViewDefinition(def *viewDefinition, hasTarget bool) {
 	// Handle IF NOT EXISTS
 	if p.currentIs(token.IF) {
 		p.nextToken()
 		if p.currentIs(token.NOT) {
 			p.nextToken()
 			if p.currentIs(token.EXISTS) {
-				create.IfNotExists = true
+				def.ifNotExists = true
 				p.nextToken()
 			}
 		}
@@ -2790,10 +3153,10 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 	if viewName != "" {
 		if p.currentIs(token.DOT) {
 			p.nextToken()
-			create.Database = viewName
-			create.View = p.parseIdentifierName()
+			def.database = viewName
+			def.view = p.parseIdentifierName()
 		} else {
-			create.View = viewName
+			def.view = viewName
 		}
 	}
 
@@ -2811,29 +3174,29 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 		p.nextToken()
 		if p.currentIs(token.CLUSTER) {
 			p.nextToken()
-			create.OnCluster = p.parseIdentifierName()
+			def.onCluster = p.parseIdentifierName()
 		}
 	}
 
 	// Handle REFRESH clause for materialized views (REFRESH AFTER/EVERY interval APPEND TO target)
 	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "REFRESH" {
 		p.nextToken() // skip REFRESH
-		create.HasRefresh = true
+		def.hasRefresh = true
 
 		// Parse refresh timing: AFTER interval or EVERY interval
 		if p.currentIs(token.IDENT) {
 			upper := strings.ToUpper(p.current.Value)
 			if upper == "AFTER" || upper == "EVERY" {
-				create.RefreshType = upper
+				def.refreshType = upper
 				p.nextToken()
 				// Parse interval value and unit
-				create.RefreshInterval = p.parseExpression(AND_PREC)
+				def.refreshInterval = p.parseExpression(AND_PREC)
 				// Parse interval unit if present as identifier
 				if p.currentIs(token.IDENT) {
 					unitUpper := strings.ToUpper(p.current.Value)
 					if unitUpper == "SECOND" || unitUpper == "MINUTE" || unitUpper == "HOUR" ||
 						unitUpper == "DAY" || unitUpper == "WEEK" || unitUpper == "MONTH" || unitUpper == "YEAR" {
-						create.RefreshUnit = unitUpper
+						def.refreshUnit = unitUpper
 						p.nextToken()
 					}
 				}
@@ -2843,27 +3206,27 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 		// Handle APPEND TO target - different from regular TO, part of REFRESH strategy
 		if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "APPEND" {
 			p.nextToken() // skip APPEND
-			create.RefreshAppend = true
+			def.refreshAppend = true
 			if p.currentIs(token.TO) {
 				p.nextToken() // skip TO
 				toName := p.parseIdentifierName()
 				if p.currentIs(token.DOT) {
 					p.nextToken()
-					create.ToDatabase = toName
-					create.To = p.parseIdentifierName()
+					def.toDatabase = toName
+					def.to = p.parseIdentifierName()
 				} else {
-					create.To = toName
+					def.to = toName
 				}
 			}
 		}
 
 		// For REFRESH ... APPEND TO target (columns), column definitions come after
-		if p.currentIs(token.LPAREN) && len(create.Columns) == 0 {
+		if p.currentIs(token.LPAREN) && len(def.columns) == 0 {
 			p.nextToken()
 			for !p.currentIs(token.RPAREN) && !p.currentIs(token.EOF) {
 				col := p.parseColumnDeclaration()
 				if col != nil {
-					create.Columns = append(create.Columns, col)
+					def.columns = append(def.columns, col)
 				}
 				if p.currentIs(token.COMMA) {
 					p.nextToken()
@@ -2876,7 +3239,7 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 
 		// Handle EMPTY keyword
 		if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "EMPTY" {
-			create.Empty = true
+			def.empty = true
 			p.nextToken()
 		}
 	}
@@ -2890,14 +3253,14 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 			if p.currentIs(token.INDEX) {
 				idx := p.parseIndexDefinition()
 				if idx != nil {
-					create.Indexes = append(create.Indexes, idx)
+					def.indexes = append(def.indexes, idx)
 				}
 			} else if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "PROJECTION" {
 				// Parse PROJECTION definitions: PROJECTION name (SELECT ...)
 				p.nextToken() // skip PROJECTION
 				proj := p.parseProjection()
 				if proj != nil {
-					create.Projections = append(create.Projections, proj)
+					def.projections = append(def.projections, proj)
 				}
 			} else if p.currentIs(token.PRIMARY) {
 				// PRIMARY KEY in column list
@@ -2906,13 +3269,13 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 					p.nextToken() // skip KEY
 					expr := p.parseExpression(LOWEST)
 					if expr != nil {
-						create.ColumnsPrimaryKey = append(create.ColumnsPrimaryKey, expr)
+						def.columnsPrimaryKey = append(def.columnsPrimaryKey, expr)
 					}
 				}
 			} else {
 				col := p.parseColumnDeclaration()
 				if col != nil {
-					create.Columns = append(create.Columns, col)
+					def.columns = append(def.columns, col)
 				}
 			}
 			if p.currentIs(token.COMMA) {
@@ -2925,39 +3288,39 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 	}
 
 	// Handle ON CLUSTER (if it appears after column definitions instead of before)
-	if create.OnCluster == "" && p.currentIs(token.ON) {
+	if def.onCluster == "" && p.currentIs(token.ON) {
 		p.nextToken()
 		if p.currentIs(token.CLUSTER) {
 			p.nextToken()
-			create.OnCluster = p.parseIdentifierName()
+			def.onCluster = p.parseIdentifierName()
 		}
 	}
 
 	// Handle TO (target table for materialized views and window views)
 	// TO clause is not valid for regular views - only for MATERIALIZED VIEW or WINDOW VIEW
 	if p.currentIs(token.TO) {
-		if !create.Materialized && !create.WindowView {
-			p.errors = append(p.errors, fmt.Errorf("TO clause is only valid for MATERIALIZED VIEW or WINDOW VIEW, not VIEW"))
+		if !hasTarget {
+			p.errorAt(p.current, "TO clause is only valid for MATERIALIZED VIEW or WINDOW VIEW, not VIEW")
 			return
 		}
 		p.nextToken()
 		toName := p.parseIdentifierName()
 		if p.currentIs(token.DOT) {
 			p.nextToken()
-			create.ToDatabase = toName
-			create.To = p.parseIdentifierName()
+			def.toDatabase = toName
+			def.to = p.parseIdentifierName()
 		} else {
-			create.To = toName
+			def.to = toName
 		}
 
 		// For MATERIALIZED VIEW ... TO target (columns) syntax,
 		// column definitions can come after the TO target
-		if p.currentIs(token.LPAREN) && len(create.Columns) == 0 {
+		if p.currentIs(token.LPAREN) && len(def.columns) == 0 {
 			p.nextToken()
 			for !p.currentIs(token.RPAREN) && !p.currentIs(token.EOF) {
 				col := p.parseColumnDeclaration()
 				if col != nil {
-					create.Columns = append(create.Columns, col)
+					def.columns = append(def.columns, col)
 				}
 				if p.currentIs(token.COMMA) {
 					p.nextToken()
@@ -2977,7 +3340,7 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 			if p.currentIs(token.EQ) {
 				p.nextToken()
 			}
-			create.InnerEngine = p.parseEngineClause()
+			def.innerEngine = p.parseEngineClause()
 		}
 	}
 
@@ -2987,15 +3350,15 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 		if p.currentIs(token.EQ) {
 			p.nextToken()
 		}
-		create.Engine = p.parseEngineClause()
+		def.engine = p.parseEngineClause()
 	}
 
 	// Parse table options (ORDER BY, PRIMARY KEY, etc.) for materialized views
-	p.parseTableOptions(create)
+	p.parseTableOptions(&def.opts)
 
 	// Parse POPULATE (for materialized views)
 	if p.currentIs(token.POPULATE) {
-		create.Populate = true
+		def.populate = true
 		p.nextToken()
 	}
 
@@ -3003,13 +3366,16 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 	if p.currentIs(token.AS) {
 		p.nextToken()
 		if p.currentIs(token.SELECT) || p.currentIs(token.WITH) || p.currentIs(token.LPAREN) {
-			create.AsSelect = p.parseSelectWithUnion()
-			// Extract FORMAT from inner SelectQuery and move it to CreateQuery
-			// For CREATE VIEW/MATERIALIZED VIEW, FORMAT should be at CreateQuery level
-			if swu, ok := create.AsSelect.(*ast.SelectWithUnionQuery); ok && swu != nil {
+			def.asSelect = p.parseSelectWithUnion()
+			// Extract FORMAT from inner SelectQuery and move it to the view
+			// For CREATE VIEW/MATERIALIZED VIEW, FORMAT belongs to the CREATE statement
+			if swu, ok := def.asSelect.(*ast.SelectWithUnionQuery); ok && swu != nil && swu.Format != nil {
+				def.format = swu.Format.Name()
+				swu.Format = nil
+			} else if ok && swu != nil {
 				for _, sel := range swu.Selects {
 					if sq, ok := sel.(*ast.SelectQuery); ok && sq != nil && sq.Format != nil {
-						create.Format = sq.Format.Name()
+						def.format = sq.Format.Name()
 						sq.Format = nil
 						break
 					}
@@ -3019,7 +3385,12 @@ func (p *Parser) parseCreateView(create *ast.CreateQuery) {
 	}
 }
 
-func (p *Parser) parseCreateFunction(create *ast.CreateQuery) {
+func (p *Parser) parseCreateFunction(pos token.Position, orReplace bool) *ast.CreateFunctionQuery {
+	create := &ast.CreateFunctionQuery{
+		Position:  pos,
+		OrReplace: orReplace,
+	}
+
 	// Handle IF NOT EXISTS
 	if p.currentIs(token.IF) {
 		p.nextToken()
@@ -3033,7 +3404,7 @@ func (p *Parser) parseCreateFunction(create *ast.CreateQuery) {
 	}
 
 	// Parse function name
-	create.FunctionName = p.parseIdentifierName()
+	create.Name = p.parseIdentifierName()
 
 	// Handle ON CLUSTER
 	if p.currentIs(token.ON) {
@@ -3047,204 +3418,316 @@ func (p *Parser) parseCreateFunction(create *ast.CreateQuery) {
 	// Parse AS lambda_expression
 	if p.currentIs(token.AS) {
 		p.nextToken()
-		create.FunctionBody = p.parseExpression(LOWEST)
+		create.Body = p.parseExpression(LOWEST)
 	}
+
+	create.Format = p.parseFormatClause()
+	return create
 }
 
-func (p *Parser) parseCreateUser(create *ast.CreateQuery) {
-	// Handle IF NOT EXISTS
-	if p.currentIs(token.IF) {
-		p.nextToken()
-		if p.currentIs(token.NOT) {
-			p.nextToken()
-			if p.currentIs(token.EXISTS) {
-				create.IfNotExists = true
-				p.nextToken()
-			}
-		}
+func (p *Parser) parseCreateUser(pos token.Position, orReplace bool) *ast.CreateUserQuery {
+	query := &ast.CreateUserQuery{
+		Position:  pos,
+		OrReplace: orReplace,
 	}
 
-	// Parse user name
-	create.UserName = p.parseIdentifierName()
+	p.nextToken() // skip USER
 
-	// Look for authentication data (NOT IDENTIFIED or IDENTIFIED)
-	// Scan through tokens looking for these keywords
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-		// Check for NOT IDENTIFIED
-		if p.currentIs(token.NOT) {
-			p.nextToken()
-			if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "IDENTIFIED" {
-				create.HasAuthenticationData = true
-				p.nextToken()
-			}
-			continue
-		}
-		// Check for IDENTIFIED (without NOT)
-		if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "IDENTIFIED" {
-			create.HasAuthenticationData = true
-			p.nextToken()
-			// Parse authentication method and value
-			// Forms: IDENTIFIED BY 'password'
-			//        IDENTIFIED WITH method BY 'password'
-			//        IDENTIFIED WITH method BY 'password', method BY 'password', ...
-			for {
-				// Skip WITH if present (auth method follows)
-				if p.currentIs(token.WITH) {
-					p.nextToken()
-				}
-				// Check for ssh_key authentication method
-				isSSHKey := p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "SSH_KEY"
-				// Skip auth method name (plaintext_password, sha256_password, etc.)
-				// Stop at BY (token), comma, or next section keywords
-				gotAuthValue := false
-				for p.currentIs(token.IDENT) {
-					ident := strings.ToUpper(p.current.Value)
-					// Stop at HOST, SETTINGS, DEFAULT, GRANTEES - don't consume these
-					if ident == "HOST" || ident == "SETTINGS" || ident == "DEFAULT" || ident == "GRANTEES" {
-						break
-					}
-					p.nextToken()
-					// Handle REALM/SERVER string values (for kerberos/ldap) - capture them!
-					if p.currentIs(token.STRING) && (ident == "REALM" || ident == "SERVER") {
-						create.AuthenticationValues = append(create.AuthenticationValues, p.current.Value)
-						gotAuthValue = true
-						p.nextToken()
-					}
-				}
-				// Check for BY 'value' or BY KEY ... TYPE ... (SSH key auth)
-				if p.currentIs(token.BY) {
-					p.nextToken()
-					if isSSHKey {
-						// Parse SSH key format: BY KEY 'key' TYPE 'type' [, KEY 'key' TYPE 'type' ...]
-						for {
-							if p.currentIs(token.KEY) {
-								p.nextToken()
-								if p.currentIs(token.STRING) {
-									p.nextToken() // skip key value
-								}
-								// Skip TYPE 'algorithm'
-								if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "TYPE" {
-									p.nextToken()
-									if p.currentIs(token.STRING) {
-										p.nextToken() // skip type value
-									}
-								}
-								create.SSHKeyCount++
-							}
-							// Check for comma (multiple keys)
-							if p.currentIs(token.COMMA) {
-								p.nextToken()
-								continue
-							}
-							break
-						}
-						gotAuthValue = true
-					} else if p.currentIs(token.STRING) {
-						create.AuthenticationValues = append(create.AuthenticationValues, p.current.Value)
-						gotAuthValue = true
-						p.nextToken()
-					}
-				}
-				_ = gotAuthValue // suppress unused variable warning if any
-				// Check for comma (multiple auth methods)
-				if p.currentIs(token.COMMA) {
-					p.nextToken()
-					continue
-				}
-				break
-			}
-			continue
-		}
+	// Handle IF NOT EXISTS or OR REPLACE, which may also follow USER
+	if p.currentIs(token.IF) && p.peekIs(token.NOT) && p.peekPeekIs(token.EXISTS) {
+		query.IfNotExists = true
+		p.nextToken()
+		p.nextToken()
+		p.nextToken()
+	} else if p.currentIs(token.OR) && p.peekIs(token.REPLACE) {
+		query.OrReplace = true
+		p.nextToken()
 		p.nextToken()
 	}
+
+	p.parseUserClauses(query)
+	return query
 }
 
-func (p *Parser) parseAlterUser() *ast.CreateQuery {
-	create := &ast.CreateQuery{
-		Position:   p.current.Pos,
-		CreateUser: true,
-		AlterUser:  true,
+func (p *Parser) parseAlterUser() *ast.CreateUserQuery {
+	query := &ast.CreateUserQuery{
+		Position: p.current.Pos,
+		IsAlter:  true,
 	}
 
 	p.nextToken() // skip ALTER
 	p.nextToken() // skip USER
 
-	// Parse user name
-	create.UserName = p.parseIdentifierName()
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
+		p.nextToken()
+	}
+
+	p.parseUserClauses(query)
+	return query
+}
 
-	// Scan for authentication data (NOT IDENTIFIED or IDENTIFIED)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-		// Check for NOT IDENTIFIED
-		if p.currentIs(token.NOT) {
-			p.nextToken()
-			if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "IDENTIFIED" {
-				create.HasAuthenticationData = true
+// parseUserClauses parses the user names of CREATE USER or ALTER USER and
+// the clauses that follow them, in any order.
+func (p *Parser) parseUserClauses(query *ast.CreateUserQuery) {
+	query.Names = p.parseRoleNames()
+	for {
+		switch {
+		case p.currentIs(token.ON) && p.peekIs(token.CLUSTER):
+			query.OnCluster = p.parseOnCluster()
+		case query.IsAlter && p.currentIs(token.RENAME) && p.peekIs(token.TO):
+			p.nextToken() // skip RENAME
+			p.nextToken() // skip TO
+			query.NewName = p.parseRoleName()
+		case !query.IsAlter && p.currentIs(token.IN):
+			p.nextToken()
+			query.Storage = p.parseIdentifierName()
+		case p.currentIs(token.NOT) && p.peekIsIdent("IDENTIFIED"):
+			p.nextToken() // skip NOT
+			p.nextToken() // skip IDENTIFIED
+			query.NotIdentified = true
+		case p.currentIsIdent("IDENTIFIED"):
+			p.nextToken()
+			query.AuthMethods = append(query.AuthMethods, p.parseAuthenticationMethods()...)
+		case query.IsAlter && p.currentIs(token.ADD) && p.peekIsIdent("IDENTIFIED"):
+			p.nextToken() // skip ADD
+			p.nextToken() // skip IDENTIFIED
+			query.AddIdentified = true
+			query.AuthMethods = append(query.AuthMethods, p.parseAuthenticationMethods()...)
+		case query.IsAlter && p.currentIsIdent("RESET") && p.peekIsIdent("AUTHENTICATION"):
+			// RESET AUTHENTICATION METHODS TO NEW
+			for _, word := range []string{"RESET", "AUTHENTICATION", "METHODS", "TO", "NEW"} {
+				if !strings.EqualFold(p.current.Value, word) {
+					p.unexpected()
+					return
+				}
+				p.nextToken()
+			}
+			query.ResetAuthMethods = true
+		case p.currentIsIdent("HOST"):
+			p.nextToken()
+			query.Hosts = append(query.Hosts, p.parseUserHosts()...)
+		case query.IsAlter && p.currentIs(token.ADD) && p.peekIsIdent("HOST"):
+			p.nextToken() // skip ADD
+			p.nextToken() // skip HOST
+			query.AddHosts = append(query.AddHosts, p.parseUserHosts()...)
+		case query.IsAlter && p.currentIs(token.DROP) && p.peekIsIdent("HOST"):
+			p.nextToken() // skip DROP
+			p.nextToken() // skip HOST
+			query.DropHosts = append(query.DropHosts, p.parseUserHosts()...)
+		case p.currentIsIdent("VALID") && p.peekIsIdent("UNTIL"):
+			p.nextToken() // skip VALID
+			p.nextToken() // skip UNTIL
+			if query.ValidUntil = p.parseValidUntil(); query.ValidUntil == nil {
+				return
+			}
+		case p.currentIs(token.DEFAULT) && p.peekIsIdent("ROLE"):
+			p.nextToken() // skip DEFAULT
+			p.nextToken() // skip ROLE
+			query.DefaultRoles = p.parseRoleSet()
+		case p.currentIs(token.DEFAULT) && p.peekIs(token.DATABASE):
+			p.nextToken() // skip DEFAULT
+			p.nextToken() // skip DATABASE
+			if p.currentIsIdent("NONE") {
+				query.DefaultDatabaseNone = true
 				p.nextToken()
+			} else {
+				query.DefaultDatabase = p.parseIdentifierName()
 			}
-			continue
+		case p.currentIsIdent("GRANTEES"):
+			p.nextToken()
+			query.Grantees = p.parseRoleSet()
+		case p.currentIs(token.SETTINGS):
+			p.nextToken()
+			query.Settings, query.SettingsNone = p.parseSettingsProfileElements()
+		default:
+			return
 		}
-		// Check for IDENTIFIED (without NOT)
-		if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "IDENTIFIED" {
-			create.HasAuthenticationData = true
+	}
+}
+
+// parseAuthenticationMethods parses the comma-separated authentication
+// methods following IDENTIFIED, such as WITH sha256_password BY 'secret'
+// or WITH ssh_key BY KEY 'key' TYPE 'ssh-ed25519'.
+func (p *Parser) parseAuthenticationMethods() []*ast.AuthenticationMethod {
+	var methods []*ast.AuthenticationMethod
+	if p.currentIs(token.WITH) {
+		p.nextToken()
+	}
+	for {
+		method := &ast.AuthenticationMethod{Position: p.current.Pos}
+		if p.currentIs(token.IDENT) && !p.current.Quoted && !p.currentIsIdent("HOST") &&
+			!p.currentIsIdent("VALID") && !p.currentIsIdent("GRANTEES") {
+			method.Type = ast.AuthenticationType(strings.ToLower(p.current.Value))
 			p.nextToken()
-			// Parse authentication method and value
-			for {
-				// Skip WITH if present
-				if p.currentIs(token.WITH) {
-					p.nextToken()
+		}
+		if !p.parseAuthenticationMethodClauses(method) {
+			return methods
+		}
+		p.finish(method)
+		methods = append(methods, method)
+
+		if !p.currentIs(token.COMMA) {
+			return methods
+		}
+		p.nextToken()
+	}
+}
+
+// parseAuthenticationMethodClauses parses what follows the type of an
+// authentication method. It reports false if it recorded an error.
+func (p *Parser) parseAuthenticationMethodClauses(method *ast.AuthenticationMethod) bool {
+	for {
+		switch {
+		case p.currentIs(token.BY) && p.peekIs(token.KEY):
+			p.nextToken() // skip BY
+			for {
+				key := &ast.SSHKey{Position: p.current.Pos}
+				p.nextToken() // skip KEY
+				if !p.currentIs(token.STRING) {
+					p.unexpected(token.STRING)
+					return false
 				}
-				// Skip auth method name
-				for p.currentIs(token.IDENT) {
-					ident := strings.ToUpper(p.current.Value)
-					if ident == "HOST" || ident == "SETTINGS" || ident == "DEFAULT" || ident == "GRANTEES" {
-						break
-					}
-					p.nextToken()
-					// Handle REALM/SERVER string values (for kerberos/ldap) - capture them!
-					if p.currentIs(token.STRING) && (ident == "REALM" || ident == "SERVER") {
-						create.AuthenticationValues = append(create.AuthenticationValues, p.current.Value)
-						p.nextToken()
-					}
+				key.Key = p.current.Value
+				p.nextToken()
+				if !p.currentIsIdent("TYPE") || !p.peekIs(token.STRING) {
+					p.unexpected()
+					return false
 				}
-				// Check for BY 'value'
-				if p.currentIs(token.BY) {
-					p.nextToken()
-					if p.currentIs(token.STRING) {
-						create.AuthenticationValues = append(create.AuthenticationValues, p.current.Value)
-						p.nextToken()
-					}
+				p.nextToken()
+				key.Type = p.current.Value
+				p.nextToken()
+				p.finish(key)
+				method.SSHKeys = append(method.SSHKeys, key)
+
+				// Further keys follow a comma: KEY 'a' TYPE 't', KEY 'b' TYPE 't'
+				if !p.currentIs(token.COMMA) || !p.peekIs(token.KEY) {
+					break
 				}
-				// Check for comma (multiple auth methods)
-				if p.currentIs(token.COMMA) {
-					p.nextToken()
-					continue
+				p.nextToken()
+			}
+		case p.currentIs(token.BY), p.currentIsIdent("SERVER"), p.currentIsIdent("REALM"):
+			p.nextToken()
+			if !p.currentIs(token.STRING) {
+				p.unexpected(token.STRING)
+				return false
+			}
+			method.Value = p.parseExpression(ALIAS_PREC)
+		case p.currentIsIdent("SALT"), p.currentIsIdent("SCHEME"):
+			isSalt := p.currentIsIdent("SALT")
+			p.nextToken()
+			if !p.currentIs(token.STRING) {
+				p.unexpected(token.STRING)
+				return false
+			}
+			if isSalt {
+				method.Salt = p.current.Value
+			} else {
+				method.Scheme = p.current.Value
+			}
+			p.nextToken()
+		case p.currentIsIdent("CN"), p.currentIsIdent("SAN"):
+			isCN := p.currentIsIdent("CN")
+			p.nextToken()
+			for p.currentIs(token.STRING) {
+				if isCN {
+					method.CommonNames = append(method.CommonNames, p.current.Value)
+				} else {
+					method.SubjectAltNames = append(method.SubjectAltNames, p.current.Value)
 				}
-				break
+				p.nextToken()
+				if !p.currentIs(token.COMMA) || !p.peekIs(token.STRING) {
+					break
+				}
+				p.nextToken()
 			}
-			continue
+		case p.currentIsIdent("VALID") && p.peekIsIdent("UNTIL"):
+			p.nextToken() // skip VALID
+			p.nextToken() // skip UNTIL
+			if method.ValidUntil = p.parseValidUntil(); method.ValidUntil == nil {
+				return false
+			}
+		default:
+			return true
 		}
-		p.nextToken()
 	}
-
-	return create
 }
 
-func (p *Parser) parseCreateGeneric(create *ast.CreateQuery) {
-	// Parse name
-	name := p.parseIdentifierName()
-	if name != "" {
-		create.Table = name // Reuse Table field for generic name
+// parseValidUntil parses the expiration date following VALID UNTIL, which
+// is a string literal or a query parameter. It returns nil after recording
+// an error.
+func (p *Parser) parseValidUntil() ast.Expression {
+	switch {
+	case p.currentIs(token.STRING):
+		return p.parseString()
+	case p.currentIs(token.PARAM):
+		return p.parseParameter()
 	}
+	p.unexpected(token.STRING)
+	return nil
+}
 
-	// Skip the rest of the statement
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+// parseUserHosts parses the hosts of a HOST clause: ANY, NONE, LOCAL, or
+// NAME, REGEXP, IP or LIKE followed by a pattern. Further patterns of the
+// same kind may follow a comma, as in IP '::1', '127.0.0.1'.
+func (p *Parser) parseUserHosts() []*ast.UserHost {
+	var hosts []*ast.UserHost
+	var kind ast.UserHostKind
+	for {
+		host := &ast.UserHost{Position: p.current.Pos}
+		switch {
+		case p.currentIs(token.ANY):
+			host.Kind = ast.UserHostAny
+		case p.currentIsIdent("NONE"):
+			host.Kind = ast.UserHostNone
+		case p.currentIs(token.LOCAL):
+			host.Kind = ast.UserHostLocal
+		case p.currentIsIdent("NAME"):
+			host.Kind = ast.UserHostName
+		case p.currentIs(token.REGEXP):
+			host.Kind = ast.UserHostRegexp
+		case p.currentIsIdent("IP"):
+			host.Kind = ast.UserHostIP
+		case p.currentIs(token.LIKE):
+			host.Kind = ast.UserHostLike
+		case p.currentIs(token.STRING) && kind != "":
+			host.Kind = kind
+		default:
+			p.unexpected()
+			return hosts
+		}
+		if !p.currentIs(token.STRING) {
+			p.nextToken()
+		}
+
+		switch host.Kind {
+		case ast.UserHostName, ast.UserHostRegexp, ast.UserHostIP, ast.UserHostLike:
+			if !p.currentIs(token.STRING) {
+				p.unexpected(token.STRING)
+				return hosts
+			}
+			host.Pattern = p.current.Value
+			p.nextToken()
+			kind = host.Kind
+		default:
+			kind = ""
+		}
+		p.finish(host)
+		hosts = append(hosts, host)
+
+		if !p.currentIs(token.COMMA) {
+			return hosts
+		}
 		p.nextToken()
 	}
 }
 
-func (p *Parser) parseCreateSettingsProfile(pos token.Position) *ast.CreateSettingsProfileQuery {
+func (p *Parser) parseCreateSettingsProfile(pos token.Position, orReplace bool) *ast.CreateSettingsProfileQuery {
 	query := &ast.CreateSettingsProfileQuery{
-		Position: pos,
+		Position:  pos,
+		OrReplace: orReplace,
 	}
 
 	// Skip SETTINGS if present (CREATE SETTINGS PROFILE vs CREATE PROFILE)
@@ -3253,40 +3736,42 @@ func (p *Parser) parseCreateSettingsProfile(pos token.Position) *ast.CreateSetti
 	}
 
 	// Skip PROFILE
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "PROFILE" {
+	if p.currentIsIdent("PROFILE") {
 		p.nextToken()
 	}
 
-	// Handle IF NOT EXISTS
-	if p.currentIs(token.IF) {
+	// Handle IF NOT EXISTS or OR REPLACE, which may also follow PROFILE
+	if p.currentIs(token.IF) && p.peekIs(token.NOT) && p.peekPeekIs(token.EXISTS) {
+		query.IfNotExists = true
+		p.nextToken()
+		p.nextToken()
+		p.nextToken()
+	} else if p.currentIs(token.OR) && p.peekIs(token.REPLACE) {
+		query.OrReplace = true
+		p.nextToken()
 		p.nextToken()
-		if p.currentIs(token.NOT) {
-			p.nextToken()
-		}
-		if p.currentIs(token.EXISTS) {
-			p.nextToken()
-		}
 	}
 
 	// Parse profile names (can be multiple: s1, s2, s3)
+	query.Names = p.parseRoleNames()
+
 	for {
-		name := p.parseIdentifierName()
-		if name != "" {
-			query.Names = append(query.Names, name)
-		}
-		if p.currentIs(token.COMMA) {
+		switch {
+		case p.currentIs(token.ON) && p.peekIs(token.CLUSTER):
+			query.OnCluster = p.parseOnCluster()
+		case p.currentIs(token.IN):
 			p.nextToken()
-			continue
+			query.Storage = p.parseIdentifierName()
+		case p.currentIs(token.SETTINGS):
+			p.nextToken()
+			query.Settings, query.SettingsNone = p.parseSettingsProfileElements()
+		case p.currentIs(token.TO):
+			p.nextToken()
+			query.Roles = p.parseRoleSet()
+		default:
+			return query
 		}
-		break
 	}
-
-	// Skip the rest of the statement (SETTINGS, TO, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-		p.nextToken()
-	}
-
-	return query
 }
 
 func (p *Parser) parseDropSettingsProfile() *ast.DropSettingsProfileQuery {
@@ -3349,37 +3834,97 @@ func (p *Parser) parseAlterSettingsProfile() *ast.AlterSettingsProfileQuery {
 	}
 
 	// Skip PROFILE
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "PROFILE" {
+	if p.currentIsIdent("PROFILE") {
 		p.nextToken()
 	}
 
 	// Handle IF EXISTS
-	if p.currentIs(token.IF) {
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
-		if p.currentIs(token.EXISTS) {
-			p.nextToken()
-		}
 	}
 
 	// Parse profile names (can be multiple: s1, s2, s3)
+	query.Names = p.parseRoleNames()
+
 	for {
-		name := p.parseIdentifierName()
-		if name != "" {
-			query.Names = append(query.Names, name)
-		}
-		if p.currentIs(token.COMMA) {
+		switch {
+		case p.currentIs(token.ON) && p.peekIs(token.CLUSTER):
+			query.OnCluster = p.parseOnCluster()
+		case p.currentIs(token.RENAME) && p.peekIs(token.TO):
+			p.nextToken() // skip RENAME
+			p.nextToken() // skip TO
+			query.NewName = p.parseIdentifierName()
+		case p.currentIs(token.SETTINGS):
 			p.nextToken()
-			continue
+			query.Settings, query.SettingsNone = p.parseSettingsProfileElements()
+		case p.currentIs(token.ADD) && (p.peekIs(token.SETTINGS) || p.peekIsIdent("SETTING")):
+			p.nextToken() // skip ADD
+			p.nextToken() // skip SETTINGS
+			elems, _ := p.parseSettingsProfileElements()
+			query.AddSettings = append(query.AddSettings, elems...)
+		case p.currentIs(token.ADD) && (p.peekIsIdent("PROFILES") || p.peekIsIdent("PROFILE")):
+			p.nextToken() // skip ADD
+			p.nextToken() // skip PROFILES
+			for {
+				elem := &ast.SettingExpr{Position: p.current.Pos}
+				elem.Profile = p.parseIdentifierName()
+				if elem.Profile == "" {
+					p.unexpected()
+					return query
+				}
+				p.finish(elem)
+				query.AddSettings = append(query.AddSettings, elem)
+				if !p.currentIs(token.COMMA) {
+					break
+				}
+				p.nextToken()
+			}
+		case p.currentIs(token.MODIFY) && (p.peekIs(token.SETTINGS) || p.peekIsIdent("SETTING")):
+			p.nextToken() // skip MODIFY
+			p.nextToken() // skip SETTINGS
+			elems, _ := p.parseSettingsProfileElements()
+			query.ModifySettings = append(query.ModifySettings, elems...)
+		case p.currentIs(token.DROP) && p.peekIs(token.ALL):
+			p.nextToken() // skip DROP
+			p.nextToken() // skip ALL
+			switch {
+			case p.currentIs(token.SETTINGS):
+				query.DropAllSettings = true
+			case p.currentIsIdent("PROFILES"):
+				query.DropAllProfiles = true
+			default:
+				p.unexpected()
+				return query
+			}
+			p.nextToken()
+		case p.currentIs(token.DROP) && (p.peekIs(token.SETTINGS) || p.peekIsIdent("SETTING")):
+			p.nextToken() // skip DROP
+			p.nextToken() // skip SETTINGS
+			for {
+				name := p.parseSettingName()
+				if name == "" {
+					p.unexpected()
+					return query
+				}
+				query.DropSettings = append(query.DropSettings, name)
+				if !p.currentIs(token.COMMA) {
+					break
+				}
+				p.nextToken()
+			}
+		case p.currentIs(token.DROP) && (p.peekIsIdent("PROFILES") || p.peekIsIdent("PROFILE")):
+			p.nextToken() // skip DROP
+			p.nextToken() // skip PROFILES
+			query.DropProfiles = append(query.DropProfiles, p.parseRoleNames()...)
+		case p.currentIs(token.TO):
+			p.nextToken()
+			query.Roles = p.parseRoleSet()
+		default:
+			return query
 		}
-		break
 	}
-
-	// Skip the rest of the statement (SETTINGS, RENAME TO, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-		p.nextToken()
-	}
-
-	return query
 }
 
 func (p *Parser) parseShowCreateSettingsProfile(pos token.Position) *ast.ShowCreateSettingsProfileQuery {
@@ -3427,26 +3972,35 @@ func (p *Parser) parseShowCreateSettingsProfile(pos token.Position) *ast.ShowCre
 	return query
 }
 
-func (p *Parser) parseCreateRowPolicy(pos token.Position) *ast.CreateRowPolicyQuery {
+func (p *Parser) parseCreateRowPolicy(pos token.Position, orReplace bool) *ast.CreateRowPolicyQuery {
 	query := &ast.CreateRowPolicyQuery{
-		Position: pos,
+		Position:  pos,
+		OrReplace: orReplace,
 	}
 
 	// Skip ROW if present (CREATE ROW POLICY vs CREATE POLICY)
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ROW" {
+	if p.currentIsIdent("ROW") {
 		p.nextToken()
 	}
 
 	// Skip POLICY
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "POLICY" {
+	if p.currentIsIdent("POLICY") {
 		p.nextToken()
 	}
 
-	// Skip the rest of the statement (policy names, ON table, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	// Handle IF NOT EXISTS or OR REPLACE, which may also follow POLICY
+	if p.currentIs(token.IF) && p.peekIs(token.NOT) && p.peekPeekIs(token.EXISTS) {
+		query.IfNotExists = true
+		p.nextToken()
+		p.nextToken()
+		p.nextToken()
+	} else if p.currentIs(token.OR) && p.peekIs(token.REPLACE) {
+		query.OrReplace = true
+		p.nextToken()
 		p.nextToken()
 	}
 
+	p.parseRowPolicyClauses(query)
 	return query
 }
 
@@ -3458,27 +4012,28 @@ func (p *Parser) parseDropRowPolicy() *ast.DropRowPolicyQuery {
 	p.nextToken() // skip DROP
 
 	// Skip ROW if present (DROP ROW POLICY vs DROP POLICY)
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ROW" {
+	if p.currentIsIdent("ROW") {
 		p.nextToken()
 	}
 
 	// Skip POLICY
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "POLICY" {
+	if p.currentIsIdent("POLICY") {
 		p.nextToken()
 	}
 
 	// Handle IF EXISTS
-	if p.currentIs(token.IF) {
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
-		if p.currentIs(token.EXISTS) {
-			query.IfExists = true
-			p.nextToken()
-		}
 	}
 
-	// Skip the rest of the statement (policy names, ON table, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	query.Policies = p.parseRowPolicyNames(&query.OnCluster, nil)
+
+	// Handle FROM access_storage
+	if p.currentIs(token.FROM) {
 		p.nextToken()
+		query.Storage = p.parseIdentifierName()
 	}
 
 	return query
@@ -3493,23 +4048,147 @@ func (p *Parser) parseAlterRowPolicy() *ast.CreateRowPolicyQuery {
 	p.nextToken() // skip ALTER
 
 	// Skip ROW if present (ALTER ROW POLICY vs ALTER POLICY)
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ROW" {
+	if p.currentIsIdent("ROW") {
 		p.nextToken()
 	}
 
 	// Skip POLICY
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "POLICY" {
+	if p.currentIsIdent("POLICY") {
 		p.nextToken()
 	}
 
-	// Skip the rest of the statement (policy names, ON table, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
 	}
 
+	p.parseRowPolicyClauses(query)
 	return query
 }
 
+// parseRowPolicyClauses parses the policy names of CREATE ROW POLICY or
+// ALTER ROW POLICY and the clauses that follow them, in any order.
+func (p *Parser) parseRowPolicyClauses(query *ast.CreateRowPolicyQuery) {
+	if query.IsAlter {
+		query.Policies = p.parseRowPolicyNames(&query.OnCluster, &query.NewName)
+	} else {
+		query.Policies = p.parseRowPolicyNames(&query.OnCluster, nil)
+	}
+
+	for {
+		switch {
+		case !query.IsAlter && p.currentIs(token.IN):
+			p.nextToken()
+			query.Storage = p.parseIdentifierName()
+		case p.currentIs(token.FOR):
+			p.nextToken()
+			query.ForSelect = p.expect(token.SELECT)
+		case p.currentIs(token.USING):
+			p.nextToken()
+			if p.currentIsIdent("NONE") && (p.peekIs(token.EOF) || p.peekIs(token.SEMICOLON) ||
+				p.peekIs(token.TO) || p.peekIs(token.AS) || p.peekIs(token.FOR)) {
+				query.UsingNone = true
+				p.nextToken()
+			} else {
+				// Use ALIAS_PREC to stop before AS PERMISSIVE
+				query.Using = p.parseExpression(ALIAS_PREC)
+			}
+		case p.currentIs(token.AS):
+			p.nextToken()
+			switch {
+			case p.currentIsIdent("PERMISSIVE"):
+				query.Kind = ast.RowPolicyPermissive
+			case p.currentIsIdent("RESTRICTIVE"):
+				query.Kind = ast.RowPolicyRestrictive
+			default:
+				p.unexpected()
+				return
+			}
+			p.nextToken()
+		case p.currentIs(token.TO):
+			p.nextToken()
+			query.Roles = p.parseRoleSet()
+		default:
+			return
+		}
+	}
+}
+
+// parseRowPolicyNames parses the names of row policies and the tables
+// they are on: name [, ...] [ON CLUSTER cluster] ON table [, ...], which
+// may be repeated after a comma for other policies. ON CLUSTER may also
+// follow the tables, as may RENAME TO if newName is not nil.
+func (p *Parser) parseRowPolicyNames(onCluster, newName *string) []*ast.RowPolicyName {
+	var policies []*ast.RowPolicyName
+	policy := &ast.RowPolicyName{Position: p.current.Pos}
+	policy.Names = p.parseRoleNames()
+	for {
+		if *onCluster == "" {
+			*onCluster = p.parseOnCluster()
+		}
+		if !p.expect(token.ON) {
+			return policies
+		}
+
+		// The tables are only known to end once an entry turns out to be
+		// followed by ON rather than ON CLUSTER, which makes it the name
+		// of the next policy: p1 ON t1, p2 ON t2
+		var next *ast.RowPolicyName
+		for {
+			table := p.parseRowPolicyTable()
+			if *onCluster == "" {
+				*onCluster = p.parseOnCluster()
+			}
+			if len(policy.Tables) > 0 && table.Database == "" && p.currentIs(token.ON) && !p.peekIs(token.CLUSTER) {
+				next = &ast.RowPolicyName{Position: table.Position, Names: []string{table.Table}}
+				break
+			}
+			policy.Tables = append(policy.Tables, table)
+			if newName != nil && p.currentIs(token.RENAME) && p.peekIs(token.TO) {
+				p.nextToken() // skip RENAME
+				p.nextToken() // skip TO
+				*newName = p.parseIdentifierName()
+			}
+			if !p.currentIs(token.COMMA) {
+				break
+			}
+			p.nextToken()
+		}
+
+		if next == nil {
+			p.finish(policy)
+			return append(policies, policy)
+		}
+		setEnd(policy, policy.Tables[len(policy.Tables)-1].End())
+		policies = append(policies, policy)
+		policy = next
+	}
+}
+
+// parseRowPolicyTable parses the table of a row policy: table, db.table,
+// or db.* for all tables of a database.
+func (p *Parser) parseRowPolicyTable() *ast.TableIdentifier {
+	table := &ast.TableIdentifier{Position: p.current.Pos}
+	table.Table = p.parseIdentifierName()
+	if p.currentIs(token.DOT) {
+		p.nextToken()
+		table.Database = table.Table
+		if p.currentIs(token.ASTERISK) {
+			table.Table = "*"
+			p.nextToken()
+		} else {
+			table.Table = p.parseIdentifierName()
+		}
+	}
+	if table.Table == "" {
+		p.unexpected()
+	}
+	p.finish(table)
+	return table
+}
+
 func (p *Parser) parseShowCreateRowPolicy(pos token.Position) *ast.ShowCreateRowPolicyQuery {
 	query := &ast.ShowCreateRowPolicyQuery{
 		Position: pos,
@@ -3542,21 +4221,27 @@ func (p *Parser) parseShowCreateRowPolicy(pos token.Position) *ast.ShowCreateRow
 	return query
 }
 
-func (p *Parser) parseCreateRole(pos token.Position) *ast.CreateRoleQuery {
+func (p *Parser) parseCreateRole(pos token.Position, orReplace bool) *ast.CreateRoleQuery {
 	query := &ast.CreateRoleQuery{
-		Position: pos,
+		Position:  pos,
+		OrReplace: orReplace,
 	}
 
-	// Skip ROLE
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ROLE" {
-		p.nextToken()
-	}
+	p.nextToken() // skip ROLE
 
-	// Skip the rest of the statement (role names, SETTINGS, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	// Handle IF NOT EXISTS or OR REPLACE, which may also follow ROLE
+	if p.currentIs(token.IF) && p.peekIs(token.NOT) && p.peekPeekIs(token.EXISTS) {
+		query.IfNotExists = true
+		p.nextToken()
+		p.nextToken()
+		p.nextToken()
+	} else if p.currentIs(token.OR) && p.peekIs(token.REPLACE) {
+		query.OrReplace = true
+		p.nextToken()
 		p.nextToken()
 	}
 
+	p.parseRoleClauses(query)
 	return query
 }
 
@@ -3566,24 +4251,22 @@ func (p *Parser) parseDropRole() *ast.DropRoleQuery {
 	}
 
 	p.nextToken() // skip DROP
-
-	// Skip ROLE
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ROLE" {
-		p.nextToken()
-	}
+	p.nextToken() // skip ROLE
 
 	// Handle IF EXISTS
-	if p.currentIs(token.IF) {
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
-		if p.currentIs(token.EXISTS) {
-			query.IfExists = true
-			p.nextToken()
-		}
 	}
 
-	// Skip the rest of the statement (role names, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	query.Names = p.parseRoleNames()
+	query.OnCluster = p.parseOnCluster()
+
+	// Handle FROM access_storage
+	if p.currentIs(token.FROM) {
 		p.nextToken()
+		query.Storage = p.parseIdentifierName()
 	}
 
 	return query
@@ -3596,42 +4279,306 @@ func (p *Parser) parseAlterRole() *ast.CreateRoleQuery {
 	}
 
 	p.nextToken() // skip ALTER
+	p.nextToken() // skip ROLE
 
-	// Skip ROLE
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ROLE" {
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
 	}
 
-	// Skip the rest of the statement (role names, SETTINGS, RENAME TO, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	p.parseRoleClauses(query)
+	return query
+}
+
+// parseRoleClauses parses the role names of CREATE ROLE or ALTER ROLE and
+// the clauses that follow them, in any order.
+func (p *Parser) parseRoleClauses(query *ast.CreateRoleQuery) {
+	query.Names = p.parseRoleNames()
+	for {
+		switch {
+		case p.currentIs(token.ON) && p.peekIs(token.CLUSTER):
+			query.OnCluster = p.parseOnCluster()
+		case query.IsAlter && p.currentIs(token.RENAME) && p.peekIs(token.TO):
+			p.nextToken() // skip RENAME
+			p.nextToken() // skip TO
+			query.NewName = p.parseRoleName()
+		case !query.IsAlter && p.currentIs(token.IN):
+			p.nextToken()
+			query.Storage = p.parseIdentifierName()
+		case p.currentIs(token.SETTINGS):
+			p.nextToken()
+			query.Settings, query.SettingsNone = p.parseSettingsProfileElements()
+		default:
+			return
+		}
+	}
+}
+
+// parseSettingsProfileElements parses the elements after SETTINGS in
+// CREATE ROLE, CREATE USER or CREATE SETTINGS PROFILE. none reports
+// SETTINGS NONE.
+func (p *Parser) parseSettingsProfileElements() (elems []*ast.SettingExpr, none bool) {
+	if p.currentIsIdent("NONE") {
+		p.nextToken()
+		return nil, true
+	}
+
+	for {
+		elem := &ast.SettingExpr{Position: p.current.Pos}
+		if (p.currentIsIdent("PROFILE") || p.currentIsIdent("INHERIT")) &&
+			(p.peekIs(token.STRING) || p.peekIs(token.IDENT) || p.peekIs(token.DEFAULT)) {
+			p.nextToken()
+			elem.Profile = p.parseIdentifierName()
+		} else {
+			elem.Name = p.parseSettingName()
+			if elem.Name == "" {
+				p.unexpected()
+				return elems, false
+			}
+			if p.currentIs(token.EQ) {
+				p.nextToken()
+				elem.Value = p.parseExpression(ALIAS_PREC)
+			}
+			p.parseSettingConstraints(elem)
+		}
+		p.finish(elem)
+		elems = append(elems, elem)
+
+		if !p.currentIs(token.COMMA) {
+			return elems, false
+		}
+		p.nextToken()
+	}
+}
+
+// parseSettingName parses the name of a setting in a settings profile,
+// which may be a compound name such as custom_a.b.
+func (p *Parser) parseSettingName() string {
+	name := p.parseIdentifierName()
+	for name != "" && p.currentIs(token.DOT) {
+		p.nextToken()
+		name += "." + p.parseIdentifierName()
+	}
+	return name
+}
+
+// parseSettingConstraints parses the MIN and MAX bounds and the
+// writability following a setting in a settings profile. MIN and MAX may
+// be followed by = before the value.
+func (p *Parser) parseSettingConstraints(elem *ast.SettingExpr) {
+	for {
+		switch {
+		case p.currentIsIdent("MIN"), p.currentIsIdent("MAX"):
+			isMin := p.currentIsIdent("MIN")
+			p.nextToken()
+			if p.currentIs(token.EQ) {
+				p.nextToken()
+			}
+			if isMin {
+				elem.Min = p.parseExpression(ALIAS_PREC)
+			} else {
+				elem.Max = p.parseExpression(ALIAS_PREC)
+			}
+		case p.currentIsIdent("CONST"), p.currentIsIdent("READONLY"),
+			p.currentIsIdent("WRITABLE"), p.currentIsIdent("CHANGEABLE_IN_READONLY"):
+			elem.Writability = ast.SettingWritability(strings.ToUpper(p.current.Value))
+			p.nextToken()
+		default:
+			return
+		}
+	}
+}
+
+// parseRoleNames parses a comma-separated list of names of roles, users
+// or other access entities, such as row policies.
+func (p *Parser) parseRoleNames() []string {
+	var names []string
+	for {
+		name := p.parseRoleName()
+		if name == "" {
+			p.unexpected()
+			return names
+		}
+		names = append(names, name)
+		if !p.currentIs(token.COMMA) {
+			return names
+		}
+		p.nextToken()
+	}
+}
+
+func (p *Parser) parseCreateQuota(pos token.Position, orReplace bool) *ast.CreateQuotaQuery {
+	query := &ast.CreateQuotaQuery{
+		Position:  pos,
+		OrReplace: orReplace,
+	}
+
+	p.nextToken() // skip QUOTA
+
+	// Handle IF NOT EXISTS or OR REPLACE, which may also follow QUOTA
+	if p.currentIs(token.IF) && p.peekIs(token.NOT) && p.peekPeekIs(token.EXISTS) {
+		query.IfNotExists = true
+		p.nextToken()
+		p.nextToken()
+		p.nextToken()
+	} else if p.currentIs(token.OR) && p.peekIs(token.REPLACE) {
+		query.OrReplace = true
+		p.nextToken()
 		p.nextToken()
 	}
 
+	p.parseQuotaClauses(query)
 	return query
 }
 
-func (p *Parser) parseCreateQuota(pos token.Position) *ast.CreateQuotaQuery {
+func (p *Parser) parseAlterQuota() *ast.CreateQuotaQuery {
 	query := &ast.CreateQuotaQuery{
-		Position: pos,
+		Position: p.current.Pos,
+		IsAlter:  true,
 	}
 
-	// Skip QUOTA keyword
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "QUOTA" {
+	p.nextToken() // skip ALTER
+	p.nextToken() // skip QUOTA
+
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
 	}
 
-	// Parse quota name
-	if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-		query.Name = p.current.Value
+	p.parseQuotaClauses(query)
+	return query
+}
+
+// parseQuotaClauses parses the quota names of CREATE QUOTA or ALTER QUOTA
+// and the clauses that follow them, in any order.
+func (p *Parser) parseQuotaClauses(query *ast.CreateQuotaQuery) {
+	query.Names = p.parseRoleNames()
+	for {
+		switch {
+		case p.currentIs(token.ON) && p.peekIs(token.CLUSTER):
+			query.OnCluster = p.parseOnCluster()
+		case query.IsAlter && p.currentIs(token.RENAME) && p.peekIs(token.TO):
+			p.nextToken() // skip RENAME
+			p.nextToken() // skip TO
+			query.NewName = p.parseIdentifierName()
+		case !query.IsAlter && p.currentIs(token.IN):
+			p.nextToken()
+			query.Storage = p.parseIdentifierName()
+		case (p.currentIsIdent("KEYED") || p.currentIs(token.KEY)) && p.peekIs(token.BY):
+			p.nextToken() // skip KEYED
+			p.nextToken() // skip BY
+			query.KeyedBy = p.parseRoleNames()
+		case p.currentIs(token.NOT) && p.peekIsIdent("KEYED"):
+			p.nextToken() // skip NOT
+			p.nextToken() // skip KEYED
+			query.NotKeyed = true
+		case p.currentIs(token.FOR):
+			for {
+				query.Intervals = append(query.Intervals, p.parseQuotaInterval())
+				if !p.currentIs(token.COMMA) || !p.peekIs(token.FOR) {
+					break
+				}
+				p.nextToken()
+			}
+		case p.currentIs(token.TO):
+			p.nextToken()
+			query.Roles = p.parseRoleSet()
+		default:
+			return
+		}
+	}
+}
+
+// parseQuotaInterval parses FOR [RANDOMIZED] [INTERVAL] n unit followed by
+// the limits over the interval, NO LIMITS or TRACKING ONLY.
+func (p *Parser) parseQuotaInterval() *ast.QuotaInterval {
+	interval := &ast.QuotaInterval{Position: p.current.Pos}
+	p.nextToken() // skip FOR
+
+	if p.currentIsIdent("RANDOMIZED") {
+		interval.Randomized = true
+		p.nextToken()
+	}
+	if p.currentIs(token.INTERVAL) {
 		p.nextToken()
 	}
 
-	// Skip the rest of the statement
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	if !p.currentIs(token.NUMBER) {
+		p.unexpected(token.NUMBER)
+		return interval
+	}
+	duration := &ast.Literal{Position: p.current.Pos, EndPosition: p.current.End}
+	setNumberValue(duration, p.current.Value)
+	interval.Duration = duration
+	p.nextToken()
+
+	interval.Unit = strings.ToUpper(p.parseIdentifierName())
+	if interval.Unit == "" {
+		p.unexpected()
+		return interval
+	}
+
+	switch {
+	case p.currentIsIdent("NO") && p.peekIsIdent("LIMITS"):
+		interval.NoLimits = true
+		p.nextToken()
+		p.nextToken()
+	case p.currentIsIdent("TRACKING") && p.peekIsIdent("ONLY"):
+		interval.TrackingOnly = true
+		p.nextToken()
 		p.nextToken()
+	default:
+		interval.Limits = p.parseQuotaLimits()
 	}
+	p.finish(interval)
+	return interval
+}
 
-	return query
+// parseQuotaLimits parses the limits of a quota interval, each written as
+// MAX resource [=] value or resource MAX value. MAX may be left out of the
+// limits following it, as in MAX errors = 10, queries = 100. A comma
+// followed by FOR ends the list and starts the next interval.
+func (p *Parser) parseQuotaLimits() []*ast.QuotaLimit {
+	var limits []*ast.QuotaLimit
+	for {
+		limit := &ast.QuotaLimit{Position: p.current.Pos}
+		maxFirst := p.currentIsIdent("MAX")
+		if maxFirst {
+			p.nextToken()
+		}
+		limit.Resource = p.parseQuotaResource()
+		if !maxFirst && p.currentIsIdent("MAX") || p.currentIs(token.EQ) {
+			p.nextToken()
+		}
+		if limit.Resource == "" {
+			p.unexpected()
+			return limits
+		}
+		limit.Value = p.parseExpression(ALIAS_PREC)
+		p.finish(limit)
+		limits = append(limits, limit)
+
+		if !p.currentIs(token.COMMA) || p.peekIs(token.FOR) {
+			return limits
+		}
+		p.nextToken()
+	}
+}
+
+// parseQuotaResource parses the name of the resource a quota limits,
+// which may be written as several words, as in RESULT ROWS. The words are
+// returned in lower case, joined by underscores.
+func (p *Parser) parseQuotaResource() string {
+	var words []string
+	for (p.currentIs(token.IDENT) || p.current.Token.IsKeyword()) && !p.currentIsIdent("MAX") {
+		words = append(words, strings.ToLower(p.current.Value))
+		p.nextToken()
+	}
+	return strings.Join(words, "_")
 }
 
 func (p *Parser) parseCreateNamedCollection(pos token.Position) *ast.CreateNamedCollectionQuery {
@@ -3639,25 +4586,26 @@ func (p *Parser) parseCreateNamedCollection(pos token.Position) *ast.CreateNamed
 		Position: pos,
 	}
 
-	// Skip NAMED keyword
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "NAMED" {
-		p.nextToken()
-	}
+	p.nextToken() // skip NAMED
 
 	// Skip COLLECTION keyword
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "COLLECTION" {
+	if p.currentIsIdent("COLLECTION") {
 		p.nextToken()
 	}
 
-	// Parse collection name
-	if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() || p.currentIs(token.STRING) {
-		query.Name = p.current.Value
+	// Handle IF NOT EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.NOT) && p.peekPeekIs(token.EXISTS) {
+		query.IfNotExists = true
+		p.nextToken()
+		p.nextToken()
 		p.nextToken()
 	}
 
-	// Skip the rest of the statement (AS key=value, ...)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-		p.nextToken()
+	query.Name = p.parseIdentifierName()
+	query.OnCluster = p.parseOnCluster()
+
+	if p.expect(token.AS) {
+		query.Pairs = p.parseNamedCollectionPairs()
 	}
 
 	return query
@@ -3671,28 +4619,85 @@ func (p *Parser) parseAlterNamedCollection() *ast.AlterNamedCollectionQuery {
 		Position: pos,
 	}
 
-	// Skip NAMED keyword
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "NAMED" {
-		p.nextToken()
-	}
+	p.nextToken() // skip NAMED
 
 	// Skip COLLECTION keyword
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "COLLECTION" {
+	if p.currentIsIdent("COLLECTION") {
 		p.nextToken()
 	}
 
-	// Parse collection name
-	if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() || p.currentIs(token.STRING) {
-		query.Name = p.current.Value
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
 	}
 
-	// Skip the rest of the statement (DELETE key, SET key=value, ...)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-		p.nextToken()
+	query.Name = p.parseIdentifierName()
+	query.OnCluster = p.parseOnCluster()
+
+	for {
+		switch {
+		case p.currentIs(token.SET):
+			p.nextToken()
+			query.Set = append(query.Set, p.parseNamedCollectionPairs()...)
+		case p.currentIs(token.DELETE):
+			p.nextToken()
+			for {
+				key := p.parseIdentifierName()
+				if key == "" {
+					p.unexpected()
+					return query
+				}
+				query.Delete = append(query.Delete, key)
+				if !p.currentIs(token.COMMA) {
+					break
+				}
+				p.nextToken()
+			}
+		default:
+			return query
+		}
 	}
+}
 
-	return query
+// parseNamedCollectionPairs parses the keys and values of a named
+// collection: key = value [[NOT] OVERRIDABLE] [, ...].
+func (p *Parser) parseNamedCollectionPairs() []*ast.KeyValuePair {
+	var pairs []*ast.KeyValuePair
+	for {
+		pair := &ast.KeyValuePair{Position: p.current.Pos}
+		pair.Key = p.parseIdentifierName()
+		if pair.Key == "" {
+			p.unexpected()
+			return pairs
+		}
+		if !p.expect(token.EQ) {
+			return pairs
+		}
+
+		// Use NOT_PREC to stop before NOT OVERRIDABLE
+		pair.Value = p.parseExpression(NOT_PREC)
+
+		switch {
+		case p.currentIsIdent("OVERRIDABLE"):
+			overridable := true
+			pair.Overridable = &overridable
+			p.nextToken()
+		case p.currentIs(token.NOT) && p.peekIsIdent("OVERRIDABLE"):
+			overridable := false
+			pair.Overridable = &overridable
+			p.nextToken()
+			p.nextToken()
+		}
+		p.finish(pair)
+		pairs = append(pairs, pair)
+
+		if !p.currentIs(token.COMMA) {
+			return pairs
+		}
+		p.nextToken()
+	}
 }
 
 func (p *Parser) parseDropNamedCollection() *ast.DropNamedCollectionQuery {
@@ -3703,35 +4708,22 @@ func (p *Parser) parseDropNamedCollection() *ast.DropNamedCollectionQuery {
 		Position: pos,
 	}
 
-	// Skip NAMED keyword
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "NAMED" {
-		p.nextToken()
-	}
-
+	p.nextToken() // skip NAMED
+
 	// Skip COLLECTION keyword
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "COLLECTION" {
+	if p.currentIsIdent("COLLECTION") {
 		p.nextToken()
 	}
 
 	// Handle IF EXISTS
-	if p.currentIs(token.IF) {
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
 		p.nextToken()
-		if p.currentIs(token.EXISTS) {
-			query.IfExists = true
-			p.nextToken()
-		}
-	}
-
-	// Parse collection name
-	if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() || p.currentIs(token.STRING) {
-		query.Name = p.current.Value
 		p.nextToken()
 	}
 
-	// Skip the rest of the statement
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-		p.nextToken()
-	}
+	query.Name = p.parseIdentifierName()
+	query.OnCluster = p.parseOnCluster()
 
 	return query
 }
@@ -3790,115 +4782,198 @@ func (p *Parser) parseShowCreateRole(pos token.Position) *ast.ShowCreateRoleQuer
 	return query
 }
 
-func (p *Parser) parseCreateResource(pos token.Position) *ast.CreateResourceQuery {
+func (p *Parser) parseCreateResource(pos token.Position, orReplace bool) *ast.CreateResourceQuery {
 	query := &ast.CreateResourceQuery{
-		Position: pos,
+		Position:  pos,
+		OrReplace: orReplace,
 	}
 
-	// Skip RESOURCE
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "RESOURCE" {
-		p.nextToken()
-	}
+	p.nextToken() // skip RESOURCE
 
-	// Get resource name
-	if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-		query.Name = p.current.Value
+	// Handle IF NOT EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.NOT) && p.peekPeekIs(token.EXISTS) {
+		query.IfNotExists = true
+		p.nextToken()
+		p.nextToken()
 		p.nextToken()
 	}
 
-	// Skip the rest of the statement (resource definition, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	query.Name = p.parseIdentifierName()
+	query.OnCluster = p.parseOnCluster()
+
+	if !p.expect(token.LPAREN) {
+		return query
+	}
+	for !p.currentIs(token.RPAREN) && !p.currentIs(token.EOF) {
+		op := p.parseResourceOperation()
+		if op == nil {
+			return query
+		}
+		query.Operations = append(query.Operations, op)
+		if !p.currentIs(token.COMMA) {
+			break
+		}
 		p.nextToken()
 	}
+	p.expect(token.RPAREN)
 
 	return query
 }
 
+// parseResourceOperation parses an operation of CREATE RESOURCE:
+// READ DISK name, WRITE DISK name, READ ANY DISK, WRITE ANY DISK,
+// MASTER THREAD, WORKER THREAD or QUERY.
+func (p *Parser) parseResourceOperation() *ast.ResourceOperation {
+	op := &ast.ResourceOperation{Position: p.current.Pos}
+	switch {
+	case p.currentIsIdent("READ"), p.currentIsIdent("WRITE"):
+		op.Mode = ast.ResourceAccessMode(strings.ToUpper(p.current.Value))
+		p.nextToken()
+		if p.currentIs(token.ANY) {
+			op.AnyDisk = true
+			p.nextToken()
+		}
+		if !p.currentIsIdent("DISK") {
+			p.unexpected()
+			return nil
+		}
+		p.nextToken()
+		if !op.AnyDisk {
+			op.Disk = p.parseIdentifierName()
+			if op.Disk == "" {
+				p.unexpected()
+				return nil
+			}
+		}
+	case p.currentIsIdent("MASTER") && p.peekIsIdent("THREAD"):
+		op.Mode = ast.ResourceMasterThread
+		p.nextToken()
+		p.nextToken()
+	case p.currentIsIdent("WORKER") && p.peekIsIdent("THREAD"):
+		op.Mode = ast.ResourceWorkerThread
+		p.nextToken()
+		p.nextToken()
+	case p.currentIsIdent("QUERY"):
+		op.Mode = ast.ResourceQuery
+		p.nextToken()
+	default:
+		p.unexpected()
+		return nil
+	}
+	p.finish(op)
+	return op
+}
+
 func (p *Parser) parseDropResource() *ast.DropResourceQuery {
 	query := &ast.DropResourceQuery{
 		Position: p.current.Pos,
 	}
 
 	p.nextToken() // skip DROP
+	p.nextToken() // skip RESOURCE
 
-	// Skip RESOURCE
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "RESOURCE" {
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
 		p.nextToken()
-	}
-
-	// Skip the rest of the statement (IF EXISTS, name, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
 		p.nextToken()
 	}
 
+	query.Name = p.parseIdentifierName()
+	query.OnCluster = p.parseOnCluster()
+
 	return query
 }
 
-func (p *Parser) parseCreateWorkload(pos token.Position) *ast.CreateWorkloadQuery {
+func (p *Parser) parseCreateWorkload(pos token.Position, orReplace bool) *ast.CreateWorkloadQuery {
 	query := &ast.CreateWorkloadQuery{
-		Position: pos,
+		Position:  pos,
+		OrReplace: orReplace,
 	}
 
-	// Skip WORKLOAD
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "WORKLOAD" {
-		p.nextToken()
-	}
+	p.nextToken() // skip WORKLOAD
 
-	// Check for IF NOT EXISTS
-	if p.currentIs(token.IF) {
+	// Handle IF NOT EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.NOT) && p.peekPeekIs(token.EXISTS) {
+		query.IfNotExists = true
+		p.nextToken()
 		p.nextToken()
-		if p.currentIs(token.NOT) {
-			p.nextToken()
-			if p.currentIs(token.EXISTS) {
-				p.nextToken()
-			}
-		}
-	}
-
-	// Get workload name
-	if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-		query.Name = p.current.Value
 		p.nextToken()
 	}
 
+	query.Name = p.parseIdentifierName()
+	query.OnCluster = p.parseOnCluster()
+
 	// Check for IN (parent workload)
 	if p.currentIs(token.IN) {
 		p.nextToken()
-		if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-			query.Parent = p.current.Value
-			p.nextToken()
-		}
+		query.Parent = p.parseIdentifierName()
 	}
 
-	// Skip the rest of the statement (SETTINGS, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	if p.currentIs(token.SETTINGS) {
 		p.nextToken()
+		query.Settings = p.parseWorkloadSettings()
 	}
 
 	return query
 }
 
+// parseWorkloadSettings parses the settings of CREATE WORKLOAD, each
+// optionally limited to a resource: name = value [FOR resource] [, ...].
+func (p *Parser) parseWorkloadSettings() []*ast.SettingExpr {
+	var settings []*ast.SettingExpr
+	for {
+		setting := &ast.SettingExpr{Position: p.current.Pos}
+		setting.Name = p.parseIdentifierName()
+		if setting.Name == "" {
+			p.unexpected()
+			return settings
+		}
+		if !p.expect(token.EQ) {
+			return settings
+		}
+		setting.Value = p.parseExpression(ALIAS_PREC)
+		if p.currentIs(token.FOR) {
+			p.nextToken()
+			setting.Resource = p.parseIdentifierName()
+		}
+		p.finish(setting)
+		settings = append(settings, setting)
+
+		if !p.currentIs(token.COMMA) {
+			return settings
+		}
+		p.nextToken()
+	}
+}
+
 func (p *Parser) parseDropWorkload() *ast.DropWorkloadQuery {
 	query := &ast.DropWorkloadQuery{
 		Position: p.current.Pos,
 	}
 
 	p.nextToken() // skip DROP
+	p.nextToken() // skip WORKLOAD
 
-	// Skip WORKLOAD
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "WORKLOAD" {
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
 		p.nextToken()
-	}
-
-	// Skip the rest of the statement (IF EXISTS, name, etc.)
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
 		p.nextToken()
 	}
 
+	query.Name = p.parseIdentifierName()
+	query.OnCluster = p.parseOnCluster()
+
 	return query
 }
 
-func (p *Parser) parseCreateDictionary(create *ast.CreateQuery) {
+func (p *Parser) parseCreateDictionary(pos token.Position, orReplace bool) *ast.CreateDictionaryQuery {
+	create := &ast.CreateDictionaryQuery{
+		Position:  pos,
+		OrReplace: orReplace,
+	}
+
 	// Handle IF NOT EXISTS
 	if p.currentIs(token.IF) {
 		p.nextToken()
@@ -3918,7 +4993,7 @@ func (p *Parser) parseCreateDictionary(create *ast.CreateQuery) {
 		p.nextToken()
 		name = p.parseIdentifierName()
 	}
-	create.Table = name
+	create.Dictionary = name
 
 	// Handle ON CLUSTER
 	if p.currentIs(token.ON) {
@@ -3932,7 +5007,7 @@ func (p *Parser) parseCreateDictionary(create *ast.CreateQuery) {
 	// Parse column definitions (attributes) if present
 	if p.currentIs(token.LPAREN) {
 		p.nextToken() // skip (
-		create.DictionaryAttrs = p.parseDictionaryAttributes()
+		create.Attributes = p.parseDictionaryAttributes()
 		if p.currentIs(token.RPAREN) {
 			p.nextToken() // skip )
 		}
@@ -4023,8 +5098,12 @@ func (p *Parser) parseCreateDictionary(create *ast.CreateQuery) {
 
 	// Only set dictionary definition if it has any content
 	if len(dictDef.PrimaryKey) > 0 || dictDef.Source != nil || dictDef.Lifetime != nil || dictDef.Layout != nil || dictDef.Range != nil || len(dictDef.Settings) > 0 {
-		create.DictionaryDef = dictDef
+		p.finish(dictDef)
+		create.Definition = dictDef
 	}
+
+	create.Format = p.parseFormatClause()
+	return create
 }
 
 func (p *Parser) isDictionaryClauseKeyword() bool {
@@ -4103,6 +5182,7 @@ func (p *Parser) parseDictionaryAttribute() *ast.DictionaryAttributeDeclaration
 		}
 	}
 
+	p.finish(attr)
 	return attr
 }
 
@@ -4150,6 +5230,7 @@ func (p *Parser) parseDictionarySource() *ast.DictionarySource {
 	}
 
 	if !p.currentIs(token.LPAREN) {
+		p.finish(source)
 		return source
 	}
 	p.nextToken() // skip (
@@ -4173,6 +5254,7 @@ func (p *Parser) parseDictionarySource() *ast.DictionarySource {
 		p.nextToken() // skip )
 	}
 
+	p.finish(source)
 	return source
 }
 
@@ -4203,7 +5285,7 @@ func (p *Parser) parseKeyValuePairs() []*ast.KeyValuePair {
 				// If peek is LPAREN, this is a function call value
 				if p.peekIs(token.IDENT) || (p.peek.Token.IsKeyword() && !p.peekIs(token.LPAREN)) {
 					// This identifier is followed by another identifier/keyword, treat as value
-					pair.Value = &ast.Identifier{Position: p.current.Pos, Parts: []string{p.current.Value}}
+					pair.Value = &ast.Identifier{Position: p.current.Pos, EndPosition: p.current.End, Parts: []string{p.current.Value}}
 					p.nextToken()
 				} else {
 					// Either a function call, or this identifier is the last thing before )
@@ -4215,6 +5297,7 @@ func (p *Parser) parseKeyValuePairs() []*ast.KeyValuePair {
 			}
 		}
 
+		p.finish(pair)
 		pairs = append(pairs, pair)
 	}
 
@@ -4227,6 +5310,7 @@ func (p *Parser) parseDictionaryLifetime() *ast.DictionaryLifetime {
 	}
 
 	if !p.currentIs(token.LPAREN) {
+		p.finish(lifetime)
 		return lifetime
 	}
 	p.nextToken() // skip (
@@ -4253,6 +5337,7 @@ func (p *Parser) parseDictionaryLifetime() *ast.DictionaryLifetime {
 		p.nextToken() // skip )
 	}
 
+	p.finish(lifetime)
 	return lifetime
 }
 
@@ -4262,6 +5347,7 @@ func (p *Parser) parseDictionaryLayout() *ast.DictionaryLayout {
 	}
 
 	if !p.currentIs(token.LPAREN) {
+		p.finish(layout)
 		return layout
 	}
 	p.nextToken() // skip (
@@ -4285,6 +5371,7 @@ func (p *Parser) parseDictionaryLayout() *ast.DictionaryLayout {
 		p.nextToken() // skip )
 	}
 
+	p.finish(layout)
 	return layout
 }
 
@@ -4294,6 +5381,7 @@ func (p *Parser) parseDictionaryRange() *ast.DictionaryRange {
 	}
 
 	if !p.currentIs(token.LPAREN) {
+		p.finish(dictRange)
 		return dictRange
 	}
 	p.nextToken() // skip (
@@ -4320,6 +5408,7 @@ func (p *Parser) parseDictionaryRange() *ast.DictionaryRange {
 		p.nextToken() // skip )
 	}
 
+	p.finish(dictRange)
 	return dictRange
 }
 
@@ -4355,6 +5444,7 @@ func (p *Parser) parseIndexDefinition() *ast.IndexDefinition {
 				}
 				p.expect(token.RPAREN)
 			}
+			p.finish(idx.Type)
 		}
 	}
 
@@ -4364,6 +5454,7 @@ func (p *Parser) parseIndexDefinition() *ast.IndexDefinition {
 		idx.Granularity = p.parseExpression(LOWEST)
 	}
 
+	p.finish(idx)
 	return idx
 }
 
@@ -4516,6 +5607,7 @@ func (p *Parser) parseColumnDeclaration() *ast.ColumnDeclaration {
 		p.expect(token.RPAREN)
 	}
 
+	p.finish(col)
 	return col
 }
 
@@ -4651,17 +5743,19 @@ func (p *Parser) parseDataType() *ast.DataType {
 					p.nextToken() // consume REGEXP
 					// Parse the pattern string
 					if p.currentIs(token.STRING) {
-						pattern := p.current.Value
+						pattern := &ast.Literal{Position: p.current.Pos, EndPosition: p.current.End, Value: p.current.Value, Type: ast.LiteralString}
 						p.nextToken()
 						param = &ast.FunctionCall{
 							Position:  pos,
 							Name:      "SKIP REGEXP",
-							Arguments: []ast.Expression{&ast.Literal{Position: pos, Value: pattern, Type: ast.LiteralString}},
+							Arguments: []ast.Expression{pattern},
 						}
+						p.finish(param)
 					}
 				} else {
 					// Parse dotted path: a, a.b, a.b.c, etc.
 					var pathParts []string
+					pathPos := p.current.Pos
 					for {
 						if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
 							pathParts = append(pathParts, p.current.Value)
@@ -4674,18 +5768,22 @@ func (p *Parser) parseDataType() *ast.DataType {
 						}
 					}
 					if len(pathParts) > 0 {
+						path := &ast.Identifier{Position: pathPos, Parts: pathParts}
+						p.finish(path)
 						param = &ast.FunctionCall{
 							Position:  pos,
 							Name:      "SKIP",
-							Arguments: []ast.Expression{&ast.Identifier{Position: pos, Parts: pathParts}},
+							Arguments: []ast.Expression{path},
 						}
+						p.finish(param)
 					}
 				}
 				// Wrap in ObjectTypeArgument
 				if param != nil {
 					param = &ast.ObjectTypeArgument{
-						Position: param.Pos(),
-						Expr:     param,
+						Position:    param.Pos(),
+						EndPosition: param.End(),
+						Expr:        param,
 					}
 					dt.Parameters = append(dt.Parameters, param)
 				}
@@ -4751,6 +5849,7 @@ func (p *Parser) parseDataType() *ast.DataType {
 						Name:     paramName,
 						Type:     paramType,
 					}
+					p.finish(param)
 				}
 			} else if (p.currentIs(token.IDENT) || p.current.Token.IsKeyword()) && p.isDataTypeName(p.current.Value) {
 				// It's a type name, parse as data type
@@ -4764,8 +5863,9 @@ func (p *Parser) parseDataType() *ast.DataType {
 			if param != nil {
 				if isObjectType {
 					param = &ast.ObjectTypeArgument{
-						Position: param.Pos(),
-						Expr:     param,
+						Position:    param.Pos(),
+						EndPosition: param.End(),
+						Expr:        param,
 					}
 				}
 				dt.Parameters = append(dt.Parameters, param)
@@ -4780,6 +5880,7 @@ func (p *Parser) parseDataType() *ast.DataType {
 		p.expect(token.RPAREN)
 	}
 
+	p.finish(dt)
 	return dt
 }
 
@@ -4841,6 +5942,7 @@ func (p *Parser) parseCodecExpr() *ast.CodecExpr {
 				}
 				p.expect(token.RPAREN)
 			}
+			p.finish(fn)
 
 			codec.Codecs = append(codec.Codecs, fn)
 		}
@@ -4853,6 +5955,7 @@ func (p *Parser) parseCodecExpr() *ast.CodecExpr {
 	}
 
 	p.expect(token.RPAREN)
+	p.finish(codec)
 	return codec
 }
 
@@ -4882,6 +5985,7 @@ func (p *Parser) parseStatisticsExpr() []*ast.FunctionCall {
 				}
 				p.expect(token.RPAREN)
 			}
+			p.finish(fn)
 
 			stats = append(stats, fn)
 		}
@@ -4937,6 +6041,7 @@ func (p *Parser) parseStatisticsTypeList() []*ast.FunctionCall {
 			}
 			p.expect(token.RPAREN)
 		}
+		p.finish(fn)
 
 		types = append(types, fn)
 
@@ -4972,6 +6077,7 @@ func (p *Parser) parseEngineClause() *ast.EngineClause {
 		p.expect(token.RPAREN)
 	}
 
+	p.finish(engine)
 	return engine
 }
 
@@ -5000,332 +6106,254 @@ func (p *Parser) parseEngineParameters() []ast.Expression {
 	return exprs
 }
 
-func (p *Parser) parseDrop() *ast.DropQuery {
-	drop := &ast.DropQuery{
-		Position: p.current.Pos,
-	}
-
+func (p *Parser) parseDrop() ast.Statement {
+	pos := p.current.Pos
 	p.nextToken() // skip DROP
 
 	// Handle TEMPORARY
+	temporary := false
 	if p.currentIs(token.TEMPORARY) {
-		drop.Temporary = true
+		temporary = true
 		p.nextToken()
 	}
 
 	// What are we dropping?
-	dropUser := false
-	dropFunction := false
-	dropDictionary := false
-	switch p.current.Token {
-	case token.TABLE:
-		p.nextToken()
-	case token.DATABASE:
-		drop.DropDatabase = true
+	kind := p.current
+	switch {
+	case p.currentIs(token.FUNCTION):
+		return p.parseDropFunction(pos)
+	case p.currentIs(token.INDEX):
+		return p.parseDropIndex(pos)
+	case p.currentIs(token.TABLE), p.currentIs(token.VIEW), p.currentIs(token.DATABASE), p.currentIsIdent("DICTIONARY"):
 		p.nextToken()
-	case token.VIEW:
-		p.nextToken()
-	case token.USER:
-		dropUser = true
-		p.nextToken()
-	case token.FUNCTION:
-		dropFunction = true
-		p.nextToken()
-	case token.INDEX:
-		drop.Index = "_pending_"
-		p.nextToken()
-	case token.SETTINGS:
-		// DROP SETTINGS PROFILE
-		p.nextToken() // skip SETTINGS
-		// Skip "PROFILE" if present
-		if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "PROFILE" {
-			p.nextToken()
-		}
-		drop.SettingsProfile = "_pending_"
 	default:
-		// Handle multi-word DROP types: ROW POLICY, NAMED COLLECTION, DICTIONARY
-		if p.currentIs(token.IDENT) {
-			upper := strings.ToUpper(p.current.Value)
-			switch upper {
-			case "DICTIONARY":
-				dropDictionary = true
-				p.nextToken()
-			case "ROW":
-				// DROP ROW POLICY
-				p.nextToken() // skip ROW
-				if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "POLICY" {
-					p.nextToken() // skip POLICY
-				}
-				// Mark as row policy drop - name will be set later
-				drop.RowPolicy = "_pending_"
-			case "POLICY":
-				// DROP POLICY
-				p.nextToken()
-				drop.Policy = "_pending_"
-			case "QUOTA":
-				p.nextToken()
-				drop.Quota = "_pending_"
-			case "ROLE":
-				p.nextToken()
-				drop.Role = "_pending_"
-			case "NAMED":
-				// DROP NAMED COLLECTION - skip tokens
-				for p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-					if p.currentIs(token.IF) {
-						break // Hit IF EXISTS
-					}
-					p.nextToken()
-				}
-			default:
-				p.nextToken() // skip unknown token
-			}
-		} else {
-			p.nextToken() // skip unknown token
-		}
+		p.unexpected(token.TABLE, token.DATABASE, token.VIEW, token.FUNCTION, token.USER)
+		return nil
 	}
 
 	// Handle IF EXISTS or IF EMPTY
+	ifExists, ifEmpty := false, false
 	if p.currentIs(token.IF) {
 		p.nextToken()
 		if p.currentIs(token.EXISTS) {
-			drop.IfExists = true
+			ifExists = true
 			p.nextToken()
-		} else if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "EMPTY" {
-			// IF EMPTY - skip the EMPTY keyword
+		} else if p.currentIsIdent("EMPTY") {
+			ifEmpty = true
 			p.nextToken()
 		}
 	}
 
-	// Parse name (can start with a number in ClickHouse)
-	name := p.parseIdentifierName()
-	if name != "" {
-		var database, tableName string
+	// Parse names (can start with a number in ClickHouse), as in DROP TABLE t1, db.t2
+	var names []*ast.TableIdentifier
+	for {
+		namePos := p.current.Pos
+		name := &ast.TableIdentifier{
+			Position: namePos,
+			Table:    p.parseIdentifierName(),
+		}
 		if p.currentIs(token.DOT) {
 			p.nextToken()
-			database = name
-			tableName = p.parseIdentifierName()
-		} else {
-			tableName = name
+			name.Database = name.Table
+			name.Table = p.parseIdentifierName()
 		}
-
-		if dropUser {
-			drop.User = tableName
-			// Handle user@host syntax
-			if p.currentIs(token.IDENT) && p.current.Value == "@" {
-				p.nextToken() // skip @
-				// Hostname can be identifier, string, or IP in quotes
-				if p.currentIs(token.IDENT) || p.currentIs(token.STRING) || p.current.Token.IsKeyword() {
-					drop.User = drop.User + "@" + p.current.Value
-					p.nextToken()
-				}
-			}
-		} else if dropFunction {
-			drop.Function = tableName
-		} else if drop.Role == "_pending_" {
-			drop.Role = tableName
-		} else if drop.Quota == "_pending_" {
-			drop.Quota = tableName
-		} else if drop.Policy == "_pending_" {
-			drop.Policy = tableName
-		} else if drop.RowPolicy == "_pending_" {
-			drop.RowPolicy = tableName
-		} else if drop.SettingsProfile == "_pending_" {
-			drop.SettingsProfile = tableName
-		} else if drop.Index == "_pending_" {
-			drop.Index = tableName
-			// For DROP INDEX, parse ON table_name
-			if p.currentIs(token.ON) {
-				p.nextToken() // skip ON
-				tableNamePart := p.parseIdentifierName()
-				if p.currentIs(token.DOT) {
-					p.nextToken()
-					drop.Database = tableNamePart
-					drop.Table = p.parseIdentifierName()
-				} else {
-					drop.Table = tableNamePart
-				}
-			}
-		} else if dropDictionary {
-			drop.Dictionary = tableName
-			// Also set Table/Tables for backward compatibility with AST JSON
-			drop.Tables = append(drop.Tables, &ast.TableIdentifier{
-				Position: drop.Position,
-				Database: database,
-				Table:    tableName,
-			})
-			drop.Table = tableName
-			if database != "" {
-				drop.Database = database
-			}
-		} else if drop.DropDatabase {
-			drop.Database = tableName
-		} else {
-			// First table - add to Tables list
-			drop.Tables = append(drop.Tables, &ast.TableIdentifier{
-				Position: drop.Position,
-				Database: database,
-				Table:    tableName,
-			})
-			drop.Table = tableName // Keep for backward compatibility
-			if database != "" {
-				drop.Database = database
-			}
+		name.EndPosition = p.prevEnd
+		if name.Table != "" {
+			names = append(names, name)
 		}
+		if !p.currentIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
 	}
 
-	// Handle multiple tables/users (DROP TABLE IF EXISTS t1, t2, t3 or DROP USER u1, u2@host)
-	for p.currentIs(token.COMMA) {
+	onCluster := p.parseOnCluster()
+
+	// Handle SYNC (can appear before or after FORMAT)
+	sync := false
+	if p.currentIs(token.SYNC) {
+		sync = true
 		p.nextToken()
-		pos := p.current.Pos
-		name := p.parseIdentifierName()
-		var database, tableName string
-		if p.currentIs(token.DOT) {
+	}
+
+	// Handle FORMAT clause (for things like DROP TABLE ... FORMAT Null)
+	format := p.parseFormatClause()
+
+	// Handle SYNC again (can also appear after FORMAT)
+	if p.currentIs(token.SYNC) {
+		sync = true
+		p.nextToken()
+	}
+
+	// Handle NO DELAY
+	if p.currentIsIdent("NO") {
+		p.nextToken()
+		if p.currentIsIdent("DELAY") {
 			p.nextToken()
-			database = name
-			tableName = p.parseIdentifierName()
-		} else {
-			tableName = name
 		}
-		// Handle user@host syntax for additional users
-		if dropUser && p.currentIs(token.IDENT) && p.current.Value == "@" {
-			p.nextToken() // skip @
-			if p.currentIs(token.IDENT) || p.currentIs(token.STRING) || p.current.Token.IsKeyword() {
-				tableName = tableName + "@" + p.current.Value
-				p.nextToken()
-			}
+	}
+
+	// Handle SETTINGS clause
+	var settings []*ast.SettingExpr
+	if p.currentIs(token.SETTINGS) {
+		p.nextToken() // skip SETTINGS
+		settings = p.parseSettingsList()
+	}
+
+	var name ast.TableIdentifier
+	if len(names) > 0 {
+		name = *names[0]
+	}
+	switch kind.Token {
+	case token.TABLE:
+		return &ast.DropTableQuery{
+			Position:  pos,
+			Temporary: temporary,
+			IfExists:  ifExists,
+			IfEmpty:   ifEmpty,
+			Tables:    names,
+			OnCluster: onCluster,
+			Sync:      sync,
+			Format:    format,
+			Settings:  settings,
 		}
-		if tableName != "" {
-			drop.Tables = append(drop.Tables, &ast.TableIdentifier{
-				Position: pos,
-				Database: database,
-				Table:    tableName,
-			})
+	case token.VIEW:
+		return &ast.DropViewQuery{
+			Position:  pos,
+			IfExists:  ifExists,
+			Views:     names,
+			OnCluster: onCluster,
+			Sync:      sync,
+			Format:    format,
+			Settings:  settings,
+		}
+	case token.DATABASE:
+		return &ast.DropDatabaseQuery{
+			Position:  pos,
+			IfExists:  ifExists,
+			IfEmpty:   ifEmpty,
+			Database:  name.Table,
+			OnCluster: onCluster,
+			Sync:      sync,
+			Format:    format,
+			Settings:  settings,
 		}
 	}
+	return &ast.DropDictionaryQuery{
+		Position:   pos,
+		IfExists:   ifExists,
+		Database:   name.Database,
+		Dictionary: name.Table,
+		OnCluster:  onCluster,
+		Sync:       sync,
+		Format:     format,
+		Settings:   settings,
+	}
+}
 
-	// Handle PARALLEL WITH (drop multiple tables in parallel)
-	// Syntax: DROP TABLE IF EXISTS t1 PARALLEL WITH DROP TABLE IF EXISTS t2
-	for p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "PARALLEL" {
-		p.nextToken() // skip PARALLEL
-		if p.currentIs(token.WITH) {
-			p.nextToken() // skip WITH
-			// Parse the next DROP statement
-			if p.currentIs(token.DROP) {
-				p.nextToken() // skip DROP
-				// Handle TEMPORARY
-				if p.currentIs(token.TEMPORARY) {
-					p.nextToken()
-				}
-				// Skip TABLE/DATABASE/etc
-				if p.currentIs(token.TABLE) || p.currentIs(token.DATABASE) || p.currentIs(token.VIEW) {
-					p.nextToken()
-				}
-				// Handle IF EXISTS
-				if p.currentIs(token.IF) {
-					p.nextToken()
-					if p.currentIs(token.EXISTS) {
-						p.nextToken()
-					}
-				}
-				// Parse table name
-				pos := p.current.Pos
-				name := p.parseIdentifierName()
-				var database, tableName string
-				if p.currentIs(token.DOT) {
-					p.nextToken()
-					database = name
-					tableName = p.parseIdentifierName()
-				} else {
-					tableName = name
-				}
-				if tableName != "" {
-					drop.Tables = append(drop.Tables, &ast.TableIdentifier{
-						Position: pos,
-						Database: database,
-						Table:    tableName,
-					})
-				}
-			}
-		}
+func (p *Parser) parseDropFunction(pos token.Position) *ast.DropFunctionQuery {
+	query := &ast.DropFunctionQuery{
+		Position: pos,
 	}
 
-	// Handle ON table or ON CLUSTER
-	if p.currentIs(token.ON) {
+	p.nextToken() // skip FUNCTION
+
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
+		p.nextToken()
+	}
+
+	query.Name = p.parseIdentifierName()
+	query.OnCluster = p.parseOnCluster()
+	return query
+}
+
+func (p *Parser) parseDropIndex(pos token.Position) *ast.DropIndexQuery {
+	query := &ast.DropIndexQuery{
+		Position: pos,
+	}
+
+	p.nextToken() // skip INDEX
+
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
-		if p.currentIs(token.CLUSTER) {
-			p.nextToken()
-			if p.currentIs(token.IDENT) || p.currentIs(token.STRING) {
-				drop.OnCluster = p.current.Value
-				p.nextToken()
-			}
-		} else {
-			// ON table_name (for DROP ROW POLICY, etc.)
-			// Skip the table reference - can be db.table or db.* (wildcard)
-			p.parseIdentifierName()
-			if p.currentIs(token.DOT) {
-				p.nextToken()
-				// Handle wildcard (*) or table name
-				if p.currentIs(token.ASTERISK) {
-					p.nextToken()
-				} else {
-					p.parseIdentifierName()
-				}
-			}
-		}
 	}
 
-	// Handle second ON CLUSTER (can appear after ON table)
-	if p.currentIs(token.ON) {
-		p.nextToken()
-		if p.currentIs(token.CLUSTER) {
+	query.Index = p.parseIdentifierName()
+
+	// ON [db.]table
+	if p.expect(token.ON) {
+		name := p.parseIdentifierName()
+		if p.currentIs(token.DOT) {
 			p.nextToken()
-			if p.currentIs(token.IDENT) || p.currentIs(token.STRING) {
-				drop.OnCluster = p.current.Value
-				p.nextToken()
-			}
+			query.Database = name
+			query.Table = p.parseIdentifierName()
+		} else {
+			query.Table = name
 		}
 	}
 
-	// Handle SYNC (can appear before or after FORMAT)
-	if p.currentIs(token.SYNC) {
-		drop.Sync = true
-		p.nextToken()
+	query.OnCluster = p.parseOnCluster()
+	return query
+}
+
+func (p *Parser) parseDropUser() *ast.DropUserQuery {
+	query := &ast.DropUserQuery{
+		Position: p.current.Pos,
 	}
 
-	// Handle FORMAT clause (for things like DROP TABLE ... FORMAT Null)
-	if p.currentIs(token.FORMAT) {
+	p.nextToken() // skip DROP
+	p.nextToken() // skip USER
+
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
-		// Store format name (Null, etc.)
-		if p.currentIs(token.NULL) {
-			drop.Format = "Null"
-			p.nextToken()
-		} else if p.currentIs(token.IDENT) {
-			drop.Format = p.current.Value
-			p.nextToken()
-		}
 	}
 
-	// Handle SYNC again (can also appear after FORMAT)
-	if p.currentIs(token.SYNC) {
-		drop.Sync = true
+	query.Names = p.parseRoleNames()
+	query.OnCluster = p.parseOnCluster()
+
+	// Handle FROM access_storage
+	if p.currentIs(token.FROM) {
 		p.nextToken()
+		query.Storage = p.parseIdentifierName()
 	}
 
-	// Handle NO DELAY
-	if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "NO" {
+	return query
+}
+
+func (p *Parser) parseDropQuota() *ast.DropQuotaQuery {
+	query := &ast.DropQuotaQuery{
+		Position: p.current.Pos,
+	}
+
+	p.nextToken() // skip DROP
+	p.nextToken() // skip QUOTA
+
+	// Handle IF EXISTS
+	if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+		query.IfExists = true
+		p.nextToken()
 		p.nextToken()
-		if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "DELAY" {
-			p.nextToken()
-		}
 	}
 
-	// Handle SETTINGS clause
-	if p.currentIs(token.SETTINGS) {
-		p.nextToken() // skip SETTINGS
-		drop.Settings = p.parseSettingsList()
+	query.Names = p.parseRoleNames()
+	query.OnCluster = p.parseOnCluster()
+
+	// Handle FROM access_storage
+	if p.currentIs(token.FROM) {
+		p.nextToken()
+		query.Storage = p.parseIdentifierName()
 	}
 
-	return drop
+	return query
 }
 
 func (p *Parser) parseAlter() *ast.AlterQuery {
@@ -5340,19 +6368,24 @@ func (p *Parser) parseAlter() *ast.AlterQuery {
 		p.nextToken()
 	}
 
-	if !p.expect(token.TABLE) {
+	if p.currentIs(token.DATABASE) {
+		// ALTER DATABASE name MODIFY SETTING ... / MODIFY COMMENT ...
+		alter.IsDatabase = true
+		p.nextToken()
+		alter.Database = p.parseIdentifierName()
+	} else if !p.expect(token.TABLE) {
 		return nil
-	}
-
-	// Parse table name (can start with a number in ClickHouse)
-	tableName := p.parseIdentifierName()
-	if tableName != "" {
-		if p.currentIs(token.DOT) {
-			p.nextToken()
-			alter.Database = tableName
-			alter.Table = p.parseIdentifierName()
-		} else {
-			alter.Table = tableName
+	} else {
+		// Parse table name (can start with a number in ClickHouse)
+		tableName := p.parseIdentifierName()
+		if tableName != "" {
+			if p.currentIs(token.DOT) {
+				p.nextToken()
+				alter.Database = tableName
+				alter.Table = p.parseIdentifierName()
+			} else {
+				alter.Table = tableName
+			}
 		}
 	}
 
@@ -5389,6 +6422,18 @@ func (p *Parser) parseAlter() *ast.AlterQuery {
 		p.nextToken()
 	}
 
+	// Settings and comment of a database have their own command types
+	if alter.IsDatabase {
+		for _, cmd := range alter.Commands {
+			switch cmd.Type {
+			case ast.AlterModifySetting:
+				cmd.Type = ast.AlterModifyDatabaseSetting
+			case ast.AlterModifyComment:
+				cmd.Type = ast.AlterModifyDatabaseComment
+			}
+		}
+	}
+
 	// Parse FORMAT clause
 	if p.currentIs(token.FORMAT) {
 		p.nextToken()
@@ -5495,6 +6540,7 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 						}
 						p.expect(token.RPAREN)
 					}
+					p.finish(idx.Type)
 				}
 			}
 			// Parse GRANULARITY
@@ -5506,6 +6552,7 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 					p.nextToken()
 				}
 			}
+			p.finish(idx)
 			// Parse AFTER
 			if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "AFTER" {
 				p.nextToken()
@@ -5526,12 +6573,13 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 			}
 			// Parse CHECK or ASSUME
 			if p.currentIs(token.CHECK) || (p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ASSUME") {
-				p.nextToken()
 				cmd.Constraint = &ast.Constraint{
-					Position:   p.current.Pos,
-					Name:       cmd.ConstraintName,
-					Expression: p.parseExpression(LOWEST),
+					Position: p.current.Pos,
+					Name:     cmd.ConstraintName,
 				}
+				p.nextToken()
+				cmd.Constraint.Expression = p.parseExpression(LOWEST)
+				p.finish(cmd.Constraint)
 			}
 		} else if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "PROJECTION" {
 			cmd.Type = ast.AlterAddProjection
@@ -5765,19 +6813,22 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 			}
 		} else if upper == "MOVE" {
 			p.nextToken()
-			if p.currentIs(token.PARTITION) {
+			if p.currentIs(token.PARTITION) || p.currentIsIdent("PART") {
 				cmd.Type = ast.AlterMovePartition
+				cmd.IsPart = p.currentIsIdent("PART")
 				p.nextToken()
 				// Check for PARTITION ID 'value' syntax
-				if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ID" {
+				if !cmd.IsPart && p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ID" {
 					p.nextToken()
 					cmd.PartitionIsID = true
 				}
 				cmd.Partition = p.parseExpression(LOWEST)
-				// Parse TO TABLE/DISK/VOLUME destination
+				// Parse TO TABLE/DISK/VOLUME destination; a part moves only to a disk or volume
 				if p.currentIs(token.TO) {
 					p.nextToken()
-					if p.currentIs(token.TABLE) {
+					if cmd.IsPart && !p.currentIsIdent("DISK") && !p.currentIsIdent("VOLUME") {
+						p.unexpected()
+					} else if p.currentIs(token.TABLE) {
 						p.nextToken()
 						// Parse destination table (can be qualified: database.table)
 						destName := p.parseIdentifierName()
@@ -5790,13 +6841,34 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 						}
 					} else if p.currentIs(token.IDENT) && (strings.ToUpper(p.current.Value) == "DISK" || strings.ToUpper(p.current.Value) == "VOLUME") {
 						// MOVE PARTITION ... TO DISK 'disk_name' or TO VOLUME 'volume_name'
+						isDisk := strings.ToUpper(p.current.Value) == "DISK"
 						p.nextToken() // skip DISK/VOLUME
-						if p.currentIs(token.STRING) {
-							p.nextToken() // skip the disk/volume name
+						if !p.currentIs(token.STRING) {
+							p.unexpected(token.STRING)
+						} else if isDisk {
+							cmd.ToDisk = p.current.Value
+							p.nextToken()
+						} else {
+							cmd.ToVolume = p.current.Value
+							p.nextToken()
 						}
 					}
 				}
 			}
+		} else if upper == "UNFREEZE" {
+			p.nextToken()
+			cmd.Type = ast.AlterUnfreeze
+			if p.currentIs(token.PARTITION) {
+				cmd.Type = ast.AlterUnfreezePartition
+				p.nextToken()
+				// Check for PARTITION ID 'value' syntax
+				if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ID" {
+					p.nextToken()
+					cmd.PartitionIsID = true
+				}
+				cmd.Partition = p.parseExpression(LOWEST)
+			}
+			cmd.WithName = p.parseFreezeName()
 		} else if upper == "REMOVE" {
 			p.nextToken()
 			// REMOVE SAMPLE BY
@@ -5850,17 +6922,23 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 			if (p.currentIs(token.IDENT) || p.current.Token.IsKeyword()) && p.peek.Token == token.IDENT && strings.ToUpper(p.peek.Value) == "REMOVE" {
 				// Just parse column name without type
 				colName := p.current.Value
+				colPos, colEnd := p.current.Pos, p.current.End
 				p.nextToken() // skip column name
-				cmd.Column = &ast.ColumnDeclaration{Name: colName}
-				// Skip REMOVE COMMENT etc. but stop at SETTINGS clause
-				for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) && !p.currentIs(token.COMMA) && !p.currentIs(token.SETTINGS) {
+				cmd.Column = &ast.ColumnDeclaration{Position: colPos, EndPosition: colEnd, Name: colName}
+				p.nextToken() // skip REMOVE
+				// The removed property is a single word: COMMENT, DEFAULT, CODEC, TTL, SETTINGS, ...
+				if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
+					cmd.RemoveProperty = strings.ToUpper(p.current.Value)
 					p.nextToken()
+				} else {
+					p.unexpected()
 				}
 			} else if (p.currentIs(token.IDENT) || p.current.Token.IsKeyword()) && p.peek.Token == token.MODIFY {
 				// MODIFY COLUMN colname MODIFY SETTING key = value
 				colName := p.current.Value
+				colPos, colEnd := p.current.Pos, p.current.End
 				p.nextToken() // skip column name
-				cmd.Column = &ast.ColumnDeclaration{Name: colName}
+				cmd.Column = &ast.ColumnDeclaration{Position: colPos, EndPosition: colEnd, Name: colName}
 				p.nextToken() // skip MODIFY
 				if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "SETTING" {
 					p.nextToken() // skip SETTING
@@ -5869,8 +6947,9 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 			} else if (p.currentIs(token.IDENT) || p.current.Token.IsKeyword()) && p.peek.Token == token.IDENT && strings.ToUpper(p.peek.Value) == "RESET" {
 				// MODIFY COLUMN colname RESET SETTING key, key2, ...
 				colName := p.current.Value
+				colPos, colEnd := p.current.Pos, p.current.End
 				p.nextToken() // skip column name
-				cmd.Column = &ast.ColumnDeclaration{Name: colName}
+				cmd.Column = &ast.ColumnDeclaration{Position: colPos, EndPosition: colEnd, Name: colName}
 				p.nextToken() // skip RESET
 				if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "SETTING" {
 					p.nextToken() // skip SETTING
@@ -5911,6 +6990,7 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 					break
 				}
 			}
+			p.finish(cmd.TTL)
 			// Keep backward compatibility with Expression/Expressions fields
 			if len(cmd.TTL.Elements) > 0 {
 				cmd.TTL.Expression = cmd.TTL.Elements[0].Expr
@@ -5970,6 +7050,12 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 			cmd.Type = ast.AlterModifyQuery
 			p.nextToken() // skip QUERY
 			cmd.Query = p.parseSelectWithUnion()
+		} else if p.currentIsIdent("REFRESH") {
+			// MODIFY REFRESH AFTER|EVERY interval [OFFSET interval] [RANDOMIZE FOR interval]
+			// [DEPENDS ON view, ...] [SETTINGS ...] [APPEND]
+			cmd.Type = ast.AlterModifyRefresh
+			p.nextToken() // skip REFRESH
+			p.parseModifyRefresh(cmd)
 		}
 	case token.RENAME:
 		p.nextToken()
@@ -6045,13 +7131,10 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 				cmd.PartitionIsID = true
 			}
 			cmd.Partition = p.parseExpression(LOWEST)
-			// Handle FROM table (ATTACH PARTITION ... FROM table)
+			// Handle FROM table (ATTACH PARTITION ... FROM [db.]table)
 			if p.currentIs(token.FROM) {
 				p.nextToken()
-				if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-					cmd.FromTable = p.current.Value
-					p.nextToken()
-				}
+				cmd.FromDatabase, cmd.FromTable = p.parseAlterFromTable()
 			}
 		} else if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "PART" {
 			// ATTACH PART uses ATTACH_PARTITION type in ClickHouse EXPLAIN
@@ -6074,6 +7157,7 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 		} else {
 			cmd.Type = ast.AlterFreeze
 		}
+		cmd.WithName = p.parseFreezeName()
 	case token.REPLACE:
 		p.nextToken()
 		if p.currentIs(token.PARTITION) {
@@ -6085,12 +7169,10 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 				cmd.PartitionIsID = true
 			}
 			cmd.Partition = p.parseExpression(LOWEST)
+			// Handle FROM table (REPLACE PARTITION ... FROM [db.]table)
 			if p.currentIs(token.FROM) {
 				p.nextToken()
-				if p.currentIs(token.IDENT) {
-					cmd.FromTable = p.current.Value
-					p.nextToken()
-				}
+				cmd.FromDatabase, cmd.FromTable = p.parseAlterFromTable()
 			}
 		}
 	case token.FETCH:
@@ -6173,6 +7255,7 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 				p.nextToken() // skip =
 				assign.Value = p.parseExpression(LOWEST)
 			}
+			p.finish(assign)
 			cmd.Assignments = append(cmd.Assignments, assign)
 			if !p.currentIs(token.COMMA) {
 				break
@@ -6188,6 +7271,7 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 				if ident, ok := inExpr.List[0].(*ast.Identifier); ok && strings.ToUpper(ident.Name()) == "PARTITION" {
 					// Fix the mis-parse: the actual assignment value is the left side of IN
 					lastAssign.Value = inExpr.Expr
+					lastAssign.EndPosition = inExpr.Expr.End()
 					// Check for PARTITION ID 'value' syntax
 					if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "ID" {
 						p.nextToken()
@@ -6218,9 +7302,102 @@ func (p *Parser) parseAlterCommand() *ast.AlterCommand {
 		return nil
 	}
 
+	p.finish(cmd)
 	return cmd
 }
 
+// parseAlterFromTable parses the source table after FROM in ATTACH PARTITION
+// and REPLACE PARTITION, optionally qualified with a database.
+func (p *Parser) parseAlterFromTable() (database, table string) {
+	name := p.parseIdentifierName()
+	if p.currentIs(token.DOT) {
+		p.nextToken()
+		return name, p.parseIdentifierName()
+	}
+	return "", name
+}
+
+// parseFreezeName parses the optional WITH NAME 'name' of FREEZE and
+// UNFREEZE.
+func (p *Parser) parseFreezeName() string {
+	if !p.currentIs(token.WITH) || !p.peekIsIdent("NAME") {
+		return ""
+	}
+	p.nextToken() // skip WITH
+	p.nextToken() // skip NAME
+	if !p.currentIs(token.STRING) {
+		p.unexpected(token.STRING)
+		return ""
+	}
+	name := p.current.Value
+	p.nextToken()
+	return name
+}
+
+// parseModifyRefresh parses the refresh strategy of MODIFY REFRESH.
+func (p *Parser) parseModifyRefresh(cmd *ast.AlterCommand) {
+	if !p.currentIsIdent("AFTER") && !p.currentIsIdent("EVERY") {
+		p.unexpected()
+		return
+	}
+	cmd.RefreshType = strings.ToUpper(p.current.Value)
+	p.nextToken()
+	cmd.RefreshInterval, cmd.RefreshUnit = p.parseRefreshInterval()
+
+	if p.currentIs(token.OFFSET) {
+		pos := p.current.Pos
+		p.nextToken()
+		cmd.RefreshOffset = &ast.IntervalExpr{Position: pos}
+		cmd.RefreshOffset.Value, cmd.RefreshOffset.Unit = p.parseRefreshInterval()
+		p.finish(cmd.RefreshOffset)
+	}
+	if p.currentIsIdent("RANDOMIZE") && p.peekIs(token.FOR) {
+		pos := p.current.Pos
+		p.nextToken() // skip RANDOMIZE
+		p.nextToken() // skip FOR
+		cmd.RefreshRandomize = &ast.IntervalExpr{Position: pos}
+		cmd.RefreshRandomize.Value, cmd.RefreshRandomize.Unit = p.parseRefreshInterval()
+		p.finish(cmd.RefreshRandomize)
+	}
+	if p.currentIsIdent("DEPENDS") && p.peekIs(token.ON) {
+		p.nextToken() // skip DEPENDS
+		p.nextToken() // skip ON
+		for {
+			name := p.parseIdentifierName()
+			if p.currentIs(token.DOT) {
+				p.nextToken()
+				name += "." + p.parseIdentifierName()
+			}
+			cmd.RefreshDependsOn = append(cmd.RefreshDependsOn, name)
+			if !p.currentIs(token.COMMA) {
+				break
+			}
+			p.nextToken()
+		}
+	}
+	if p.currentIs(token.SETTINGS) {
+		p.nextToken()
+		cmd.Settings = p.parseSettingsList()
+	}
+	if p.currentIsIdent("APPEND") {
+		cmd.RefreshAppend = true
+		p.nextToken()
+	}
+}
+
+// parseRefreshInterval parses an interval of a refresh strategy, such as
+// 1 HOUR, returning the value and the upper-case unit.
+func (p *Parser) parseRefreshInterval() (ast.Expression, string) {
+	value := p.parseExpression(AND_PREC)
+	if !p.currentIs(token.IDENT) && !p.current.Token.IsKeyword() {
+		p.unexpected()
+		return value, ""
+	}
+	unit := strings.ToUpper(p.current.Value)
+	p.nextToken()
+	return value, unit
+}
+
 func (p *Parser) parseTruncate() *ast.TruncateQuery {
 	trunc := &ast.TruncateQuery{
 		Position: p.current.Pos,
@@ -6376,6 +7553,7 @@ func (p *Parser) parseUpdate() *ast.UpdateQuery {
 			p.nextToken() // skip =
 			assign.Value = p.parseExpression(LOWEST)
 		}
+		p.finish(assign)
 		update.Assignments = append(update.Assignments, assign)
 		if !p.currentIs(token.COMMA) {
 			break
@@ -6845,6 +8023,7 @@ func (p *Parser) parseExplain() *ast.ExplainQuery {
 			}
 			explain.ExplainType = ast.ExplainCurrentTransaction
 			explain.ExplicitType = true
+			p.finish(explain)
 			return explain // No statement follows CURRENT TRANSACTION
 		default:
 			explain.ExplainType = ast.ExplainPlan
@@ -6879,6 +8058,7 @@ func (p *Parser) parseExplain() *ast.ExplainQuery {
 	// Parse the statement being explained
 	explain.Statement = p.parseStatement()
 
+	p.finish(explain)
 	return explain
 }
 
@@ -6894,14 +8074,35 @@ func (p *Parser) parseSet() *ast.SetQuery {
 	return set
 }
 
+// parseSetRole handles SET ROLE and SET DEFAULT ROLE statements
 func (p *Parser) parseSetRole() *ast.SetRoleQuery {
 	query := &ast.SetRoleQuery{
 		Position: p.current.Pos,
+		Kind:     ast.SetRole,
 	}
 
-	// Skip SET DEFAULT ROLE ... TO ...
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+	p.nextToken() // skip SET
+
+	if p.currentIs(token.DEFAULT) {
+		query.Kind = ast.SetDefaultRole
+		p.nextToken()
+	}
+	if !p.currentIsIdent("ROLE") {
+		p.unexpected()
+		return query
+	}
+	p.nextToken() // skip ROLE
+
+	if query.Kind == ast.SetRole && p.currentIs(token.DEFAULT) {
+		query.Kind = ast.SetRoleDefault
 		p.nextToken()
+		return query
+	}
+
+	query.Roles = p.parseRoleSet()
+	if query.Kind == ast.SetDefaultRole {
+		p.expect(token.TO)
+		query.Users = p.parseRoleSet()
 	}
 
 	return query
@@ -6977,6 +8178,103 @@ func (p *Parser) parseOptimize() *ast.OptimizeQuery {
 	return opt
 }
 
+// systemCommand is a SYSTEM command, as matched by its words.
+type systemCommand struct {
+	words []string
+	kind  ast.SystemCommandKind
+	table bool // Followed by an optional [db.]table, view or dictionary
+}
+
+// systemCommands lists the SYSTEM commands. The cache of a DROP or PREWARM
+// ... CACHE command is given by the words between the first and the last.
+var systemCommands = []systemCommand{
+	{[]string{"SHUTDOWN"}, ast.SystemShutdown, false},
+	{[]string{"KILL"}, ast.SystemKill, false},
+	{[]string{"SUSPEND"}, ast.SystemSuspend, false},
+	{[]string{"DROP", "DNS", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "CONNECTIONS", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "MARK", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "UNCOMPRESSED", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "INDEX", "MARK", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "INDEX", "UNCOMPRESSED", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "PRIMARY", "INDEX", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "SKIPPING", "INDEX", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "VECTOR", "SIMILARITY", "INDEX", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "TEXT", "INDEX", "DICTIONARY", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "TEXT", "INDEX", "HEADER", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "TEXT", "INDEX", "POSTINGS", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "TEXT", "INDEX", "CACHES"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "MMAP", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "QUERY", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "QUERY", "CONDITION", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "COMPILED", "EXPRESSION", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "FILESYSTEM", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "PAGE", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "S3", "CLIENT", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "ICEBERG", "METADATA", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "SCHEMA", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"DROP", "FORMAT", "SCHEMA", "CACHE"}, ast.SystemDropCache, false},
+	{[]string{"PREWARM", "MARK", "CACHE"}, ast.SystemPrewarmCache, true},
+	{[]string{"PREWARM", "PRIMARY", "INDEX", "CACHE"}, ast.SystemPrewarmCache, true},
+	{[]string{"RELOAD", "DICTIONARY"}, ast.SystemReloadDictionary, true},
+	{[]string{"RELOAD", "DICTIONARIES"}, ast.SystemReloadDictionaries, false},
+	{[]string{"RELOAD", "EMBEDDED", "DICTIONARIES"}, ast.SystemReloadEmbeddedDictionaries, false},
+	{[]string{"RELOAD", "MODEL"}, ast.SystemReloadModel, false},
+	{[]string{"RELOAD", "MODELS"}, ast.SystemReloadModels, false},
+	{[]string{"RELOAD", "FUNCTION"}, ast.SystemReloadFunction, false},
+	{[]string{"RELOAD", "FUNCTIONS"}, ast.SystemReloadFunctions, false},
+	{[]string{"RELOAD", "CONFIG"}, ast.SystemReloadConfig, false},
+	{[]string{"RELOAD", "USERS"}, ast.SystemReloadUsers, false},
+	{[]string{"RELOAD", "ASYNCHRONOUS", "METRICS"}, ast.SystemReloadAsynchronousMetrics, false},
+	{[]string{"RESTART", "REPLICA"}, ast.SystemRestartReplica, true},
+	{[]string{"RESTART", "REPLICAS"}, ast.SystemRestartReplicas, false},
+	{[]string{"RESTORE", "REPLICA"}, ast.SystemRestoreReplica, true},
+	{[]string{"DROP", "REPLICA"}, ast.SystemDropReplica, false},
+	{[]string{"DROP", "DATABASE", "REPLICA"}, ast.SystemDropDatabaseReplica, false},
+	{[]string{"SYNC", "REPLICA"}, ast.SystemSyncReplica, true},
+	{[]string{"SYNC", "DATABASE", "REPLICA"}, ast.SystemSyncDatabaseReplica, false},
+	{[]string{"SYNC", "FILE", "CACHE"}, ast.SystemSyncFileCache, false},
+	{[]string{"SYNC", "TRANSACTION", "LOG"}, ast.SystemSyncTransactionLog, false},
+	{[]string{"FLUSH", "LOGS"}, ast.SystemFlushLogs, false},
+	{[]string{"FLUSH", "DISTRIBUTED"}, ast.SystemFlushDistributed, true},
+	{[]string{"FLUSH", "ASYNC", "INSERT", "QUEUE"}, ast.SystemFlushAsyncInsertQueue, true},
+	{[]string{"START", "MERGES"}, ast.SystemStartMerges, true},
+	{[]string{"STOP", "MERGES"}, ast.SystemStopMerges, true},
+	{[]string{"START", "TTL", "MERGES"}, ast.SystemStartTTLMerges, true},
+	{[]string{"STOP", "TTL", "MERGES"}, ast.SystemStopTTLMerges, true},
+	{[]string{"START", "MOVES"}, ast.SystemStartMoves, true},
+	{[]string{"STOP", "MOVES"}, ast.SystemStopMoves, true},
+	{[]string{"START", "FETCHES"}, ast.SystemStartFetches, true},
+	{[]string{"STOP", "FETCHES"}, ast.SystemStopFetches, true},
+	{[]string{"START", "REPLICATED", "SENDS"}, ast.SystemStartReplicatedSends, true},
+	{[]string{"STOP", "REPLICATED", "SENDS"}, ast.SystemStopReplicatedSends, true},
+	{[]string{"START", "DISTRIBUTED", "SENDS"}, ast.SystemStartDistributedSends, true},
+	{[]string{"STOP", "DISTRIBUTED", "SENDS"}, ast.SystemStopDistributedSends, true},
+	{[]string{"START", "REPLICATION", "QUEUES"}, ast.SystemStartReplicationQueues, true},
+	{[]string{"STOP", "REPLICATION", "QUEUES"}, ast.SystemStopReplicationQueues, true},
+	{[]string{"START", "PULLING", "REPLICATION", "LOG"}, ast.SystemStartPullingReplicationLog, true},
+	{[]string{"STOP", "PULLING", "REPLICATION", "LOG"}, ast.SystemStopPullingReplicationLog, true},
+	{[]string{"START", "CLEANUP"}, ast.SystemStartCleanup, true},
+	{[]string{"STOP", "CLEANUP"}, ast.SystemStopCleanup, true},
+	{[]string{"START", "REPLICATED", "DDL", "QUERIES"}, ast.SystemStartReplicatedDDLQueries, false},
+	{[]string{"STOP", "REPLICATED", "DDL", "QUERIES"}, ast.SystemStopReplicatedDDLQueries, false},
+	{[]string{"START", "LISTEN"}, ast.SystemStartListen, false},
+	{[]string{"STOP", "LISTEN"}, ast.SystemStopListen, false},
+	{[]string{"START", "VIEW"}, ast.SystemStartView, true},
+	{[]string{"STOP", "VIEW"}, ast.SystemStopView, true},
+	{[]string{"START", "VIEWS"}, ast.SystemStartViews, false},
+	{[]string{"STOP", "VIEWS"}, ast.SystemStopViews, false},
+	{[]string{"REFRESH", "VIEW"}, ast.SystemRefreshView, true},
+	{[]string{"CANCEL", "VIEW"}, ast.SystemCancelView, true},
+	{[]string{"WAIT", "VIEW"}, ast.SystemWaitView, true},
+	{[]string{"ENABLE", "FAILPOINT"}, ast.SystemEnableFailpoint, false},
+	{[]string{"DISABLE", "FAILPOINT"}, ast.SystemDisableFailpoint, false},
+	{[]string{"WAIT", "LOADING", "PARTS"}, ast.SystemWaitLoadingParts, true},
+	{[]string{"LOAD", "PRIMARY", "KEY"}, ast.SystemLoadPrimaryKey, true},
+	{[]string{"UNLOAD", "PRIMARY", "KEY"}, ast.SystemUnloadPrimaryKey, true},
+	{[]string{"UNFREEZE"}, ast.SystemUnfreeze, false},
+}
+
 func (p *Parser) parseSystem() *ast.SystemQuery {
 	sys := &ast.SystemQuery{
 		Position: p.current.Pos,
@@ -6984,182 +8282,247 @@ func (p *Parser) parseSystem() *ast.SystemQuery {
 
 	p.nextToken() // skip SYSTEM
 
-	// Read the command - can include identifiers and keywords (like TTL, SYNC, etc.)
-	// Stop when we see:
-	// 1. An IDENT followed by DOT (qualified table name like sqllt.table)
-	// 2. A plain IDENT (not a command keyword) followed by end-of-statement (SEMICOLON, EOF, FORMAT),
-	//    UNLESS the previous part was FAILPOINT (failpoint names are part of the command)
-	var parts []string
-	for p.currentIs(token.IDENT) || p.isSystemCommandKeyword() {
-		// Check if this IDENT is followed by DOT - if so, it's a table name, not part of the command
-		if p.currentIs(token.IDENT) && p.peekIs(token.DOT) {
-			break
+	cmd := p.parseSystemCommand()
+	if cmd == nil {
+		return sys
+	}
+	sys.Kind = cmd.kind
+	switch cmd.kind {
+	case ast.SystemDropCache, ast.SystemPrewarmCache:
+		sys.Cache = strings.Join(cmd.words[1:len(cmd.words)-1], " ")
+	case ast.SystemOther:
+		sys.Command = strings.Join(cmd.words, " ")
+	}
+
+	// ON CLUSTER comes before the arguments
+	sys.OnCluster = p.parseOnCluster()
+
+	switch cmd.kind {
+	case ast.SystemDropCache:
+		switch sys.Cache {
+		case "QUERY":
+			// DROP QUERY CACHE TAG 'tag'
+			if p.currentIsIdent("TAG") && p.peekIs(token.STRING) {
+				p.nextToken()
+				sys.CacheTag = p.current.Value
+				p.nextToken()
+			}
+		case "FILESYSTEM":
+			// DROP FILESYSTEM CACHE 'name'
+			if p.currentIs(token.STRING) {
+				sys.CacheName = p.current.Value
+				p.nextToken()
+			}
+		case "SCHEMA", "FORMAT SCHEMA":
+			// DROP [FORMAT] SCHEMA CACHE FOR storage or format
+			if p.currentIs(token.FOR) {
+				p.nextToken()
+				sys.SchemaCacheFor = p.parseIdentifierName()
+			}
 		}
-		// Check if we've completed a command that expects a table name next
-		// Commands like STOP MERGES, START MERGES, STOP TTL MERGES, START TTL MERGES, etc.
-		if len(parts) > 0 {
-			upperCmd := strings.ToUpper(strings.Join(parts, " "))
-			if strings.HasSuffix(upperCmd, " MERGES") ||
-				strings.HasSuffix(upperCmd, " MOVES") ||
-				strings.HasSuffix(upperCmd, " FETCHES") ||
-				strings.HasSuffix(upperCmd, " SENDS") ||
-				strings.HasSuffix(upperCmd, " MUTATIONS") ||
-				upperCmd == "FLUSH DISTRIBUTED" ||
-				upperCmd == "STOP DISTRIBUTED SENDS" ||
-				upperCmd == "START DISTRIBUTED SENDS" {
-				// Next token should be the table name
+	case ast.SystemStartMerges, ast.SystemStopMerges:
+		// START/STOP MERGES ON VOLUME policy.volume
+		if p.currentIs(token.ON) && p.peekIsIdent("VOLUME") {
+			p.nextToken() // skip ON
+			p.nextToken() // skip VOLUME
+			sys.Volume = p.parseIdentifierName()
+			if p.expect(token.DOT) {
+				sys.Volume += "." + p.parseIdentifierName()
+			}
+		}
+	case ast.SystemSyncDatabaseReplica:
+		sys.Database = p.parseIdentifierName()
+	case ast.SystemDropReplica, ast.SystemDropDatabaseReplica:
+		p.parseSystemDropReplica(sys)
+	case ast.SystemFlushLogs:
+		// FLUSH LOGS [log, ...]
+		for p.isSystemTableStart() {
+			name := p.parseIdentifierName()
+			if p.currentIs(token.DOT) {
+				p.nextToken()
+				name += "." + p.parseIdentifierName()
+			}
+			sys.Logs = append(sys.Logs, name)
+			if !p.currentIs(token.COMMA) {
 				break
 			}
+			p.nextToken()
 		}
-		// Check if this is a plain IDENT (not a command keyword) followed by end-of-statement
-		// This indicates it's likely a table name, not part of the command
-		// Exception: after FAILPOINT, the identifier is the failpoint name (part of command)
-		if p.currentIs(token.IDENT) && !p.isSystemCommandKeyword() {
-			if p.peekIs(token.SEMICOLON) || p.peekIs(token.EOF) || p.peekIs(token.FORMAT) {
-				// Check if previous part was FAILPOINT or FOR - these are followed by identifiers that are part of command
-				if len(parts) > 0 {
-					lastPart := strings.ToUpper(parts[len(parts)-1])
-					if lastPart == "FAILPOINT" || lastPart == "FOR" {
-						// This is a failpoint name or format name, consume it as part of command
-						parts = append(parts, p.current.Value)
-						p.nextToken()
-						continue
-					}
+	case ast.SystemEnableFailpoint, ast.SystemDisableFailpoint:
+		sys.Failpoint = p.parseIdentifierName()
+		if sys.Failpoint == "" {
+			p.unexpected()
+		}
+	case ast.SystemReloadModel, ast.SystemReloadFunction:
+		if p.isSystemTableStart() {
+			sys.Name = p.parseIdentifierName()
+		}
+	case ast.SystemStartListen, ast.SystemStopListen:
+		// The protocol, such as TCP WITH PROXY, QUERIES ALL or CUSTOM 'name'
+		var words []string
+		for p.isSystemTableStart() || p.currentIs(token.STRING) {
+			words = append(words, p.current.Value)
+			p.nextToken()
+		}
+		sys.Name = strings.Join(words, " ")
+	case ast.SystemSuspend:
+		// SUSPEND FOR n SECOND
+		if p.expect(token.FOR) {
+			sys.Duration = p.parseExpression(AND_PREC)
+			if p.currentIsIdent("SECOND") {
+				p.nextToken()
+			}
+		}
+	case ast.SystemUnfreeze:
+		sys.Name = p.parseFreezeName()
+	}
+
+	if cmd.table && p.isSystemTableStart() {
+		name := p.parseIdentifierName()
+		if p.currentIs(token.DOT) {
+			p.nextToken()
+			sys.Database = name
+			sys.Table = p.parseIdentifierName()
+		} else {
+			sys.Table = name
+		}
+	}
+
+	if cmd.kind == ast.SystemSyncReplica {
+		// SYNC REPLICA table [PULL | STRICT | LIGHTWEIGHT [FROM 'replica', ...]]
+		switch {
+		case p.currentIsIdent("PULL"):
+			sys.SyncMode = ast.SyncReplicaPull
+		case p.currentIsIdent("STRICT"):
+			sys.SyncMode = ast.SyncReplicaStrict
+		case p.currentIsIdent("LIGHTWEIGHT"):
+			sys.SyncMode = ast.SyncReplicaLightweight
+		}
+		if sys.SyncMode != "" {
+			p.nextToken()
+		}
+		if sys.SyncMode == ast.SyncReplicaLightweight && p.currentIs(token.FROM) {
+			p.nextToken()
+			for p.currentIs(token.STRING) {
+				sys.SyncFrom = append(sys.SyncFrom, p.current.Value)
+				p.nextToken()
+				if !p.currentIs(token.COMMA) {
+					break
 				}
-				break
+				p.nextToken()
 			}
-			// Special case: for SYNC REPLICA commands, check if next token is a mode keyword (PULL/LIGHTWEIGHT/STRICT)
-			// followed by end-of-statement. If so, current token is the table name.
-			if p.peekIs(token.IDENT) {
-				nextUpper := strings.ToUpper(p.peek.Value)
-				if nextUpper == "PULL" || nextUpper == "LIGHTWEIGHT" || nextUpper == "STRICT" {
-					// Look ahead one more token to check if it's followed by end-of-statement
-					currentValue := p.current.Value
-					p.nextToken() // now at mode keyword
-					modeValue := p.current.Value
-					if p.peekIs(token.SEMICOLON) || p.peekIs(token.EOF) || p.peekIs(token.FORMAT) {
-						// Mode keyword is followed by end-of-statement
-						// Include mode in command, but NOT the table name
-						parts = append(parts, modeValue)
-						p.nextToken() // move past mode keyword
-						// Restore table name info for later parsing
-						sys.Command = strings.Join(parts, " ")
-						sys.Table = currentValue
-						// Skip the normal table parsing since we've set it here
-						return sys
-					}
-					// Not followed by end-of-statement, continue normally
-					// But we've consumed tokens, so we need to handle this carefully
-					// For now, let's add both to parts and continue
-					parts = append(parts, currentValue)
-					parts = append(parts, modeValue)
-					p.nextToken()
-					continue
+		}
+	}
+
+	// Parse optional SETTINGS clause
+	if p.currentIs(token.SETTINGS) {
+		p.nextToken() // skip SETTINGS
+		sys.Settings = p.parseSettingsList()
+	}
+
+	return sys
+}
+
+// parseSystemCommand matches the words of a SYSTEM command against
+// systemCommands, consuming words for as long as they start a command.
+// A command that is not listed is of kind SystemOther, and its words run
+// up to ON, SETTINGS, FORMAT or a qualified table name.
+func (p *Parser) parseSystemCommand() *systemCommand {
+	var words, written []string
+	var match *systemCommand
+	for p.isSystemCommandWord() {
+		next := append(words, strings.ToUpper(p.current.Value))
+		isPrefix := false
+		for i := range systemCommands {
+			cmd := &systemCommands[i]
+			if len(cmd.words) >= len(next) && slices.Equal(cmd.words[:len(next)], next) {
+				isPrefix = true
+				if len(cmd.words) == len(next) {
+					match = cmd
 				}
 			}
 		}
-		parts = append(parts, p.current.Value)
+		if !isPrefix {
+			break
+		}
+		words = next
+		written = append(written, p.current.Value)
 		p.nextToken()
 	}
-	sys.Command = strings.Join(parts, " ")
+	if match != nil && len(match.words) == len(words) {
+		return match
+	}
 
-	// Check for ON CLUSTER clause - comes before the table name
-	if p.currentIs(token.ON) {
+	for p.isSystemCommandWord() && !p.currentIs(token.ON) && !p.currentIs(token.SETTINGS) &&
+		!p.currentIs(token.FORMAT) && !p.peekIs(token.DOT) {
+		written = append(written, p.current.Value)
 		p.nextToken()
-		if p.currentIs(token.CLUSTER) {
-			p.nextToken()
-			sys.OnCluster = p.parseIdentifierName()
-		}
 	}
+	if len(written) == 0 {
+		p.unexpected()
+		return nil
+	}
+	return &systemCommand{words: written, kind: ast.SystemOther, table: true}
+}
 
-	// Parse optional table name for commands like SYNC REPLICA table
-	// Table names can be keywords like "system" or dotted like "system.one"
-	if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-		tableName := p.current.Value
+// isSystemCommandWord reports whether the current token can be a word of
+// a SYSTEM command.
+func (p *Parser) isSystemCommandWord() bool {
+	return (p.currentIs(token.IDENT) && !p.current.Quoted) || p.current.Token.IsKeyword()
+}
+
+// parseSystemDropReplica parses the arguments of DROP REPLICA and DROP
+// DATABASE REPLICA: 'name' [FROM SHARD 'shard'] followed by FROM TABLE
+// [db.]table, FROM DATABASE db or FROM ZKPATH 'path'.
+func (p *Parser) parseSystemDropReplica(sys *ast.SystemQuery) {
+	if !p.currentIs(token.STRING) {
+		p.unexpected(token.STRING)
+		return
+	}
+	sys.Replica = p.current.Value
+	p.nextToken()
+
+	for p.currentIs(token.FROM) {
 		p.nextToken()
-		if p.currentIs(token.DOT) {
+		switch {
+		case p.currentIsIdent("SHARD") && p.peekIs(token.STRING):
 			p.nextToken()
-			sys.Database = tableName
-			if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-				sys.Table = p.current.Value
+			sys.Shard = p.current.Value
+			p.nextToken()
+		case p.currentIs(token.TABLE):
+			p.nextToken()
+			name := p.parseIdentifierName()
+			if p.currentIs(token.DOT) {
 				p.nextToken()
-			}
-		} else {
-			// For certain commands, the table name appears as both database and table in EXPLAIN
-			// But for FLUSH DISTRIBUTED, use DuplicateTableOutput instead of setting both
-			upperCmd := strings.ToUpper(sys.Command)
-			if strings.Contains(upperCmd, "RELOAD DICTIONARY") ||
-				strings.Contains(upperCmd, "DROP REPLICA") ||
-				strings.Contains(upperCmd, "RESTORE REPLICA") ||
-				strings.Contains(upperCmd, "STOP DISTRIBUTED SENDS") ||
-				strings.Contains(upperCmd, "START DISTRIBUTED SENDS") ||
-				strings.Contains(upperCmd, "LOAD PRIMARY KEY") ||
-				strings.Contains(upperCmd, "UNLOAD PRIMARY KEY") {
-				sys.Database = tableName
-				sys.Table = tableName
+				sys.Database = name
+				sys.Table = p.parseIdentifierName()
 			} else {
-				sys.Table = tableName
+				sys.Table = name
 			}
+		case p.currentIs(token.DATABASE):
+			p.nextToken()
+			sys.Database = p.parseIdentifierName()
+		case p.currentIsIdent("ZKPATH") && p.peekIs(token.STRING):
+			p.nextToken()
+			sys.ReplicaPath = p.current.Value
+			p.nextToken()
+		default:
+			p.unexpected()
+			return
 		}
 	}
-
-	// Set DuplicateTableOutput for commands that need database/table output twice
-	upperCmd := strings.ToUpper(sys.Command)
-	if strings.Contains(upperCmd, "FLUSH DISTRIBUTED") {
-		// FLUSH DISTRIBUTED always outputs the table name twice (even if unqualified)
-		if sys.Table != "" {
-			sys.DuplicateTableOutput = true
-		}
-	} else if strings.Contains(upperCmd, "STOP DISTRIBUTED SENDS") ||
-		strings.Contains(upperCmd, "START DISTRIBUTED SENDS") ||
-		strings.Contains(upperCmd, "RELOAD DICTIONARY") {
-		// Only set duplicate if database and table are different (qualified name)
-		if sys.Database != sys.Table {
-			sys.DuplicateTableOutput = true
-		}
-	}
-
-	// Parse optional SETTINGS clause
-	if p.currentIs(token.SETTINGS) {
-		p.nextToken() // skip SETTINGS
-		sys.Settings = p.parseSettingsList()
-	}
-
-	return sys
 }
 
-// isSystemCommandKeyword returns true if current token is a keyword/identifier that is part of SYSTEM command
-// and should NOT be treated as a table name
-func (p *Parser) isSystemCommandKeyword() bool {
+// isSystemTableStart reports whether the current token can start the name
+// of a table or other object following a SYSTEM command.
+func (p *Parser) isSystemTableStart() bool {
 	switch p.current.Token {
-	case token.TTL, token.SYNC, token.DROP, token.FORMAT, token.FOR, token.INDEX, token.INSERT,
-		token.PRIMARY, token.KEY, token.DISTRIBUTED, token.RESTORE:
+	case token.IDENT, token.PARAM, token.NUMBER:
 		return true
+	case token.SETTINGS, token.FORMAT, token.ON:
+		return false
 	}
-	// Handle identifiers that are part of SYSTEM commands (not table names)
-	if p.currentIs(token.IDENT) {
-		upper := strings.ToUpper(p.current.Value)
-		switch upper {
-		case "SCHEMA", "CACHE", "QUEUE",
-			// FAILPOINT names are part of the command, not table names
-			"FAILPOINT",
-			// These are common parts of SYSTEM commands that end with identifiers
-			"ENABLE", "DISABLE", "FLUSH", "RELOAD", "RESTART", "STOP", "START",
-			// Parts of STOP/START commands
-			"LISTEN", "LISTENING", "MOVES", "MERGES", "TTL", "SENDS", "FETCHES", "PULLING",
-			"REPLICATED", "DISTRIBUTED", "CLEANUP",
-			// RELOAD targets
-			"DICTIONARIES", "DICTIONARY", "MODELS", "MODEL", "FUNCTIONS", "FUNCTION",
-			"EMBEDDED", "CONFIG", "SYMBOLS", "ASYNCHRONOUS", "METRICS",
-			// FLUSH/DROP targets
-			"LOGS", "ASYNC", "UNCOMPRESSED", "COMPILED", "MARK", "QUERY", "MMAP",
-			"DNS", "FILESYSTEM", "S3",
-			// Other command parts
-			"MUTATIONS", "REPLICATION", "QUEUES", "DDL", "REPLICAS", "REPLICA":
-			return true
-		}
-	}
-	return false
+	return p.current.Token.IsKeyword()
 }
 
 func (p *Parser) parseRename() *ast.RenameQuery {
@@ -7190,7 +8553,7 @@ func (p *Parser) parseRename() *ast.RenameQuery {
 
 	// Parse rename pairs (can have multiple: t1 TO t2, t3 TO t4, ...)
 	for {
-		pair := &ast.RenamePair{}
+		pair := &ast.RenamePair{Position: p.current.Pos}
 
 		// Parse from table name (can be qualified: database.table)
 		fromName := p.parseIdentifierName()
@@ -7216,6 +8579,7 @@ func (p *Parser) parseRename() *ast.RenameQuery {
 			pair.ToTable = toName
 		}
 
+		p.finish(pair)
 		rename.Pairs = append(rename.Pairs, pair)
 
 		// Check for more pairs
@@ -7523,9 +8887,10 @@ func (p *Parser) parseAttach() *ast.AttachQuery {
 					p.expect(token.RPAREN)
 					if len(exprs) == 0 || len(exprs) > 1 {
 						attach.OrderBy = []ast.Expression{&ast.Literal{
-							Position: pos,
-							Type:     ast.LiteralTuple,
-							Value:    exprs,
+							Position:    pos,
+							EndPosition: p.prevEnd,
+							Type:        ast.LiteralTuple,
+							Value:       exprs,
 						}}
 					} else {
 						attach.OrderBy = exprs
@@ -7544,9 +8909,10 @@ func (p *Parser) parseAttach() *ast.AttachQuery {
 					p.expect(token.RPAREN)
 					if len(exprs) == 0 || len(exprs) > 1 {
 						attach.PrimaryKey = []ast.Expression{&ast.Literal{
-							Position: pos,
-							Type:     ast.LiteralTuple,
-							Value:    exprs,
+							Position:    pos,
+							EndPosition: p.prevEnd,
+							Type:        ast.LiteralTuple,
+							Value:       exprs,
 						}}
 					} else {
 						attach.PrimaryKey = exprs
@@ -7642,6 +9008,7 @@ func (p *Parser) parseArrayJoin() *ast.ArrayJoinClause {
 	// Parse array expressions
 	aj.Columns = p.parseExpressionList()
 
+	p.finish(aj)
 	return aj
 }
 
@@ -7663,15 +9030,15 @@ func (p *Parser) parseWindowDefinitions() []*ast.WindowDefinition {
 			break
 		}
 
-		if !p.expect(token.LPAREN) {
-			break
-		}
-
 		// Parse window specification
 		spec := &ast.WindowSpec{
 			Position: p.current.Pos,
 		}
 
+		if !p.expect(token.LPAREN) {
+			break
+		}
+
 		// Check for named window reference (e.g., w1 as (w0 ORDER BY ...))
 		if p.currentIs(token.IDENT) {
 			upper := strings.ToUpper(p.current.Value)
@@ -7706,7 +9073,9 @@ func (p *Parser) parseWindowDefinitions() []*ast.WindowDefinition {
 		}
 
 		p.expect(token.RPAREN)
+		p.finish(spec)
 		def.Spec = spec
+		p.finish(def)
 		defs = append(defs, def)
 
 		if !p.currentIs(token.COMMA) {
@@ -7824,8 +9193,10 @@ func (p *Parser) parseFromSelectSyntax() *ast.SelectWithUnionQuery {
 
 	// Continue parsing the rest of SELECT (WHERE, GROUP BY, etc.)
 	p.parseSelectRemainder(sel)
+	p.finish(sel)
 
 	query.Selects = append(query.Selects, sel)
+	p.finish(query)
 	return query
 }
 
@@ -8008,18 +9379,7 @@ func (p *Parser) parseParenthesizedSelect() *ast.SelectWithUnionQuery {
 		if p.currentIs(token.FORMAT) {
 			p.nextToken()
 			formatParsed = true
-			// Get the format name and attach to first SELECT
-			if len(query.Selects) > 0 {
-				if sq, ok := query.Selects[0].(*ast.SelectQuery); ok {
-					if p.currentIs(token.NULL) {
-						sq.Format = &ast.Identifier{Position: p.current.Pos, Parts: []string{"Null"}}
-						p.nextToken()
-					} else if p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
-						sq.Format = &ast.Identifier{Position: p.current.Pos, Parts: []string{p.current.Value}}
-						p.nextToken()
-					}
-				}
-			}
+			query.Format = p.parseFormatIdentifier()
 		} else if p.currentIs(token.SETTINGS) {
 			p.nextToken()
 			settings := p.parseSettingsList()
@@ -8101,6 +9461,7 @@ func (p *Parser) parseProjection() *ast.Projection {
 
 	// Parse (SELECT ...)
 	if !p.currentIs(token.LPAREN) {
+		p.finish(proj)
 		return proj
 	}
 	p.nextToken() // skip (
@@ -8182,42 +9543,283 @@ func (p *Parser) parseProjection() *ast.Projection {
 		}
 	}
 
+	p.finish(proj.Select)
+
 	// Skip closing paren
 	if p.currentIs(token.RPAREN) {
 		p.nextToken()
 	}
 
+	p.finish(proj)
 	return proj
 }
 
-// parseGrant handles GRANT statements
+// parseGrant handles GRANT and REVOKE statements
 func (p *Parser) parseGrant() *ast.GrantQuery {
 	grant := &ast.GrantQuery{
 		Position: p.current.Pos,
-		IsRevoke: false,
+		IsRevoke: p.currentIs(token.REVOKE),
 	}
 
-	// Skip all tokens until end of statement
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
-		p.nextToken()
+	p.nextToken() // skip GRANT or REVOKE
+
+	grant.OnCluster = p.parseOnCluster()
+
+	// REVOKE GRANT OPTION FOR ... and REVOKE ADMIN OPTION FOR ...
+	if grant.IsRevoke && p.peekIsIdent("OPTION") && p.peekPeekIs(token.FOR) {
+		if p.currentIs(token.GRANT) {
+			grant.GrantOption = true
+		} else if p.currentIsIdent("ADMIN") {
+			grant.AdminOption = true
+		}
+		if grant.GrantOption || grant.AdminOption {
+			p.nextToken() // skip GRANT or ADMIN
+			p.nextToken() // skip OPTION
+			p.nextToken() // skip FOR
+		}
+	}
+
+	if !grant.IsRevoke && p.currentIsIdent("CURRENT") && p.peekIsIdent("GRANTS") {
+		// GRANT CURRENT GRANTS [(privileges ON target, ...) | ON target]
+		grant.CurrentGrants = true
+		p.nextToken() // skip CURRENT
+		p.nextToken() // skip GRANTS
+		if p.currentIs(token.LPAREN) {
+			p.nextToken()
+			grant.Elements, _ = p.parseGrantElements()
+			p.expect(token.RPAREN)
+		} else if p.currentIs(token.ON) {
+			elem := &ast.GrantElement{Position: p.current.Pos}
+			p.parseGrantTarget(elem)
+			p.finish(elem)
+			grant.Elements = append(grant.Elements, elem)
+		}
+	} else {
+		grant.Elements, grant.Roles = p.parseGrantElements()
+	}
+
+	if grant.IsRevoke {
+		p.expect(token.FROM)
+	} else {
+		p.expect(token.TO)
+	}
+	grant.Grantees = p.parseRoleSet()
+
+	// WITH GRANT OPTION, WITH ADMIN OPTION and WITH REPLACE OPTION
+	for p.currentIs(token.WITH) && p.peekPeekIsIdent("OPTION") {
+		switch {
+		case p.peekIs(token.GRANT):
+			grant.GrantOption = true
+		case p.peekIsIdent("ADMIN"):
+			grant.AdminOption = true
+		case p.peekIs(token.REPLACE):
+			grant.ReplaceOption = true
+		default:
+			p.errorAt(p.peek, fmt.Sprintf("unexpected token %s", p.peek.Token), token.GRANT, token.REPLACE)
+			return grant
+		}
+		p.nextToken() // skip WITH
+		p.nextToken() // skip GRANT, ADMIN or REPLACE
+		p.nextToken() // skip OPTION
+	}
+
+	if grant.OnCluster == "" {
+		grant.OnCluster = p.parseOnCluster()
 	}
 
 	return grant
 }
 
-// parseRevoke handles REVOKE statements
-func (p *Parser) parseRevoke() *ast.GrantQuery {
-	grant := &ast.GrantQuery{
-		Position: p.current.Pos,
-		IsRevoke: true,
+// parseGrantElements parses the privileges of a GRANT or REVOKE statement,
+// each list of them followed by ON and its target. A list of names without
+// ON is a list of roles, which is returned instead, leaving out NONE.
+func (p *Parser) parseGrantElements() (elements []*ast.GrantElement, roles []string) {
+	for {
+		elem := &ast.GrantElement{Position: p.current.Pos}
+		var names []string
+		for {
+			priv, name := p.parsePrivilege()
+			if priv == nil {
+				p.unexpected()
+				return elements, roles
+			}
+			elem.Privileges = append(elem.Privileges, priv)
+			names = append(names, name)
+			if !p.currentIs(token.COMMA) {
+				break
+			}
+			p.nextToken()
+		}
+
+		if !p.currentIs(token.ON) {
+			if len(elements) > 0 {
+				p.unexpected(token.ON)
+				return elements, roles
+			}
+			for _, name := range names {
+				if !strings.EqualFold(name, "NONE") {
+					roles = append(roles, name)
+				}
+			}
+			return nil, roles
+		}
+		p.parseGrantTarget(elem)
+		p.finish(elem)
+		elements = append(elements, elem)
+
+		if !p.currentIs(token.COMMA) {
+			return elements, roles
+		}
+		p.nextToken()
 	}
+}
 
-	// Skip all tokens until end of statement
-	for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+// parsePrivilege parses a privilege, which may be several words such as
+// ALTER UPDATE, followed by an optional list of columns. name is the
+// privilege as written, which is the name of a role in GRANT role TO user.
+func (p *Parser) parsePrivilege() (priv *ast.Privilege, name string) {
+	priv = &ast.Privilege{Position: p.current.Pos}
+	var words []string
+	for (p.currentIs(token.IDENT) || p.current.Token.IsKeyword()) &&
+		!p.currentIs(token.ON) && !p.currentIs(token.TO) && !p.currentIs(token.FROM) {
+		words = append(words, p.current.Value)
+		p.nextToken()
+	}
+	if len(words) == 0 {
+		if !p.currentIs(token.STRING) {
+			return nil, ""
+		}
+		words = append(words, p.current.Value)
 		p.nextToken()
 	}
+	name = strings.Join(words, " ")
+	priv.Name = strings.ToUpper(name)
 
-	return grant
+	if p.currentIs(token.LPAREN) {
+		p.nextToken()
+		for !p.currentIs(token.RPAREN) && !p.currentIs(token.EOF) {
+			col := p.parseIdentifierName()
+			if col == "" {
+				p.unexpected(token.RPAREN)
+				break
+			}
+			priv.Columns = append(priv.Columns, col)
+			if !p.currentIs(token.COMMA) {
+				break
+			}
+			p.nextToken()
+		}
+		p.expect(token.RPAREN)
+	}
+
+	p.finish(priv)
+	return priv, name
+}
+
+// parseGrantTarget parses ON and the object privileges are granted on:
+// *.*, db.*, db.table, table, or a name such as a table engine, with
+// * as a wildcard at the end of each name.
+func (p *Parser) parseGrantTarget(elem *ast.GrantElement) {
+	p.nextToken() // skip ON
+
+	name := p.parseGrantTargetName()
+	if p.currentIs(token.DOT) {
+		p.nextToken()
+		elem.Database = name
+		name = p.parseGrantTargetName()
+	}
+	elem.Table = name
+}
+
+func (p *Parser) parseGrantTargetName() string {
+	if p.currentIs(token.ASTERISK) {
+		p.nextToken()
+		return "*"
+	}
+	name := p.parseIdentifierName()
+	if name == "" {
+		p.unexpected()
+		return ""
+	}
+	if p.currentIs(token.ASTERISK) {
+		p.nextToken()
+		name += "*"
+	}
+	return name
+}
+
+// parseRoleSet parses a list of users and roles, such as the grantees
+// after TO in GRANT: names, CURRENT_USER, or ALL [EXCEPT names]. ANY, as
+// in GRANTEES ANY, is the same as ALL. NONE stands for an empty list.
+func (p *Parser) parseRoleSet() *ast.RoleSet {
+	set := &ast.RoleSet{Position: p.current.Pos}
+	except := false
+	for {
+		switch {
+		case !except && (p.currentIs(token.ALL) || p.currentIs(token.ANY)):
+			set.All = true
+			p.nextToken()
+		case p.currentIsIdent("CURRENT_USER"):
+			if except {
+				set.ExceptCurrentUser = true
+			} else {
+				set.CurrentUser = true
+			}
+			p.nextToken()
+			if p.currentIs(token.LPAREN) && p.peekIs(token.RPAREN) {
+				p.nextToken()
+				p.nextToken()
+			}
+		case p.currentIsIdent("NONE"):
+			p.nextToken()
+		default:
+			name := p.parseRoleName()
+			if name == "" {
+				p.unexpected()
+				p.finish(set)
+				return set
+			}
+			if except {
+				set.Except = append(set.Except, name)
+			} else {
+				set.Names = append(set.Names, name)
+			}
+		}
+
+		if !except && p.currentIs(token.EXCEPT) {
+			except = true
+			p.nextToken()
+			continue
+		}
+		if !p.currentIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+	}
+	p.finish(set)
+	return set
+}
+
+// parseRoleName parses the name of a role or user, including the host of
+// a user written as name@host.
+func (p *Parser) parseRoleName() string {
+	name := p.parseIdentifierName()
+	if name != "" && p.currentIs(token.IDENT) && p.current.Value == "@" && !p.current.Quoted {
+		p.nextToken()
+		name += "@" + p.parseIdentifierName()
+	}
+	return name
+}
+
+// parseOnCluster parses an optional ON CLUSTER clause, returning the
+// cluster name.
+func (p *Parser) parseOnCluster() string {
+	if !p.currentIs(token.ON) || !p.peekIs(token.CLUSTER) {
+		return ""
+	}
+	p.nextToken() // skip ON
+	p.nextToken() // skip CLUSTER
+	return p.parseIdentifierName()
 }
 
 func (p *Parser) parseBackup() *ast.BackupQuery {
@@ -8260,12 +9862,11 @@ func (p *Parser) parseBackup() *ast.BackupQuery {
 		p.nextToken()
 		// Parse target - it's a function call like Null or Disk('path')
 		if p.currentIs(token.NULL) || p.currentIs(token.IDENT) {
-			name := p.current.Value
-			p.nextToken()
 			fn := &ast.FunctionCall{
-				Position: backup.Position,
-				Name:     name,
+				Position: p.current.Pos,
+				Name:     p.current.Value,
 			}
+			p.nextToken()
 			if p.currentIs(token.LPAREN) {
 				p.nextToken()
 				if !p.currentIs(token.RPAREN) {
@@ -8273,6 +9874,7 @@ func (p *Parser) parseBackup() *ast.BackupQuery {
 				}
 				p.expect(token.RPAREN)
 			}
+			p.finish(fn)
 			backup.Target = fn
 		}
 	}
@@ -8332,12 +9934,11 @@ func (p *Parser) parseRestore() *ast.RestoreQuery {
 		p.nextToken()
 		// Parse source - it's a function call like Null or Disk('path')
 		if p.currentIs(token.NULL) || p.currentIs(token.IDENT) {
-			name := p.current.Value
-			p.nextToken()
 			fn := &ast.FunctionCall{
-				Position: restore.Position,
-				Name:     name,
+				Position: p.current.Pos,
+				Name:     p.current.Value,
 			}
+			p.nextToken()
 			if p.currentIs(token.LPAREN) {
 				p.nextToken()
 				if !p.currentIs(token.RPAREN) {
@@ -8345,6 +9946,7 @@ func (p *Parser) parseRestore() *ast.RestoreQuery {
 				}
 				p.expect(token.RPAREN)
 			}
+			p.finish(fn)
 			restore.Source = fn
 		}
 	}
@@ -8472,31 +10074,62 @@ endModifiers:
 	return query
 }
 
-// parseTTLElement parses a single TTL element: expression [DELETE] [WHERE condition] [GROUP BY ...] [SET ...]
+// parseTTLElement parses a single TTL element: expression [DELETE | RECOMPRESS CODEC(...) | TO DISK [IF EXISTS] 'x' | TO VOLUME [IF EXISTS] 'y']
+// [WHERE condition] [GROUP BY keys [SET assignments]]
 func (p *Parser) parseTTLElement() *ast.TTLElement {
 	elem := &ast.TTLElement{
 		Position: p.current.Pos,
-		Expr:     p.parseExpression(ALIAS_PREC),
+		Action:   ast.TTLDelete,
+	}
+	elem.Expr = p.parseExpression(ALIAS_PREC)
+	switch {
+	case p.currentIs(token.DELETE):
+		p.nextToken()
+	case p.currentIsIdent("RECOMPRESS"):
+		elem.Action = ast.TTLRecompress
+		p.nextToken()
+		if p.currentIsIdent("CODEC") {
+			p.nextToken()
+			elem.Codec = p.parseCodecExpr()
+		}
+	case p.currentIs(token.TO) && (p.peekIsIdent("DISK") || p.peekIsIdent("VOLUME")):
+		p.nextToken() // skip TO
+		isDisk := p.currentIsIdent("DISK")
+		p.nextToken()
+		if p.currentIs(token.IF) && p.peekIs(token.EXISTS) {
+			elem.IfExists = true
+			p.nextToken()
+			p.nextToken()
+		}
+		if !p.currentIs(token.STRING) {
+			p.unexpected(token.STRING)
+			break
+		}
+		if isDisk {
+			elem.Action = ast.TTLMoveToDisk
+			elem.Disk = p.current.Value
+		} else {
+			elem.Action = ast.TTLMoveToVolume
+			elem.Volume = p.current.Value
+		}
+		p.nextToken()
 	}
-	// Skip RECOMPRESS CODEC(...), DELETE, TO DISK, TO VOLUME (but not WHERE)
-	p.skipTTLModifiersExceptWhere()
 	// Handle WHERE clause for this TTL element (conditional deletion)
 	if p.currentIs(token.WHERE) {
 		p.nextToken()
 		elem.Where = p.parseExpression(ALIAS_PREC)
 	}
-	// Handle GROUP BY x SET y = max(y) syntax (skip for now, already parsed in Where or just skip)
-	if p.currentIs(token.GROUP) {
-		p.nextToken()
-		if p.currentIs(token.BY) {
-			p.nextToken()
-			for {
-				p.parseExpression(ALIAS_PREC)
-				if p.currentIs(token.COMMA) {
-					p.nextToken()
-				} else {
-					break
-				}
+	// Handle GROUP BY x SET y = max(y) syntax
+	if p.currentIs(token.GROUP) && p.peekIs(token.BY) {
+		elem.Action = ast.TTLGroupBy
+		p.nextToken() // skip GROUP
+		p.nextToken() // skip BY
+		for {
+			elem.GroupBy = append(elem.GroupBy, p.parseExpression(ALIAS_PREC))
+			if p.currentIs(token.COMMA) {
+				p.nextToken()
+			} else {
+				break
 			}
 		}
 	}
@@ -8506,125 +10139,27 @@ func (p *Parser) parseTTLElement() *ast.TTLElement {
 	// - Comma starting new TTL: followed by expression (like d + toIntervalYear(...))
 	if p.currentIs(token.SET) {
 		p.nextToken()
-		for {
-			// Parse assignment expression: id = expr
-			p.parseExpression(ALIAS_PREC)
-			// Check for comma
-			if p.currentIs(token.COMMA) {
-				// Check if this is a SET continuation (COMMA IDENT EQ pattern)
-				// We can check using peek (what follows comma) and peekPeek (what follows that)
-				// without consuming any tokens
-				isSetContinuation := false
-				if p.peekIs(token.IDENT) || p.peek.Token.IsKeyword() {
-					if p.peekPeekIs(token.EQ) {
-						// It's another SET assignment (id = expr)
-						isSetContinuation = true
-					}
-				}
-				if isSetContinuation {
-					// Consume comma and continue parsing SET assignments
-					p.nextToken()
-					continue
-				}
-				// Not a SET assignment - break and let caller handle the comma
-				break
-			}
-			// No comma, end of SET clause
-			break
-		}
-	}
-	return elem
-}
-
-// skipTTLModifiersExceptWhere skips TTL modifiers but stops at WHERE
-func (p *Parser) skipTTLModifiersExceptWhere() {
-	for {
-		// Skip RECOMPRESS CODEC(...)
-		if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "RECOMPRESS" {
-			p.nextToken()
-			if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "CODEC" {
-				p.nextToken()
-				if p.currentIs(token.LPAREN) {
-					depth := 1
-					p.nextToken()
-					for depth > 0 && !p.currentIs(token.EOF) {
-						if p.currentIs(token.LPAREN) {
-							depth++
-						} else if p.currentIs(token.RPAREN) {
-							depth--
-						}
-						p.nextToken()
-					}
-				}
-			}
-			continue
-		}
-		// Skip DELETE (TTL ... DELETE)
-		if p.currentIs(token.DELETE) {
-			p.nextToken()
-			continue
-		}
-		// Skip TO DISK 'name' or TO VOLUME 'name'
-		if p.currentIs(token.TO) {
-			p.nextToken()
-			if p.currentIs(token.IDENT) {
-				upper := strings.ToUpper(p.current.Value)
-				if upper == "DISK" || upper == "VOLUME" {
-					p.nextToken()
-					if p.currentIs(token.STRING) {
-						p.nextToken()
-					}
-					continue
-				}
+		for p.currentIs(token.IDENT) || p.current.Token.IsKeyword() {
+			assign := &ast.Assignment{
+				Position: p.current.Pos,
+				Column:   p.current.Value,
 			}
-		}
-		break
-	}
-}
-
-// skipTTLModifiers skips TTL modifiers like RECOMPRESS CODEC(...), DELETE, TO DISK, TO VOLUME
-func (p *Parser) skipTTLModifiers() {
-	for {
-		// Skip RECOMPRESS CODEC(...)
-		if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "RECOMPRESS" {
-			p.nextToken() // skip RECOMPRESS
-			if p.currentIs(token.IDENT) && strings.ToUpper(p.current.Value) == "CODEC" {
-				p.nextToken() // skip CODEC
-				if p.currentIs(token.LPAREN) {
-					// Skip the entire CODEC(...) call
-					depth := 1
-					p.nextToken() // skip (
-					for depth > 0 && !p.currentIs(token.EOF) {
-						if p.currentIs(token.LPAREN) {
-							depth++
-						} else if p.currentIs(token.RPAREN) {
-							depth--
-						}
-						p.nextToken()
-					}
-				}
+			p.nextToken() // skip column name
+			if !p.expect(token.EQ) {
+				break
 			}
-			continue
-		}
-		// Skip DELETE (TTL ... DELETE)
-		if p.currentIs(token.DELETE) {
-			p.nextToken()
-			continue
-		}
-		// Skip TO DISK 'name' or TO VOLUME 'name'
-		if p.currentIs(token.TO) {
-			p.nextToken()
-			if p.currentIs(token.IDENT) {
-				upper := strings.ToUpper(p.current.Value)
-				if upper == "DISK" || upper == "VOLUME" {
-					p.nextToken()
-					if p.currentIs(token.STRING) {
-						p.nextToken()
-					}
-					continue
-				}
+			assign.Value = p.parseExpression(ALIAS_PREC)
+			p.finish(assign)
+			elem.Set = append(elem.Set, assign)
+			// Check if this is a SET continuation (COMMA IDENT EQ pattern)
+			// using peek and peekPeek, without consuming any tokens
+			if !p.currentIs(token.COMMA) || !(p.peekIs(token.IDENT) || p.peek.Token.IsKeyword()) || !p.peekPeekIs(token.EQ) {
+				// Not a SET assignment - let caller handle the comma
+				break
 			}
+			p.nextToken() // skip comma
 		}
-		break
 	}
+	p.finish(elem)
+	return elem
 }
@@ -3,15 +3,23 @@ package parser_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	gotoken "go/token"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/sqlc-dev/doubleclick/ast"
 	"github.com/sqlc-dev/doubleclick/parser"
+	"github.com/sqlc-dev/doubleclick/token"
 )
 
 // checkExplain runs skipped explain_todo tests to see which ones now pass.
@@ -338,6 +346,1346 @@ func TestParser(t *testing.T) {
 	}
 }
 
+// TestEndPositions checks that node spans cover exactly their source text
+func TestEndPositions(t *testing.T) {
+	query := "SELECT a + 1 AS x, count(*) FROM db.t WHERE b IN (1, 2) ORDER BY x DESC LIMIT 10"
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(stmts))
+	}
+
+	text := func(n ast.Node) string {
+		return query[n.Pos().Offset:n.End().Offset]
+	}
+
+	sel := stmts[0].(*ast.SelectWithUnionQuery).Selects[0].(*ast.SelectQuery)
+	tests := []struct {
+		node ast.Node
+		want string
+	}{
+		{stmts[0], query},
+		{sel, query},
+		{sel.Columns[0], "a + 1 AS x"},
+		{sel.Columns[1], "count(*)"},
+		{sel.From, "db.t"},
+		{sel.Where, "b IN (1, 2)"},
+		{sel.OrderBy[0], "x DESC"},
+		{sel.Limit, "10"},
+	}
+	for _, tt := range tests {
+		if got := text(tt.node); got != tt.want {
+			t.Errorf("%T: got %q, want %q", tt.node, got, tt.want)
+		}
+	}
+
+	// A parenthesized expression spans its parentheses, and a heredoc
+	// string its opening tag
+	query = "SELECT (a + 1) * 2, $tag$x$tag$"
+	stmts, err = parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	sel = stmts[0].(*ast.SelectWithUnionQuery).Selects[0].(*ast.SelectQuery)
+	tests = []struct {
+		node ast.Node
+		want string
+	}{
+		{sel.Columns[0], "(a + 1) * 2"},
+		{sel.Columns[0].(*ast.BinaryExpr).Left, "(a + 1)"},
+		{sel.Columns[1], "$tag$x$tag$"},
+	}
+	for _, tt := range tests {
+		if got := text(tt.node); got != tt.want {
+			t.Errorf("%T: got %q, want %q", tt.node, got, tt.want)
+		}
+	}
+
+	// Comma joins start at the table, tuple indexes after the dot, and a
+	// FORMAT after a parenthesized SELECT belongs to the union
+	query = "(SELECT t.1.2 FROM a, b) FORMAT JSON"
+	stmts, err = parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	union := stmts[0].(*ast.SelectWithUnionQuery)
+	sel = union.Selects[0].(*ast.SelectQuery)
+	access := sel.Columns[0].(*ast.TupleAccess)
+	tests = []struct {
+		node ast.Node
+		want string
+	}{
+		{access, "t.1.2"},
+		{access.Index, "2"},
+		{access.Tuple.(*ast.TupleAccess).Index, "1"},
+		{sel.From.Tables[1], "b"},
+		{union.Format, "JSON"},
+	}
+	for _, tt := range tests {
+		if got := text(tt.node); got != tt.want {
+			t.Errorf("%T: got %q, want %q", tt.node, got, tt.want)
+		}
+	}
+}
+
+// TestInspect checks that ast.Inspect reaches nested nodes and honors pruning
+func TestInspect(t *testing.T) {
+	query := "SELECT sum(a) OVER (PARTITION BY b), c FROM (SELECT max(e) AS c FROM u) WHERE d > 1"
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	var funcs, idents []string
+	ast.Inspect(stmts[0], func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FunctionCall:
+			funcs = append(funcs, n.Name)
+		case *ast.Identifier:
+			idents = append(idents, n.Name())
+		case *ast.Subquery:
+			return false
+		}
+		return true
+	})
+
+	if got, want := strings.Join(funcs, ","), "sum"; got != want {
+		t.Errorf("functions: got %q, want %q", got, want)
+	}
+	if got, want := strings.Join(idents, ","), "a,b,c,d"; got != want {
+		t.Errorf("identifiers: got %q, want %q", got, want)
+	}
+
+	// Fields repeating other children, such as the expressions of a TTL
+	// clause, are skipped so that every node is visited once
+	for _, query := range []string{
+		"ALTER TABLE t MODIFY TTL d + INTERVAL 1 DAY, d + INTERVAL 2 DAY DELETE WHERE x = 1",
+		"ALTER TABLE t ADD INDEX i a + b TYPE minmax",
+		"SELECT * REPLACE (a + 1 AS a), COLUMNS('b') REPLACE (b + 1 AS b) FROM t",
+	} {
+		stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+		if err != nil {
+			t.Fatalf("Parse error for %q: %v", query, err)
+		}
+		seen := make(map[ast.Node]bool)
+		ast.Inspect(stmts[0], func(n ast.Node) bool {
+			if n != nil && seen[n] {
+				t.Errorf("%q: %T visited twice", query, n)
+			}
+			seen[n] = true
+			return true
+		})
+	}
+}
+
+// TestApply checks that ast.Apply can replace, wrap, delete and fill in nodes
+func TestApply(t *testing.T) {
+	query := "SELECT a, b FROM t SETTINGS max_threads = 1, optimize_read_in_order = 0"
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	ast.Apply(stmts[0], func(c *ast.Cursor) bool {
+		switch n := c.Node().(type) {
+		case *ast.TableIdentifier:
+			c.Replace(&ast.TableIdentifier{Database: "db", Table: n.Table})
+		case *ast.Identifier:
+			if c.Name() == "Columns" && n.Name() == "b" {
+				c.Replace(&ast.FunctionCall{Name: "toString", Arguments: []ast.Expression{n}})
+				return false
+			}
+		case *ast.SettingExpr:
+			if n.Name == "max_threads" {
+				c.Delete()
+			}
+		case nil:
+			if c.Name() == "Where" {
+				c.Replace(&ast.Identifier{Parts: []string{"c"}})
+			}
+		}
+		return true
+	}, nil)
+
+	sel := stmts[0].(*ast.SelectWithUnionQuery).Selects[0].(*ast.SelectQuery)
+	if fn, ok := sel.Columns[1].(*ast.FunctionCall); !ok || fn.Name != "toString" {
+		t.Errorf("second column: got %#v, want toString call", sel.Columns[1])
+	}
+	if table := sel.From.Tables[0].Table.Table.(*ast.TableIdentifier); table.Database != "db" {
+		t.Errorf("table database: got %q, want %q", table.Database, "db")
+	}
+	if len(sel.Settings) != 1 || sel.Settings[0].Name != "optimize_read_in_order" {
+		t.Errorf("settings: got %d, want only optimize_read_in_order", len(sel.Settings))
+	}
+	if ident, ok := sel.Where.(*ast.Identifier); !ok || ident.Name() != "c" {
+		t.Errorf("where: got %#v, want identifier c", sel.Where)
+	}
+
+	// Fields repeating other children follow the replaced nodes
+	stmts, err = parser.Parse(context.Background(), strings.NewReader(
+		"ALTER TABLE t MODIFY TTL d + INTERVAL 1 DAY, d + INTERVAL 2 DAY; SELECT * REPLACE (a + 1 AS a) FROM t"))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	for _, stmt := range stmts {
+		ast.Apply(stmt, func(c *ast.Cursor) bool {
+			switch n := c.Node().(type) {
+			case *ast.TTLElement:
+				c.Replace(&ast.TTLElement{Expr: &ast.Identifier{Parts: []string{"e"}}})
+				return false
+			case *ast.ReplaceExpr:
+				c.Replace(&ast.ReplaceExpr{Expr: &ast.Literal{Type: ast.LiteralInteger, Value: int64(2)}, Name: n.Name})
+				return false
+			}
+			return true
+		}, nil)
+	}
+	ttl := stmts[0].(*ast.AlterQuery).Commands[0].TTL
+	if ttl.Expression != ttl.Elements[0].Expr || len(ttl.Expressions) != 1 || ttl.Expressions[0] != ttl.Elements[1].Expr {
+		t.Errorf("TTL expressions do not match the replaced elements: %+v", ttl)
+	}
+	asterisk := stmts[1].(*ast.SelectWithUnionQuery).Selects[0].(*ast.SelectQuery).Columns[0].(*ast.Asterisk)
+	if len(asterisk.Replace) != 1 || asterisk.Replace[0] != asterisk.Transformers[0].Replaces[0] {
+		t.Errorf("REPLACE list does not match the replaced transformer: %+v", asterisk)
+	}
+}
+
+// TestCloneEqual checks that ast.Clone makes independent copies and that
+// ast.Equal ignores positions and purely syntactic flags
+func TestCloneEqual(t *testing.T) {
+	parse := func(query string) ast.Statement {
+		t.Helper()
+		stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+		if err != nil {
+			t.Fatalf("Parse error: %v", err)
+		}
+		return stmts[0]
+	}
+
+	orig := parse("SELECT [1, 2], (a + b) FROM t")
+	clone := ast.Clone(orig)
+	if !ast.Equal(orig, clone) {
+		t.Fatalf("clone is not equal to the original")
+	}
+
+	// Modifying the clone's array literal must not affect the original
+	sel := clone.(*ast.SelectWithUnionQuery).Selects[0].(*ast.SelectQuery)
+	arr := sel.Columns[0].(*ast.Literal).Value.([]ast.Expression)
+	arr[0].(*ast.Literal).Value = int64(42)
+	if ast.Equal(orig, clone) {
+		t.Errorf("modified clone is still equal to the original")
+	}
+
+	if !ast.Equal(orig, parse("SELECT  [1,2],  a + b  FROM t")) {
+		t.Errorf("queries differing only in spacing and parentheses are not equal")
+	}
+	if ast.Equal(orig, parse("SELECT [1, 2], a - b FROM t")) {
+		t.Errorf("queries with different operators are equal")
+	}
+
+	// Empty and missing parameter lists differ, and Clone keeps them apart
+	params := parse("SELECT medianGK()(x)")
+	if ast.Equal(params, parse("SELECT medianGK(x)")) {
+		t.Errorf("medianGK()(x) and medianGK(x) are equal")
+	}
+	if !ast.Equal(params, ast.Clone(params)) {
+		t.Errorf("clone of medianGK()(x) is not equal to the original")
+	}
+}
+
+// TestJSONRoundTrip checks that statements decode back from their JSON encoding
+func TestJSONRoundTrip(t *testing.T) {
+	queries := []string{
+		"SELECT a, [1, 2], (1, 'x'), nan, -inf, 18446744073709551615 FROM t WHERE b IN (SELECT c FROM u)",
+		"SELECT '\\xFF', medianGK()(x), CAST(y AS String) FROM t",
+		"INSERT INTO t VALUES (1, [], 'a'), (2, [3], 'b')",
+		"DROP DICTIONARY db.dict",
+		"ALTER TABLE t DROP PART 'all_1_1_0'",
+	}
+	for _, query := range queries {
+		stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+		if err != nil {
+			t.Fatalf("Parse error for %q: %v", query, err)
+		}
+		data, err := json.Marshal(stmts[0])
+		if err != nil {
+			t.Fatalf("Marshal error for %q: %v", query, err)
+		}
+		got, err := ast.UnmarshalStatement(data)
+		if err != nil {
+			t.Fatalf("Unmarshal error for %q: %v\n%s", query, err, data)
+		}
+		if !ast.Equal(stmts[0], got) {
+			t.Errorf("round trip changed %q:\n%s", query, data)
+		}
+		if want, have := parser.Explain(stmts[0]), parser.Explain(got); want != have {
+			t.Errorf("round trip changed EXPLAIN for %q:\nwant:\n%s\ngot:\n%s", query, want, have)
+		}
+	}
+}
+
+// TestComments checks that comments are kept and attached to the nearest node
+func TestComments(t *testing.T) {
+	query := `-- owner: team-a
+CREATE TABLE t (
+    id UInt64, -- primary id
+    /* display name */
+    name String
+) ENGINE = MergeTree ORDER BY id;`
+
+	p := parser.New(strings.NewReader(query))
+	stmts, err := p.ParseStatements(context.Background())
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	comments := p.Comments()
+	if len(comments) != 3 {
+		t.Fatalf("expected 3 comments, got %d", len(comments))
+	}
+	for _, c := range comments {
+		if got := query[c.Pos().Offset:c.End().Offset]; got != c.Text {
+			t.Errorf("comment span %q does not match text %q", got, c.Text)
+		}
+	}
+
+	cmap := ast.NewCommentMap(stmts, comments)
+	create := stmts[0].(*ast.CreateTableQuery)
+	tests := []struct {
+		comments []*ast.Comment
+		want     string
+	}{
+		{cmap[create].Leading, "-- owner: team-a"},
+		{cmap[create.Columns[0]].Trailing, "-- primary id"},
+		{cmap[create.Columns[1]].Leading, "/* display name */"},
+	}
+	for _, tt := range tests {
+		if len(tt.comments) != 1 || tt.comments[0].Text != tt.want {
+			t.Errorf("expected comment %q, got %v", tt.want, tt.comments)
+		}
+	}
+}
+
+// TestText checks that the original text of each statement and node can be recovered
+func TestText(t *testing.T) {
+	query := `SELECT a + 1 FROM t; -- first
+INSERT INTO t (a) VALUES (1);
+  CREATE TABLE u (x UInt8 /* id */) ENGINE = Memory`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	want := []string{
+		"SELECT a + 1 FROM t",
+		"INSERT INTO t (a) VALUES (1)",
+		"CREATE TABLE u (x UInt8 /* id */) ENGINE = Memory",
+	}
+	if len(stmts) != len(want) {
+		t.Fatalf("expected %d statements, got %d", len(want), len(stmts))
+	}
+	for i, stmt := range stmts {
+		if got := ast.Text(query, stmt); got != want[i] {
+			t.Errorf("statement %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+
+	sel := stmts[0].(*ast.SelectWithUnionQuery).Selects[0].(*ast.SelectQuery)
+	if got := ast.Text(query, sel.Columns[0]); got != "a + 1" {
+		t.Errorf("expected %q, got %q", "a + 1", got)
+	}
+}
+
+// TestParseErrors checks that syntax errors are reported as structured errors
+func TestParseErrors(t *testing.T) {
+	query := "SELECT 1;\nSELECT a FROM t ORDR BY a"
+
+	_, err := parser.Parse(context.Background(), strings.NewReader(query))
+	var list parser.ErrorList
+	if !errors.As(err, &list) {
+		t.Fatalf("expected an ErrorList, got %T: %v", err, err)
+	}
+	var perr *parser.ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a ParseError, got %T: %v", err, err)
+	}
+
+	if perr.Pos.Line != 2 || perr.Pos.Column != 22 || perr.Token != token.BY || perr.Statement != 1 {
+		t.Errorf("unexpected error: %+v", perr)
+	}
+	if perr.Misspelled != "ORDR" || len(perr.Suggestions) != 1 || perr.Suggestions[0] != "ORDER" {
+		t.Errorf("expected suggestion ORDER for ORDR, got %q for %q", perr.Suggestions, perr.Misspelled)
+	}
+
+	want := `unexpected token BY at line 2, column 22
+  2 | SELECT a FROM t ORDR BY a
+    |                      ^^
+did you mean ORDER instead of ORDR?`
+	if got := perr.Snippet(query); got != want {
+		t.Errorf("Snippet:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	// A misspelled keyword far from every expected token is matched
+	// against all keywords
+	_, err = parser.Parse(context.Background(), strings.NewReader("SELECT a FROM t FINAL SETINGS max_threads = 1"))
+	if !errors.As(err, &perr) || perr.Misspelled != "SETINGS" || !slices.Equal(perr.Suggestions, []string{"SETTINGS"}) {
+		t.Errorf("expected suggestion SETTINGS for SETINGS, got %v", err)
+	}
+}
+
+// TestRecoverErrors checks that parsing continues after a bad statement
+func TestRecoverErrors(t *testing.T) {
+	query := "SELECT 1; CREAT TABLE t (a Int8); SELECT a FROM t ORDR BY a; SELECT 2"
+
+	p := parser.NewWithMode(strings.NewReader(query), parser.RecoverErrors)
+	stmts, err := p.ParseStatements(context.Background())
+	var list parser.ErrorList
+	if !errors.As(err, &list) || len(list) != 2 {
+		t.Fatalf("expected 2 parse errors, got %v", err)
+	}
+	if list[0].Statement != 1 || list[1].Statement != 2 {
+		t.Errorf("expected errors in statements 1 and 2, got %d and %d", list[0].Statement, list[1].Statement)
+	}
+
+	want := []string{
+		"SELECT 1",
+		"CREAT TABLE t (a Int8)",
+		"SELECT a FROM t ORDR BY a",
+		"SELECT 2",
+	}
+	if len(stmts) != len(want) {
+		t.Fatalf("expected %d statements, got %d", len(want), len(stmts))
+	}
+	for i, stmt := range stmts {
+		if got := ast.Text(query, stmt); got != want[i] {
+			t.Errorf("statement %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+
+	for i, partial := range []bool{false, true} {
+		bad, ok := stmts[i+1].(*ast.BadStatement)
+		if !ok {
+			t.Fatalf("statement %d: expected *ast.BadStatement, got %T", i+1, stmts[i+1])
+		}
+		if bad.Error != list[i].Error() {
+			t.Errorf("statement %d: expected error %q, got %q", i+1, list[i].Error(), bad.Error)
+		}
+		if (bad.Partial != nil) != partial {
+			t.Errorf("statement %d: unexpected partial statement %v", i+1, bad.Partial)
+		}
+	}
+}
+
+// TestParseFragments checks the entry points for parsing parts of statements
+func TestParseFragments(t *testing.T) {
+	expr, err := parser.ParseExpression("(a + 1) * 2")
+	if err != nil {
+		t.Fatalf("ParseExpression: %v", err)
+	}
+	if bin, ok := expr.(*ast.BinaryExpr); !ok || bin.Op != "*" {
+		t.Errorf("ParseExpression: unexpected expression %#v", expr)
+	}
+
+	dt, err := parser.ParseDataType("Map(String, Array(Nullable(Decimal(18, 4))))")
+	if err != nil {
+		t.Fatalf("ParseDataType: %v", err)
+	}
+	if dt.Name != "Map" || len(dt.Parameters) != 2 {
+		t.Errorf("ParseDataType: unexpected type %#v", dt)
+	}
+
+	settings, err := parser.ParseSettings("max_threads = 8, readonly = 1")
+	if err != nil {
+		t.Fatalf("ParseSettings: %v", err)
+	}
+	if len(settings) != 2 || settings[0].Name != "max_threads" || settings[1].Name != "readonly" {
+		t.Errorf("ParseSettings: unexpected settings %v", settings)
+	}
+
+	exprs, hasModifiers, err := parser.ParseOrderBy("id, created_at DESC")
+	if err != nil {
+		t.Fatalf("ParseOrderBy: %v", err)
+	}
+	if len(exprs) != 2 || !hasModifiers {
+		t.Errorf("ParseOrderBy: got %d expressions, modifiers %v", len(exprs), hasModifiers)
+	}
+
+	for _, input := range []string{"", "1 2", "a; SELECT 1"} {
+		if _, err := parser.ParseExpression(input); err == nil {
+			t.Errorf("ParseExpression(%q): expected an error", input)
+		}
+	}
+}
+
+// TestNext checks that statements can be read one at a time
+func TestNext(t *testing.T) {
+	query := "SELECT 1; SELET 2; SELECT 3;; INSERT INTO t VALUES (1)"
+
+	p := parser.New(strings.NewReader(query))
+	var got []string
+	var errs int
+	for {
+		stmt, err := p.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		var list parser.ErrorList
+		if errors.As(err, &list) {
+			errs++
+			if list[0].Statement != 1 {
+				t.Errorf("expected error in statement 1, got %d", list[0].Statement)
+			}
+		} else if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if stmt != nil {
+			got = append(got, ast.Text(query, stmt))
+		}
+	}
+	want := []string{"SELECT 1", "SELECT 3", "INSERT INTO t VALUES (1)"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("expected statements %q, got %q", want, got)
+	}
+	if errs == 0 {
+		t.Errorf("expected an error for the misspelled statement")
+	}
+
+	// Stop early after the first INSERT
+	p = parser.NewWithMode(strings.NewReader(query), parser.RecoverErrors)
+	var n int
+	for stmt, err := range p.All(context.Background()) {
+		n++
+		if _, ok := stmt.(*ast.BadStatement); ok != (err != nil) {
+			t.Errorf("statement %d: got %T with error %v", n, stmt, err)
+		}
+		if _, ok := stmt.(*ast.InsertQuery); ok {
+			break
+		}
+	}
+	if n != 4 {
+		t.Errorf("expected 4 statements, got %d", n)
+	}
+
+	// Comments are not collected in SkipComments mode
+	p = parser.NewWithMode(strings.NewReader("-- a\nSELECT 1; /* b */ SELECT 2"), parser.SkipComments)
+	n = 0
+	for _, err := range p.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		n++
+		if comments := p.Comments(); comments != nil {
+			t.Errorf("statement %d: expected no comments, got %v", n, comments)
+		}
+	}
+	if n != 2 {
+		t.Errorf("expected 2 statements, got %d", n)
+	}
+}
+
+// TestInsertFormatData checks that inline data after INSERT ... FORMAT is kept as written
+func TestInsertFormatData(t *testing.T) {
+	data := "1,\"it's\"\n2,/* not a comment\n-- 3; SELECT 4\n"
+	query := "/* head */ INSERT INTO t FORMAT CSV\n" + data
+
+	p := parser.New(strings.NewReader(query))
+	stmts, err := p.ParseStatements(context.Background())
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(stmts))
+	}
+	ins := stmts[0].(*ast.InsertQuery)
+	if ins.Format.Name() != "CSV" {
+		t.Errorf("expected format CSV, got %q", ins.Format.Name())
+	}
+	if ins.Data != data {
+		t.Errorf("expected data %q, got %q", data, ins.Data)
+	}
+	if got := query[ins.DataPosition.Offset:ins.DataEndPosition.Offset]; got != data {
+		t.Errorf("data range covers %q", got)
+	}
+	if ins.DataPosition.Line != 2 || ins.DataPosition.Column != 1 {
+		t.Errorf("expected data to start at line 2, column 1, got %+v", ins.DataPosition)
+	}
+	if got := ast.Text(query, ins); got != strings.TrimPrefix(query, "/* head */ ") {
+		t.Errorf("statement text %q", got)
+	}
+	if comments := p.Comments(); len(comments) != 1 || comments[0].Text != "/* head */" {
+		t.Errorf("expected only the head comment, got %v", comments)
+	}
+
+	// Raw input is only kept from a FORMAT clause on, and bytes are kept as written
+	query = "SELECT 1 FORMAT JSON; INSERT INTO t FORMAT TSV 1\t\xff\n"
+	stmts, err = parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if ins := stmts[1].(*ast.InsertQuery); ins.Data != "1\t\xff\n" {
+		t.Errorf("expected data %q, got %q", "1\t\xff\n", ins.Data)
+	}
+}
+
+func TestValuesRows(t *testing.T) {
+	query := "INSERT INTO t VALUES (-1, 'a', NULL, true), (2.5, lower('B'));"
+
+	p := parser.New(strings.NewReader(query))
+	var literals [][]any
+	var exprs [][]ast.Expression
+	p.OnValuesRow(func(row *parser.ValuesRow) error {
+		literals = append(literals, slices.Clone(row.Literals))
+		exprs = append(exprs, row.Exprs)
+		return nil
+	})
+	stmts, err := p.ParseStatements(context.Background())
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if ins := stmts[0].(*ast.InsertQuery); ins.Values != nil {
+		t.Errorf("expected no collected rows, got %d", len(ins.Values))
+	}
+	if len(literals) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(literals))
+	}
+	if want := []any{int64(-1), "a", nil, true}; !slices.Equal(literals[0], want) {
+		t.Errorf("expected literals %v, got %v", want, literals[0])
+	}
+	if exprs[0] != nil {
+		t.Errorf("expected no expressions for a literal row, got %v", exprs[0])
+	}
+	if len(literals[1]) != 0 || len(exprs[1]) != 2 {
+		t.Fatalf("expected 2 expressions for the second row, got %v and %v", literals[1], exprs[1])
+	}
+	if _, ok := exprs[1][1].(*ast.FunctionCall); !ok {
+		t.Errorf("expected a function call, got %T", exprs[1][1])
+	}
+
+	stop := errors.New("stop")
+	p = parser.New(strings.NewReader(query + " SELECT 1"))
+	p.OnValuesRow(func(row *parser.ValuesRow) error { return stop })
+	if _, err := p.Next(context.Background()); err != stop {
+		t.Errorf("expected the callback error, got %v", err)
+	}
+	if stmt, err := p.Next(context.Background()); err != nil {
+		t.Errorf("expected the next statement to parse, got %v", err)
+	} else if _, ok := stmt.(*ast.SelectWithUnionQuery); !ok {
+		t.Errorf("expected a SELECT, got %T", stmt)
+	}
+}
+
+func TestGrant(t *testing.T) {
+	query := `GRANT ON CLUSTER c SELECT(a, b), ALTER UPDATE ON db.*, INSERT ON *.* TO u1, 'u2'@'%', CURRENT_USER WITH GRANT OPTION;
+GRANT r1, r2 TO ALL EXCEPT u3 WITH ADMIN OPTION WITH REPLACE OPTION;
+GRANT CURRENT GRANTS(SELECT ON db.t) TO u1;
+REVOKE ADMIN OPTION FOR r1 FROM u1`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 4 {
+		t.Fatalf("expected 4 statements, got %d", len(stmts))
+	}
+
+	g := stmts[0].(*ast.GrantQuery)
+	if g.OnCluster != "c" || !g.GrantOption || len(g.Elements) != 2 {
+		t.Fatalf("unexpected GRANT: %+v", g)
+	}
+	privs := g.Elements[0].Privileges
+	if len(privs) != 2 || privs[0].Name != "SELECT" || !slices.Equal(privs[0].Columns, []string{"a", "b"}) || privs[1].Name != "ALTER UPDATE" {
+		t.Errorf("unexpected privileges %+v", g.Elements[0])
+	}
+	if e := g.Elements[0]; e.Database != "db" || e.Table != "*" {
+		t.Errorf("expected ON db.*, got %q.%q", e.Database, e.Table)
+	}
+	if e := g.Elements[1]; e.Database != "*" || e.Table != "*" || e.Privileges[0].Name != "INSERT" {
+		t.Errorf("expected INSERT ON *.*, got %+v", e)
+	}
+	if !slices.Equal(g.Grantees.Names, []string{"u1", "u2@%"}) || !g.Grantees.CurrentUser {
+		t.Errorf("unexpected grantees %+v", g.Grantees)
+	}
+
+	g = stmts[1].(*ast.GrantQuery)
+	if !slices.Equal(g.Roles, []string{"r1", "r2"}) || g.Elements != nil {
+		t.Errorf("expected roles r1, r2, got %+v", g)
+	}
+	if !g.Grantees.All || !slices.Equal(g.Grantees.Except, []string{"u3"}) || !g.AdminOption || !g.ReplaceOption {
+		t.Errorf("unexpected role grant %+v, grantees %+v", g, g.Grantees)
+	}
+
+	g = stmts[2].(*ast.GrantQuery)
+	if !g.CurrentGrants || len(g.Elements) != 1 || g.Elements[0].Table != "t" {
+		t.Errorf("unexpected GRANT CURRENT GRANTS %+v", g)
+	}
+
+	g = stmts[3].(*ast.GrantQuery)
+	if !g.IsRevoke || !g.AdminOption || !slices.Equal(g.Roles, []string{"r1"}) {
+		t.Errorf("unexpected REVOKE %+v", g)
+	}
+}
+
+func TestRoles(t *testing.T) {
+	query := `CREATE OR REPLACE ROLE r1, r2@'%' ON CLUSTER c IN local_directory SETTINGS PROFILE 'default', max_threads = 4 MIN 1 MAX 8 CONST;
+ALTER ROLE IF EXISTS r1 RENAME TO r3 SETTINGS NONE;
+DROP ROLE IF EXISTS r1, r2 ON CLUSTER c FROM local_directory;
+SET ROLE ALL EXCEPT r1;
+SET ROLE DEFAULT;
+SET DEFAULT ROLE NONE TO u1, CURRENT_USER;
+SET role = 1;
+CREATE ROLE OR REPLACE r4`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 8 {
+		t.Fatalf("expected 8 statements, got %d", len(stmts))
+	}
+
+	create := stmts[0].(*ast.CreateRoleQuery)
+	if !create.OrReplace || !slices.Equal(create.Names, []string{"r1", "r2@%"}) || create.OnCluster != "c" || create.Storage != "local_directory" {
+		t.Errorf("unexpected CREATE ROLE %+v", create)
+	}
+	if len(create.Settings) != 2 || create.Settings[0].Profile != "default" {
+		t.Fatalf("unexpected settings %+v", create.Settings)
+	}
+	if s := create.Settings[1]; s.Name != "max_threads" || s.Value == nil || s.Min == nil || s.Max == nil || s.Writability != ast.SettingConst {
+		t.Errorf("unexpected setting %+v", s)
+	}
+	if got := parser.Explain(create); got != "CreateRoleQuery\n" {
+		t.Errorf("unexpected EXPLAIN output %q", got)
+	}
+
+	alter := stmts[1].(*ast.CreateRoleQuery)
+	if !alter.IsAlter || !alter.IfExists || alter.NewName != "r3" || !alter.SettingsNone {
+		t.Errorf("unexpected ALTER ROLE %+v", alter)
+	}
+
+	drop := stmts[2].(*ast.DropRoleQuery)
+	if !drop.IfExists || len(drop.Names) != 2 || drop.OnCluster != "c" || drop.Storage != "local_directory" {
+		t.Errorf("unexpected DROP ROLE %+v", drop)
+	}
+
+	set := stmts[3].(*ast.SetRoleQuery)
+	if set.Kind != ast.SetRole || !set.Roles.All || !slices.Equal(set.Roles.Except, []string{"r1"}) {
+		t.Errorf("unexpected SET ROLE %+v", set)
+	}
+	if got := parser.Explain(set); got != "SetRoleQuery\n" {
+		t.Errorf("unexpected EXPLAIN output %q", got)
+	}
+	if set := stmts[4].(*ast.SetRoleQuery); set.Kind != ast.SetRoleDefault || set.Roles != nil {
+		t.Errorf("unexpected SET ROLE DEFAULT %+v", set)
+	}
+	set = stmts[5].(*ast.SetRoleQuery)
+	if set.Kind != ast.SetDefaultRole || set.Roles == nil || set.Roles.Names != nil || !slices.Equal(set.Users.Names, []string{"u1"}) || !set.Users.CurrentUser {
+		t.Errorf("unexpected SET DEFAULT ROLE %+v", set)
+	}
+	if _, ok := stmts[6].(*ast.SetQuery); !ok {
+		t.Errorf("expected a setting named role to be a SET query, got %T", stmts[6])
+	}
+	if create := stmts[7].(*ast.CreateRoleQuery); !create.OrReplace || !slices.Equal(create.Names, []string{"r4"}) {
+		t.Errorf("unexpected CREATE ROLE OR REPLACE %+v", create)
+	}
+}
+
+func TestRowPolicies(t *testing.T) {
+	query := `CREATE ROW POLICY p1, p2 ON CLUSTER c ON db.t1, db.* FOR SELECT USING tenant = currentUser() AS RESTRICTIVE TO ALL EXCEPT admin;
+ALTER POLICY p1 ON db.t1 RENAME TO p3 USING NONE TO NONE;
+DROP ROW POLICY IF EXISTS p1 ON t1, p2 ON t2 ON CLUSTER c;
+DROP ROW POLICY p1 ON t1, t2 ON CLUSTER c;
+DROP ROW POLICY p1 ON t1, p2 ON CLUSTER c ON t2`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 5 {
+		t.Fatalf("expected 5 statements, got %d", len(stmts))
+	}
+
+	create := stmts[0].(*ast.CreateRowPolicyQuery)
+	if len(create.Policies) != 1 || !slices.Equal(create.Policies[0].Names, []string{"p1", "p2"}) || create.OnCluster != "c" {
+		t.Fatalf("unexpected policies %+v", create)
+	}
+	if tables := create.Policies[0].Tables; len(tables) != 2 || tables[0].Database != "db" || tables[0].Table != "t1" || tables[1].Table != "*" {
+		t.Errorf("unexpected tables %+v", tables)
+	}
+	if !create.ForSelect || create.Kind != ast.RowPolicyRestrictive {
+		t.Errorf("unexpected CREATE ROW POLICY %+v", create)
+	}
+	if using, ok := create.Using.(*ast.BinaryExpr); !ok || using.Op != "=" {
+		t.Errorf("expected USING tenant = currentUser(), got %#v", create.Using)
+	}
+	if !create.Roles.All || !slices.Equal(create.Roles.Except, []string{"admin"}) {
+		t.Errorf("unexpected roles %+v", create.Roles)
+	}
+
+	alter := stmts[1].(*ast.CreateRowPolicyQuery)
+	if !alter.IsAlter || alter.NewName != "p3" || !alter.UsingNone || alter.Using != nil || alter.Roles == nil || alter.Roles.Names != nil {
+		t.Errorf("unexpected ALTER ROW POLICY %+v", alter)
+	}
+
+	drop := stmts[2].(*ast.DropRowPolicyQuery)
+	if !drop.IfExists || len(drop.Policies) != 2 || drop.Policies[1].Names[0] != "p2" || drop.Policies[1].Tables[0].Table != "t2" || drop.OnCluster != "c" {
+		t.Errorf("unexpected DROP ROW POLICY %+v", drop)
+	}
+
+	// ON CLUSTER after a table does not start another policy
+	drop = stmts[3].(*ast.DropRowPolicyQuery)
+	if len(drop.Policies) != 1 || len(drop.Policies[0].Tables) != 2 || drop.Policies[0].Tables[1].Table != "t2" || drop.OnCluster != "c" {
+		t.Errorf("unexpected DROP ROW POLICY %+v", drop)
+	}
+	drop = stmts[4].(*ast.DropRowPolicyQuery)
+	if len(drop.Policies) != 2 || drop.Policies[1].Names[0] != "p2" || drop.Policies[1].Tables[0].Table != "t2" || drop.OnCluster != "c" {
+		t.Errorf("unexpected DROP ROW POLICY %+v", drop)
+	}
+}
+
+func TestQuotas(t *testing.T) {
+	query := `CREATE QUOTA IF NOT EXISTS q1 ON CLUSTER c KEYED BY client_key, user_name FOR RANDOMIZED INTERVAL 1 hour MAX queries = 100, errors = 10, FOR 1 DAY RESULT ROWS MAX '12K' TO ALL EXCEPT admin;
+ALTER QUOTA q1, q2 RENAME TO q3 NOT KEYED FOR INTERVAL 1 WEEK TRACKING ONLY TO NONE;
+CREATE QUOTA OR REPLACE q4 FOR INTERVAL 1 MINUTE NO LIMITS`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 3 {
+		t.Fatalf("expected 3 statements, got %d", len(stmts))
+	}
+
+	create := stmts[0].(*ast.CreateQuotaQuery)
+	if !create.IfNotExists || !slices.Equal(create.Names, []string{"q1"}) || create.OnCluster != "c" ||
+		!slices.Equal(create.KeyedBy, []string{"client_key", "user_name"}) {
+		t.Fatalf("unexpected CREATE QUOTA %+v", create)
+	}
+	if len(create.Intervals) != 2 {
+		t.Fatalf("expected 2 intervals, got %d", len(create.Intervals))
+	}
+	hourly := create.Intervals[0]
+	if !hourly.Randomized || hourly.Unit != "HOUR" || hourly.Duration.(*ast.Literal).Value != int64(1) {
+		t.Errorf("unexpected interval %+v", hourly)
+	}
+	if len(hourly.Limits) != 2 || hourly.Limits[0].Resource != "queries" || hourly.Limits[1].Resource != "errors" ||
+		hourly.Limits[1].Value.(*ast.Literal).Value != int64(10) {
+		t.Errorf("unexpected limits %+v", hourly.Limits)
+	}
+	daily := create.Intervals[1]
+	if daily.Unit != "DAY" || len(daily.Limits) != 1 || daily.Limits[0].Resource != "result_rows" ||
+		daily.Limits[0].Value.(*ast.Literal).Value != "12K" {
+		t.Errorf("unexpected interval %+v", daily)
+	}
+	if !create.Roles.All || !slices.Equal(create.Roles.Except, []string{"admin"}) {
+		t.Errorf("unexpected roles %+v", create.Roles)
+	}
+	if got := parser.Explain(create); got != "CreateQuotaQuery\n" {
+		t.Errorf("unexpected EXPLAIN %q", got)
+	}
+
+	alter := stmts[1].(*ast.CreateQuotaQuery)
+	if !alter.IsAlter || len(alter.Names) != 2 || alter.NewName != "q3" || !alter.NotKeyed ||
+		len(alter.Intervals) != 1 || !alter.Intervals[0].TrackingOnly || alter.Roles == nil || alter.Roles.Names != nil {
+		t.Errorf("unexpected ALTER QUOTA %+v", alter)
+	}
+	if got := parser.Explain(alter); got != "CreateQuotaQuery\n" {
+		t.Errorf("unexpected EXPLAIN %q", got)
+	}
+
+	replace := stmts[2].(*ast.CreateQuotaQuery)
+	if !replace.OrReplace || !slices.Equal(replace.Names, []string{"q4"}) || len(replace.Intervals) != 1 {
+		t.Errorf("unexpected CREATE QUOTA OR REPLACE %+v", replace)
+	}
+}
+
+func TestSettingsProfiles(t *testing.T) {
+	query := `CREATE SETTINGS PROFILE IF NOT EXISTS p1 ON CLUSTER c SETTINGS INHERIT 'default', max_memory_usage = 5000000 MIN 4000000 MAX = 6000000 WRITABLE, custom_a.b = 1 TO r1, u1;
+ALTER PROFILE p1 RENAME TO p2 ADD SETTINGS readonly = 1 CONST DROP SETTINGS max_threads, custom_a.b DROP PROFILES 'web' ADD PROFILES 'readonly' DROP ALL PROFILES TO ALL`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(stmts))
+	}
+
+	create := stmts[0].(*ast.CreateSettingsProfileQuery)
+	if !create.IfNotExists || !slices.Equal(create.Names, []string{"p1"}) || create.OnCluster != "c" || len(create.Settings) != 3 {
+		t.Fatalf("unexpected CREATE SETTINGS PROFILE %+v", create)
+	}
+	if create.Settings[0].Profile != "default" {
+		t.Errorf("expected INHERIT 'default', got %+v", create.Settings[0])
+	}
+	mem := create.Settings[1]
+	if mem.Name != "max_memory_usage" || mem.Min.(*ast.Literal).Value != int64(4000000) ||
+		mem.Max.(*ast.Literal).Value != int64(6000000) || mem.Writability != ast.SettingWritable {
+		t.Errorf("unexpected setting %+v", mem)
+	}
+	if create.Settings[2].Name != "custom_a.b" {
+		t.Errorf("expected custom_a.b, got %q", create.Settings[2].Name)
+	}
+	if !slices.Equal(create.Roles.Names, []string{"r1", "u1"}) {
+		t.Errorf("unexpected roles %+v", create.Roles)
+	}
+
+	alter := stmts[1].(*ast.AlterSettingsProfileQuery)
+	if alter.NewName != "p2" || len(alter.AddSettings) != 2 || alter.AddSettings[0].Name != "readonly" ||
+		alter.AddSettings[0].Writability != ast.SettingConst || alter.AddSettings[1].Profile != "readonly" {
+		t.Errorf("unexpected ALTER SETTINGS PROFILE %+v", alter)
+	}
+	if !slices.Equal(alter.DropSettings, []string{"max_threads", "custom_a.b"}) || !slices.Equal(alter.DropProfiles, []string{"web"}) ||
+		!alter.DropAllProfiles || alter.DropAllSettings || !alter.Roles.All {
+		t.Errorf("unexpected ALTER SETTINGS PROFILE %+v", alter)
+	}
+}
+
+func TestNamedCollections(t *testing.T) {
+	query := `CREATE NAMED COLLECTION IF NOT EXISTS s3 ON CLUSTER c AS url = 'https://bucket.s3.amazonaws.com/' OVERRIDABLE, secret_key = 'x' NOT OVERRIDABLE, format = 'CSV';
+ALTER NAMED COLLECTION IF EXISTS s3 SET format = 'Parquet' NOT OVERRIDABLE DELETE secret_key, url;
+DROP NAMED COLLECTION IF EXISTS s3 ON CLUSTER c`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 3 {
+		t.Fatalf("expected 3 statements, got %d", len(stmts))
+	}
+
+	create := stmts[0].(*ast.CreateNamedCollectionQuery)
+	if !create.IfNotExists || create.Name != "s3" || create.OnCluster != "c" || len(create.Pairs) != 3 {
+		t.Fatalf("unexpected CREATE NAMED COLLECTION %+v", create)
+	}
+	url, secret, format := create.Pairs[0], create.Pairs[1], create.Pairs[2]
+	if url.Key != "url" || url.Value.(*ast.Literal).Value != "https://bucket.s3.amazonaws.com/" || url.Overridable == nil || !*url.Overridable {
+		t.Errorf("unexpected pair %+v", url)
+	}
+	if secret.Key != "secret_key" || secret.Overridable == nil || *secret.Overridable {
+		t.Errorf("unexpected pair %+v", secret)
+	}
+	if format.Overridable != nil {
+		t.Errorf("expected no OVERRIDABLE, got %v", *format.Overridable)
+	}
+
+	alter := stmts[1].(*ast.AlterNamedCollectionQuery)
+	if !alter.IfExists || len(alter.Set) != 1 || alter.Set[0].Key != "format" || alter.Set[0].Overridable == nil ||
+		!slices.Equal(alter.Delete, []string{"secret_key", "url"}) {
+		t.Errorf("unexpected ALTER NAMED COLLECTION %+v", alter)
+	}
+
+	drop := stmts[2].(*ast.DropNamedCollectionQuery)
+	if !drop.IfExists || drop.Name != "s3" || drop.OnCluster != "c" {
+		t.Errorf("unexpected DROP NAMED COLLECTION %+v", drop)
+	}
+}
+
+func TestWorkloads(t *testing.T) {
+	query := `CREATE OR REPLACE RESOURCE io ON CLUSTER c (WRITE DISK s3, READ ANY DISK, MASTER THREAD);
+CREATE WORKLOAD IF NOT EXISTS production IN all SETTINGS priority = 1, weight = 9, max_speed = 1000000 FOR io;
+DROP RESOURCE IF EXISTS io;
+DROP WORKLOAD production ON CLUSTER c`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 4 {
+		t.Fatalf("expected 4 statements, got %d", len(stmts))
+	}
+
+	resource := stmts[0].(*ast.CreateResourceQuery)
+	if !resource.OrReplace || resource.Name != "io" || resource.OnCluster != "c" || len(resource.Operations) != 3 {
+		t.Fatalf("unexpected CREATE RESOURCE %+v", resource)
+	}
+	if op := resource.Operations[0]; op.Mode != ast.ResourceWrite || op.Disk != "s3" || op.AnyDisk {
+		t.Errorf("unexpected operation %+v", op)
+	}
+	if op := resource.Operations[1]; op.Mode != ast.ResourceRead || op.Disk != "" || !op.AnyDisk {
+		t.Errorf("unexpected operation %+v", op)
+	}
+	if op := resource.Operations[2]; op.Mode != ast.ResourceMasterThread {
+		t.Errorf("unexpected operation %+v", op)
+	}
+
+	workload := stmts[1].(*ast.CreateWorkloadQuery)
+	if !workload.IfNotExists || workload.Name != "production" || workload.Parent != "all" || len(workload.Settings) != 3 {
+		t.Fatalf("unexpected CREATE WORKLOAD %+v", workload)
+	}
+	if s := workload.Settings[1]; s.Name != "weight" || s.Value.(*ast.Literal).Value != int64(9) || s.Resource != "" {
+		t.Errorf("unexpected setting %+v", s)
+	}
+	if s := workload.Settings[2]; s.Name != "max_speed" || s.Resource != "io" {
+		t.Errorf("unexpected setting %+v", s)
+	}
+
+	if drop := stmts[2].(*ast.DropResourceQuery); !drop.IfExists || drop.Name != "io" {
+		t.Errorf("unexpected DROP RESOURCE %+v", drop)
+	}
+	if drop := stmts[3].(*ast.DropWorkloadQuery); drop.Name != "production" || drop.OnCluster != "c" {
+		t.Errorf("unexpected DROP WORKLOAD %+v", drop)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	query := `CREATE USER IF NOT EXISTS u1, u2@'%.example.com' ON CLUSTER c IDENTIFIED WITH plaintext_password BY 'a', BY 'b', ldap SERVER 'srv' HOST IP '192.168.0.0/16', '::1' HOST LOCAL VALID UNTIL '2030-01-01' DEFAULT ROLE ALL EXCEPT r1 DEFAULT DATABASE db GRANTEES ANY EXCEPT u3 SETTINGS PROFILE 'default';
+CREATE USER u4 IDENTIFIED WITH ssh_key BY KEY 'AAAA' TYPE 'ssh-ed25519', KEY 'BBBB' TYPE 'ssh-rsa';
+ALTER USER IF EXISTS u1 RENAME TO u5@'localhost' NOT IDENTIFIED ADD HOST NAME 'example.com' DROP HOST REGEXP 'a', 'b' DEFAULT DATABASE NONE;
+CREATE USER u6 VALID UNTIL '2030-01-01' IN local_directory;
+CREATE USER u7 IDENTIFIED BY 'c' VALID UNTIL {until:String} IN local_directory`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 5 {
+		t.Fatalf("expected 5 statements, got %d", len(stmts))
+	}
+
+	create := stmts[0].(*ast.CreateUserQuery)
+	if create.IsAlter || !create.IfNotExists || len(create.Names) != 2 || create.Names[1] != "u2@%.example.com" || create.OnCluster != "c" {
+		t.Fatalf("unexpected CREATE USER %+v", create)
+	}
+	if len(create.AuthMethods) != 3 {
+		t.Fatalf("expected 3 authentication methods, got %d", len(create.AuthMethods))
+	}
+	if m := create.AuthMethods[1]; m.Type != "" || m.Value.(*ast.Literal).Value != "b" {
+		t.Errorf("unexpected authentication method %+v", m)
+	}
+	if m := create.AuthMethods[2]; m.Type != ast.AuthLDAP || m.Value.(*ast.Literal).Value != "srv" {
+		t.Errorf("unexpected authentication method %+v", m)
+	}
+	if len(create.Hosts) != 3 || create.Hosts[1].Kind != ast.UserHostIP || create.Hosts[1].Pattern != "::1" || create.Hosts[2].Kind != ast.UserHostLocal {
+		t.Errorf("unexpected hosts %+v", create.Hosts)
+	}
+	if create.ValidUntil == nil || !create.DefaultRoles.All || create.DefaultRoles.Except[0] != "r1" || create.DefaultDatabase != "db" {
+		t.Errorf("unexpected CREATE USER %+v", create)
+	}
+	if !create.Grantees.All || create.Grantees.Except[0] != "u3" || len(create.Settings) != 1 || create.Settings[0].Profile != "default" {
+		t.Errorf("unexpected grantees or settings %+v", create)
+	}
+
+	keys := stmts[1].(*ast.CreateUserQuery).AuthMethods[0]
+	if keys.Type != ast.AuthSSHKey || len(keys.SSHKeys) != 2 || keys.SSHKeys[1].Key != "BBBB" || keys.SSHKeys[1].Type != "ssh-rsa" {
+		t.Errorf("unexpected authentication method %+v", keys)
+	}
+
+	alter := stmts[2].(*ast.CreateUserQuery)
+	if !alter.IsAlter || !alter.IfExists || alter.NewName != "u5@localhost" || !alter.NotIdentified || !alter.DefaultDatabaseNone {
+		t.Fatalf("unexpected ALTER USER %+v", alter)
+	}
+	if len(alter.AddHosts) != 1 || alter.AddHosts[0].Kind != ast.UserHostName || alter.AddHosts[0].Pattern != "example.com" {
+		t.Errorf("unexpected added hosts %+v", alter.AddHosts)
+	}
+	if len(alter.DropHosts) != 2 || alter.DropHosts[1].Kind != ast.UserHostRegexp || alter.DropHosts[1].Pattern != "b" {
+		t.Errorf("unexpected dropped hosts %+v", alter.DropHosts)
+	}
+
+	// VALID UNTIL takes a string or a parameter, so IN names the storage.
+	valid := stmts[3].(*ast.CreateUserQuery)
+	if lit, ok := valid.ValidUntil.(*ast.Literal); !ok || lit.Value != "2030-01-01" || valid.Storage != "local_directory" {
+		t.Errorf("unexpected VALID UNTIL %+v", valid)
+	}
+	valid = stmts[4].(*ast.CreateUserQuery)
+	if param, ok := valid.AuthMethods[0].ValidUntil.(*ast.Parameter); !ok || param.Name != "until" || valid.Storage != "local_directory" {
+		t.Errorf("unexpected VALID UNTIL %+v", valid)
+	}
+}
+
+func TestTTLActions(t *testing.T) {
+	query := `CREATE TABLE t (d DateTime, k UInt32, v UInt64) ENGINE = MergeTree ORDER BY k
+TTL d + INTERVAL 1 MONTH RECOMPRESS CODEC(ZSTD(12)),
+    d + INTERVAL 3 MONTH TO VOLUME 's3_cold',
+    d + INTERVAL 6 MONTH TO DISK IF EXISTS 'archive',
+    d + INTERVAL 1 YEAR GROUP BY k SET v = max(v), d = min(d),
+    d + INTERVAL 2 YEAR DELETE WHERE k = 0`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	create := stmts[0].(*ast.CreateTableQuery)
+	if create.TTL == nil || len(create.TTL.Elements) != 5 {
+		t.Fatalf("expected 5 TTL elements, got %+v", create.TTL)
+	}
+	elems := create.TTL.Elements
+
+	if e := elems[0]; e.Action != ast.TTLRecompress || e.Codec == nil || e.Codec.Codecs[0].Name != "ZSTD" {
+		t.Errorf("unexpected TTL element %+v", e)
+	}
+	if e := elems[1]; e.Action != ast.TTLMoveToVolume || e.Volume != "s3_cold" || e.Disk != "" {
+		t.Errorf("unexpected TTL element %+v", e)
+	}
+	if e := elems[2]; e.Action != ast.TTLMoveToDisk || e.Disk != "archive" || !e.IfExists {
+		t.Errorf("unexpected TTL element %+v", e)
+	}
+	if e := elems[3]; e.Action != ast.TTLGroupBy || len(e.GroupBy) != 1 || len(e.Set) != 2 || e.Set[1].Column != "d" {
+		t.Errorf("unexpected TTL element %+v", e)
+	}
+	if e := elems[4]; e.Action != ast.TTLDelete || e.Where == nil {
+		t.Errorf("unexpected TTL element %+v", e)
+	}
+}
+
+func TestAlterOperands(t *testing.T) {
+	query := `ALTER TABLE t MOVE PARTITION 202401 TO VOLUME 's3_cold', MOVE PART 'all_1_1_0' TO DISK 'hdd';
+ALTER TABLE t ATTACH PARTITION 1 FROM db.src, REPLACE PARTITION 2 FROM src;
+ALTER TABLE t MODIFY COLUMN c REMOVE CODEC;
+ALTER TABLE t FREEZE PARTITION 1 WITH NAME 'b1', UNFREEZE WITH NAME 'b0';
+ALTER TABLE mv MODIFY REFRESH EVERY 1 HOUR OFFSET 10 MINUTE RANDOMIZE FOR 5 MINUTE DEPENDS ON db.a, b APPEND;
+ALTER DATABASE d MODIFY COMMENT 'archive'`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 6 {
+		t.Fatalf("expected 6 statements, got %d", len(stmts))
+	}
+
+	move := stmts[0].(*ast.AlterQuery).Commands
+	if move[0].Type != ast.AlterMovePartition || move[0].ToVolume != "s3_cold" || move[0].ToDisk != "" {
+		t.Errorf("unexpected MOVE PARTITION %+v", move[0])
+	}
+	if !move[1].IsPart || move[1].ToDisk != "hdd" {
+		t.Errorf("unexpected MOVE PART %+v", move[1])
+	}
+	if _, err := parser.Parse(context.Background(), strings.NewReader("ALTER TABLE t MOVE PART 'all_1_1_0' TO TABLE u")); err == nil {
+		t.Errorf("expected MOVE PART ... TO TABLE to fail")
+	}
+
+	from := stmts[1].(*ast.AlterQuery).Commands
+	if from[0].FromDatabase != "db" || from[0].FromTable != "src" || from[1].FromDatabase != "" || from[1].FromTable != "src" {
+		t.Errorf("unexpected FROM tables %+v %+v", from[0], from[1])
+	}
+
+	if cmd := stmts[2].(*ast.AlterQuery).Commands[0]; cmd.Column.Name != "c" || cmd.RemoveProperty != "CODEC" {
+		t.Errorf("unexpected MODIFY COLUMN %+v", cmd)
+	}
+
+	freeze := stmts[3].(*ast.AlterQuery).Commands
+	if freeze[0].Type != ast.AlterFreezePartition || freeze[0].WithName != "b1" {
+		t.Errorf("unexpected FREEZE %+v", freeze[0])
+	}
+	if freeze[1].Type != ast.AlterUnfreeze || freeze[1].WithName != "b0" {
+		t.Errorf("unexpected UNFREEZE %+v", freeze[1])
+	}
+
+	refresh := stmts[4].(*ast.AlterQuery).Commands[0]
+	if refresh.Type != ast.AlterModifyRefresh || refresh.RefreshType != "EVERY" || refresh.RefreshUnit != "HOUR" || !refresh.RefreshAppend {
+		t.Errorf("unexpected MODIFY REFRESH %+v", refresh)
+	}
+	if refresh.RefreshOffset == nil || refresh.RefreshOffset.Unit != "MINUTE" || refresh.RefreshRandomize == nil || len(refresh.RefreshDependsOn) != 2 || refresh.RefreshDependsOn[0] != "db.a" {
+		t.Errorf("unexpected MODIFY REFRESH %+v", refresh)
+	}
+
+	database := stmts[5].(*ast.AlterQuery)
+	if !database.IsDatabase || database.Database != "d" || database.Commands[0].Type != ast.AlterModifyDatabaseComment || database.Commands[0].Comment != "archive" {
+		t.Errorf("unexpected ALTER DATABASE %+v", database)
+	}
+}
+
+func TestSystemCommands(t *testing.T) {
+	query := `SYSTEM STOP TTL MERGES ON CLUSTER c db.t;
+SYSTEM DROP QUERY CONDITION CACHE;
+SYSTEM DROP QUERY CACHE TAG 'daily';
+SYSTEM SYNC REPLICA t LIGHTWEIGHT FROM 'r1', 'r2';
+SYSTEM DROP REPLICA 'r1' FROM ZKPATH '/clickhouse/tables/t';
+SYSTEM ENABLE FAILPOINT replicated_merge_tree_insert_retry_pause;
+SYSTEM FLUSH LOGS query_log, part_log;
+SYSTEM JEMALLOC PURGE;
+SYSTEM STOP VIRTUAL PARTS UPDATE ON CLUSTER c db.t;
+SYSTEM STOP MERGES ON VOLUME default.hot`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 10 {
+		t.Fatalf("expected 10 statements, got %d", len(stmts))
+	}
+
+	if sys := stmts[0].(*ast.SystemQuery); sys.Kind != ast.SystemStopTTLMerges || sys.OnCluster != "c" || sys.Database != "db" || sys.Table != "t" {
+		t.Errorf("unexpected STOP TTL MERGES %+v", sys)
+	}
+	if sys := stmts[1].(*ast.SystemQuery); sys.Kind != ast.SystemDropCache || sys.Cache != "QUERY CONDITION" {
+		t.Errorf("unexpected DROP CACHE %+v", sys)
+	}
+	if sys := stmts[2].(*ast.SystemQuery); sys.Cache != "QUERY" || sys.CacheTag != "daily" {
+		t.Errorf("unexpected DROP QUERY CACHE %+v", sys)
+	}
+	if sys := stmts[3].(*ast.SystemQuery); sys.Kind != ast.SystemSyncReplica || sys.Table != "t" || sys.SyncMode != ast.SyncReplicaLightweight || len(sys.SyncFrom) != 2 {
+		t.Errorf("unexpected SYNC REPLICA %+v", sys)
+	}
+	if sys := stmts[4].(*ast.SystemQuery); sys.Kind != ast.SystemDropReplica || sys.Replica != "r1" || sys.ReplicaPath != "/clickhouse/tables/t" {
+		t.Errorf("unexpected DROP REPLICA %+v", sys)
+	}
+	if sys := stmts[5].(*ast.SystemQuery); sys.Kind != ast.SystemEnableFailpoint || sys.Failpoint != "replicated_merge_tree_insert_retry_pause" {
+		t.Errorf("unexpected ENABLE FAILPOINT %+v", sys)
+	}
+	if sys := stmts[6].(*ast.SystemQuery); sys.Kind != ast.SystemFlushLogs || len(sys.Logs) != 2 || sys.Logs[1] != "part_log" {
+		t.Errorf("unexpected FLUSH LOGS %+v", sys)
+	}
+	if sys := stmts[7].(*ast.SystemQuery); sys.Kind != ast.SystemOther || sys.Command != "JEMALLOC PURGE" {
+		t.Errorf("unexpected JEMALLOC PURGE %+v", sys)
+	}
+	if sys := stmts[8].(*ast.SystemQuery); sys.Kind != ast.SystemOther || sys.Command != "STOP VIRTUAL PARTS UPDATE" || sys.OnCluster != "c" || sys.Database != "db" || sys.Table != "t" {
+		t.Errorf("unexpected STOP VIRTUAL PARTS UPDATE %+v", sys)
+	}
+	if sys := stmts[9].(*ast.SystemQuery); sys.Kind != ast.SystemStopMerges || sys.Volume != "default.hot" || sys.Table != "" {
+		t.Errorf("unexpected STOP MERGES ON VOLUME %+v", sys)
+	}
+}
+
+func TestCreateDropKinds(t *testing.T) {
+	query := `CREATE TABLE db.t (a UInt8) ENGINE = MergeTree ORDER BY a;
+CREATE VIEW v AS SELECT 1;
+CREATE MATERIALIZED VIEW mv TO db.dst POPULATE AS SELECT 1;
+CREATE DATABASE IF NOT EXISTS d ENGINE = Atomic;
+CREATE OR REPLACE FUNCTION f AS (x) -> x + 1;
+CREATE DICTIONARY dict (k UInt64) PRIMARY KEY k SOURCE(NULL()) LAYOUT(FLAT()) LIFETIME(0);
+DROP TABLE IF EXISTS db.t1, t2 SYNC;
+DROP VIEW v;
+DROP DATABASE d ON CLUSTER c;
+DROP FUNCTION IF EXISTS f;
+DROP INDEX i ON db.t`
+
+	stmts, err := parser.Parse(context.Background(), strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(stmts) != 11 {
+		t.Fatalf("expected 11 statements, got %d", len(stmts))
+	}
+
+	if c, ok := stmts[0].(*ast.CreateTableQuery); !ok || c.Database != "db" || c.Table != "t" || c.Engine == nil || len(c.Columns) != 1 {
+		t.Errorf("unexpected CREATE TABLE %#v", stmts[0])
+	}
+	if c, ok := stmts[1].(*ast.CreateViewQuery); !ok || c.View != "v" || c.AsSelect == nil {
+		t.Errorf("unexpected CREATE VIEW %#v", stmts[1])
+	}
+	if c, ok := stmts[2].(*ast.CreateMaterializedViewQuery); !ok || c.View != "mv" || c.ToDatabase != "db" || c.To != "dst" || !c.Populate {
+		t.Errorf("unexpected CREATE MATERIALIZED VIEW %#v", stmts[2])
+	}
+	if c, ok := stmts[3].(*ast.CreateDatabaseQuery); !ok || c.Database != "d" || !c.IfNotExists || c.Engine == nil {
+		t.Errorf("unexpected CREATE DATABASE %#v", stmts[3])
+	}
+	if c, ok := stmts[4].(*ast.CreateFunctionQuery); !ok || c.Name != "f" || !c.OrReplace || c.Body == nil {
+		t.Errorf("unexpected CREATE FUNCTION %#v", stmts[4])
+	}
+	if c, ok := stmts[5].(*ast.CreateDictionaryQuery); !ok || c.Dictionary != "dict" || len(c.Attributes) != 1 || c.Definition == nil {
+		t.Errorf("unexpected CREATE DICTIONARY %#v", stmts[5])
+	}
+	if d, ok := stmts[6].(*ast.DropTableQuery); !ok || !d.IfExists || len(d.Tables) != 2 || !d.Sync {
+		t.Errorf("unexpected DROP TABLE %#v", stmts[6])
+	} else {
+		for i, want := range []string{"db.t1", "t2"} {
+			name := d.Tables[i]
+			if got := query[name.Pos().Offset:name.End().Offset]; got != want {
+				t.Errorf("DROP TABLE name %d: got %q, want %q", i, got, want)
+			}
+		}
+	}
+	if d, ok := stmts[7].(*ast.DropViewQuery); !ok || len(d.Views) != 1 || d.Views[0].Table != "v" {
+		t.Errorf("unexpected DROP VIEW %#v", stmts[7])
+	}
+	if d, ok := stmts[8].(*ast.DropDatabaseQuery); !ok || d.Database != "d" || d.OnCluster != "c" {
+		t.Errorf("unexpected DROP DATABASE %#v", stmts[8])
+	}
+	if d, ok := stmts[9].(*ast.DropFunctionQuery); !ok || d.Name != "f" || !d.IfExists {
+		t.Errorf("unexpected DROP FUNCTION %#v", stmts[9])
+	}
+	if d, ok := stmts[10].(*ast.DropIndexQuery); !ok || d.Index != "i" || d.Database != "db" || d.Table != "t" {
+		t.Errorf("unexpected DROP INDEX %#v", stmts[10])
+	}
+
+	for _, stmt := range stmts {
+		data, err := json.Marshal(stmt)
+		if err != nil {
+			t.Fatalf("marshal %T: %v", stmt, err)
+		}
+		back, err := ast.UnmarshalStatement(data)
+		if err != nil {
+			t.Fatalf("unmarshal %T: %v", stmt, err)
+		}
+		if fmt.Sprintf("%T", back) != fmt.Sprintf("%T", stmt) {
+			t.Errorf("round trip of %T gave %T", stmt, back)
+		}
+	}
+}
+
+// TestEndPositionCases checks that setEnd has a case for every AST node
+// with an end position, as a missing case leaves the end position zero
+func TestEndPositionCases(t *testing.T) {
+	fset := gotoken.NewFileSet()
+	files, err := filepath.Glob("../ast/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make(map[string]bool)
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := goparser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		goast.Inspect(f, func(n goast.Node) bool {
+			spec, ok := n.(*goast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if st, ok := spec.Type.(*goast.StructType); ok {
+				for _, field := range st.Fields.List {
+					for _, ident := range field.Names {
+						if ident.Name == "EndPosition" {
+							want[spec.Name.Name] = true
+						}
+					}
+				}
+			}
+			return false
+		})
+	}
+	// Comments get their end position from the lexer
+	delete(want, "Comment")
+
+	f, err := goparser.ParseFile(fset, "position.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have := make(map[string]bool)
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*goast.FuncDecl); !ok || fn.Name.Name != "setEnd" {
+			continue
+		}
+		goast.Inspect(decl, func(n goast.Node) bool {
+			if star, ok := n.(*goast.StarExpr); ok {
+				if sel, ok := star.X.(*goast.SelectorExpr); ok {
+					have[sel.Sel.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	for name := range want {
+		if !have[name] {
+			t.Errorf("setEnd has no case for *ast.%s", name)
+		}
+	}
+	if len(want) == 0 {
+		t.Errorf("found no AST nodes with an end position")
+	}
+}
+
 // BenchmarkParser benchmarks the parser performance using a complex query
 func BenchmarkParser(b *testing.B) {
 	query := `
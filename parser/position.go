@@ -0,0 +1,327 @@
+package parser
+
+import (
+	"reflect"
+
+	"github.com/sqlc-dev/doubleclick/ast"
+	"github.com/sqlc-dev/doubleclick/token"
+)
+
+// finish records the end of the most recently consumed token as the end
+// position of n. Parse functions finish a node just before returning it, so
+// the recorded end covers every token that belongs to the node, including
+// trailing keywords and closing parentheses.
+func (p *Parser) finish(n ast.Node) {
+	setEnd(n, p.prevEnd)
+}
+
+// setPos sets the start position of n. It is used to widen the span of a
+// parenthesized expression, which is returned as the inner node, to
+// include the opening parenthesis.
+func setPos(n ast.Node, pos token.Position) {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return
+	}
+	if f := reflect.ValueOf(n).Elem().FieldByName("Position"); f.IsValid() {
+		f.Set(reflect.ValueOf(pos))
+	}
+}
+
+// setEnd sets the end position of n. Nodes that consumed no tokens get an
+// empty span at their start position. Unlike setPos, setEnd runs for every
+// node, so it uses a type switch rather than reflection; TestEndPositionCases
+// checks that the switch covers every node with an end position.
+func setEnd(n ast.Node, end token.Position) {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return
+	}
+	if start := n.Pos(); end.Offset < start.Offset {
+		end = start
+	}
+	switch n := n.(type) {
+	case *ast.SelectWithUnionQuery:
+		n.EndPosition = end
+	case *ast.SelectIntersectExceptQuery:
+		n.EndPosition = end
+	case *ast.SelectQuery:
+		n.EndPosition = end
+	case *ast.ArrayJoinClause:
+		n.EndPosition = end
+	case *ast.WindowDefinition:
+		n.EndPosition = end
+	case *ast.IntoOutfileClause:
+		n.EndPosition = end
+	case *ast.TablesInSelectQuery:
+		n.EndPosition = end
+	case *ast.TablesInSelectQueryElement:
+		n.EndPosition = end
+	case *ast.TableExpression:
+		n.EndPosition = end
+	case *ast.SampleClause:
+		n.EndPosition = end
+	case *ast.TableJoin:
+		n.EndPosition = end
+	case *ast.OrderByElement:
+		n.EndPosition = end
+	case *ast.InterpolateElement:
+		n.EndPosition = end
+	case *ast.SettingExpr:
+		n.EndPosition = end
+	case *ast.InsertQuery:
+		n.EndPosition = end
+	case *ast.CreateTableQuery:
+		n.EndPosition = end
+	case *ast.CreateViewQuery:
+		n.EndPosition = end
+	case *ast.CreateMaterializedViewQuery:
+		n.EndPosition = end
+	case *ast.CreateWindowViewQuery:
+		n.EndPosition = end
+	case *ast.CreateDatabaseQuery:
+		n.EndPosition = end
+	case *ast.CreateFunctionQuery:
+		n.EndPosition = end
+	case *ast.CreateDictionaryQuery:
+		n.EndPosition = end
+	case *ast.ColumnDeclaration:
+		n.EndPosition = end
+	case *ast.DictionaryAttributeDeclaration:
+		n.EndPosition = end
+	case *ast.DictionaryDefinition:
+		n.EndPosition = end
+	case *ast.DictionarySource:
+		n.EndPosition = end
+	case *ast.KeyValuePair:
+		n.EndPosition = end
+	case *ast.DictionaryLifetime:
+		n.EndPosition = end
+	case *ast.DictionaryLayout:
+		n.EndPosition = end
+	case *ast.DictionaryRange:
+		n.EndPosition = end
+	case *ast.DataType:
+		n.EndPosition = end
+	case *ast.ObjectTypeArgument:
+		n.EndPosition = end
+	case *ast.NameTypePair:
+		n.EndPosition = end
+	case *ast.CodecExpr:
+		n.EndPosition = end
+	case *ast.IndexDefinition:
+		n.EndPosition = end
+	case *ast.Constraint:
+		n.EndPosition = end
+	case *ast.EngineClause:
+		n.EndPosition = end
+	case *ast.TTLClause:
+		n.EndPosition = end
+	case *ast.TTLElement:
+		n.EndPosition = end
+	case *ast.DropTableQuery:
+		n.EndPosition = end
+	case *ast.DropViewQuery:
+		n.EndPosition = end
+	case *ast.DropDictionaryQuery:
+		n.EndPosition = end
+	case *ast.DropDatabaseQuery:
+		n.EndPosition = end
+	case *ast.DropFunctionQuery:
+		n.EndPosition = end
+	case *ast.DropIndexQuery:
+		n.EndPosition = end
+	case *ast.DropUserQuery:
+		n.EndPosition = end
+	case *ast.DropQuotaQuery:
+		n.EndPosition = end
+	case *ast.UndropQuery:
+		n.EndPosition = end
+	case *ast.UpdateQuery:
+		n.EndPosition = end
+	case *ast.AlterQuery:
+		n.EndPosition = end
+	case *ast.AlterCommand:
+		n.EndPosition = end
+	case *ast.Projection:
+		n.EndPosition = end
+	case *ast.ProjectionSelectQuery:
+		n.EndPosition = end
+	case *ast.Assignment:
+		n.EndPosition = end
+	case *ast.TruncateQuery:
+		n.EndPosition = end
+	case *ast.DeleteQuery:
+		n.EndPosition = end
+	case *ast.UseQuery:
+		n.EndPosition = end
+	case *ast.DetachQuery:
+		n.EndPosition = end
+	case *ast.AttachQuery:
+		n.EndPosition = end
+	case *ast.BackupQuery:
+		n.EndPosition = end
+	case *ast.RestoreQuery:
+		n.EndPosition = end
+	case *ast.DescribeQuery:
+		n.EndPosition = end
+	case *ast.ShowQuery:
+		n.EndPosition = end
+	case *ast.ExplainQuery:
+		n.EndPosition = end
+	case *ast.SetQuery:
+		n.EndPosition = end
+	case *ast.OptimizeQuery:
+		n.EndPosition = end
+	case *ast.CheckQuery:
+		n.EndPosition = end
+	case *ast.SystemQuery:
+		n.EndPosition = end
+	case *ast.TransactionControlQuery:
+		n.EndPosition = end
+	case *ast.RenamePair:
+		n.EndPosition = end
+	case *ast.RenameQuery:
+		n.EndPosition = end
+	case *ast.ExchangeQuery:
+		n.EndPosition = end
+	case *ast.ExistsQuery:
+		n.EndPosition = end
+	case *ast.GrantQuery:
+		n.EndPosition = end
+	case *ast.GrantElement:
+		n.EndPosition = end
+	case *ast.Privilege:
+		n.EndPosition = end
+	case *ast.RoleSet:
+		n.EndPosition = end
+	case *ast.ShowGrantsQuery:
+		n.EndPosition = end
+	case *ast.KillQuery:
+		n.EndPosition = end
+	case *ast.ShowPrivilegesQuery:
+		n.EndPosition = end
+	case *ast.ShowCreateQuotaQuery:
+		n.EndPosition = end
+	case *ast.CreateQuotaQuery:
+		n.EndPosition = end
+	case *ast.QuotaInterval:
+		n.EndPosition = end
+	case *ast.QuotaLimit:
+		n.EndPosition = end
+	case *ast.CreateSettingsProfileQuery:
+		n.EndPosition = end
+	case *ast.AlterSettingsProfileQuery:
+		n.EndPosition = end
+	case *ast.DropSettingsProfileQuery:
+		n.EndPosition = end
+	case *ast.CreateNamedCollectionQuery:
+		n.EndPosition = end
+	case *ast.AlterNamedCollectionQuery:
+		n.EndPosition = end
+	case *ast.DropNamedCollectionQuery:
+		n.EndPosition = end
+	case *ast.ShowCreateSettingsProfileQuery:
+		n.EndPosition = end
+	case *ast.CreateRowPolicyQuery:
+		n.EndPosition = end
+	case *ast.RowPolicyName:
+		n.EndPosition = end
+	case *ast.DropRowPolicyQuery:
+		n.EndPosition = end
+	case *ast.ShowCreateRowPolicyQuery:
+		n.EndPosition = end
+	case *ast.CreateRoleQuery:
+		n.EndPosition = end
+	case *ast.CreateUserQuery:
+		n.EndPosition = end
+	case *ast.AuthenticationMethod:
+		n.EndPosition = end
+	case *ast.SSHKey:
+		n.EndPosition = end
+	case *ast.UserHost:
+		n.EndPosition = end
+	case *ast.DropRoleQuery:
+		n.EndPosition = end
+	case *ast.ShowCreateRoleQuery:
+		n.EndPosition = end
+	case *ast.SetRoleQuery:
+		n.EndPosition = end
+	case *ast.CreateResourceQuery:
+		n.EndPosition = end
+	case *ast.ResourceOperation:
+		n.EndPosition = end
+	case *ast.DropResourceQuery:
+		n.EndPosition = end
+	case *ast.CreateWorkloadQuery:
+		n.EndPosition = end
+	case *ast.DropWorkloadQuery:
+		n.EndPosition = end
+	case *ast.CreateIndexQuery:
+		n.EndPosition = end
+	case *ast.Identifier:
+		n.EndPosition = end
+	case *ast.TableIdentifier:
+		n.EndPosition = end
+	case *ast.Literal:
+		n.EndPosition = end
+	case *ast.Asterisk:
+		n.EndPosition = end
+	case *ast.ReplaceExpr:
+		n.EndPosition = end
+	case *ast.ColumnTransformer:
+		n.EndPosition = end
+	case *ast.ColumnsMatcher:
+		n.EndPosition = end
+	case *ast.FunctionCall:
+		n.EndPosition = end
+	case *ast.WindowSpec:
+		n.EndPosition = end
+	case *ast.WindowFrame:
+		n.EndPosition = end
+	case *ast.FrameBound:
+		n.EndPosition = end
+	case *ast.BinaryExpr:
+		n.EndPosition = end
+	case *ast.UnaryExpr:
+		n.EndPosition = end
+	case *ast.TernaryExpr:
+		n.EndPosition = end
+	case *ast.Subquery:
+		n.EndPosition = end
+	case *ast.WithElement:
+		n.EndPosition = end
+	case *ast.CaseExpr:
+		n.EndPosition = end
+	case *ast.WhenClause:
+		n.EndPosition = end
+	case *ast.CastExpr:
+		n.EndPosition = end
+	case *ast.ExtractExpr:
+		n.EndPosition = end
+	case *ast.IntervalExpr:
+		n.EndPosition = end
+	case *ast.ArrayAccess:
+		n.EndPosition = end
+	case *ast.TupleAccess:
+		n.EndPosition = end
+	case *ast.Lambda:
+		n.EndPosition = end
+	case *ast.Parameter:
+		n.EndPosition = end
+	case *ast.AliasedExpr:
+		n.EndPosition = end
+	case *ast.BetweenExpr:
+		n.EndPosition = end
+	case *ast.InExpr:
+		n.EndPosition = end
+	case *ast.IsNullExpr:
+		n.EndPosition = end
+	case *ast.LikeExpr:
+		n.EndPosition = end
+	case *ast.ExistsExpr:
+		n.EndPosition = end
+	case *ast.ParallelWithQuery:
+		n.EndPosition = end
+	case *ast.BadStatement:
+		n.EndPosition = end
+	}
+}
@@ -0,0 +1,206 @@
+package parser
+
+import (
+	"math"
+
+	"github.com/sqlc-dev/doubleclick/ast"
+	"github.com/sqlc-dev/doubleclick/lexer"
+	"github.com/sqlc-dev/doubleclick/token"
+)
+
+// ValuesRow is a row of an INSERT ... VALUES statement, as passed to the
+// function set with OnValuesRow.
+type ValuesRow struct {
+	Insert *ast.InsertQuery // Statement being parsed, up to the VALUES keyword
+	Index  int              // Index of the row in the statement
+
+	// Literals holds the values of a row made only of plain literals,
+	// without building AST nodes: nil for NULL, bool, int64 or uint64 for
+	// integers, float64, or string. It is reused for the next row.
+	Literals []any
+
+	// Exprs holds the values as full expression trees if any of them is
+	// not a plain literal. Literals is empty in that case.
+	Exprs []ast.Expression
+}
+
+// OnValuesRow sets fn to be called for every row of an INSERT ... VALUES
+// statement, instead of collecting the rows in InsertQuery.Values, so that
+// statements with millions of rows can be parsed in constant memory. If fn
+// returns an error, the rest of the statement is skipped and Next returns
+// the error.
+func (p *Parser) OnValuesRow(fn func(row *ValuesRow) error) {
+	p.onValuesRow = fn
+}
+
+// plainValue is a value of a VALUES row made of a single literal token,
+// possibly negated.
+type plainValue struct {
+	minus lexer.Item // MINUS token, if negated
+	tok   lexer.Item // Literal token
+	value any
+}
+
+// parseValues parses the rows after VALUES, passing them to the
+// OnValuesRow function if one is set, or collecting them in ins.Values.
+func (p *Parser) parseValues(ins *ast.InsertQuery) {
+	row := ValuesRow{Insert: ins}
+	for p.currentIs(token.LPAREN) {
+		p.nextToken() // skip (
+		p.parseValuesRow(&row)
+		if p.currentIs(token.RPAREN) {
+			p.nextToken() // skip )
+		}
+
+		if p.onValuesRow == nil {
+			exprs := row.Exprs
+			if exprs == nil {
+				exprs = p.plainValueNodes()
+			}
+			ins.Values = append(ins.Values, exprs)
+		} else if err := p.onValuesRow(&row); err != nil {
+			p.valuesErr = err
+			for !p.currentIs(token.EOF) && !p.currentIs(token.SEMICOLON) {
+				p.nextToken()
+			}
+			return
+		}
+		row.Index++
+
+		// Check for more rows
+		if p.currentIs(token.COMMA) {
+			p.nextToken()
+		} else {
+			break
+		}
+	}
+}
+
+// parseValuesRow parses the values of a row up to the closing parenthesis.
+// Plain literals are read without going through parseExpression until the
+// first value that is not one, at which point the row switches to full
+// expression trees.
+func (p *Parser) parseValuesRow(row *ValuesRow) {
+	row.Literals = row.Literals[:0]
+	row.Exprs = nil
+	p.plainValues = p.plainValues[:0]
+
+	for !p.currentIs(token.RPAREN) && !p.currentIs(token.EOF) {
+		if row.Exprs == nil {
+			if v, ok := p.plainValue(); ok {
+				if v.minus.Token == token.MINUS {
+					p.nextToken()
+				}
+				p.nextToken()
+				p.plainValues = append(p.plainValues, v)
+				row.Literals = append(row.Literals, v.value)
+			} else {
+				row.Exprs = append(make([]ast.Expression, 0, len(p.plainValues)+1), p.plainValueNodes()...)
+				row.Literals = row.Literals[:0]
+			}
+		}
+		if row.Exprs != nil {
+			if expr := p.parseExpression(LOWEST); expr != nil {
+				row.Exprs = append(row.Exprs, expr)
+			}
+		}
+
+		if p.currentIs(token.COMMA) {
+			p.nextToken()
+		} else {
+			break
+		}
+	}
+}
+
+// plainValue reports whether the next value is a plain literal, one that
+// parseExpression would turn into a single literal or a negated number, and
+// returns it without consuming it.
+func (p *Parser) plainValue() (plainValue, bool) {
+	var v plainValue
+	v.tok = p.current
+	next := p.peek
+	if v.tok.Token == token.MINUS {
+		v.minus, v.tok, next = p.current, p.peek, p.peekPeek
+		if v.tok.Token != token.NUMBER {
+			return v, false
+		}
+	}
+	if next.Token != token.COMMA && next.Token != token.RPAREN {
+		return v, false
+	}
+
+	switch v.tok.Token {
+	case token.STRING:
+		v.value = v.tok.Value
+	case token.NULL:
+		v.value = nil
+	case token.TRUE, token.FALSE:
+		v.value = v.tok.Token == token.TRUE
+	case token.NUMBER:
+		var lit ast.Literal
+		setNumberValue(&lit, v.tok.Value)
+		negative := v.minus.Token == token.MINUS
+		switch n := lit.Value.(type) {
+		case int64:
+			if negative {
+				n = -n
+			}
+			v.value = n
+		case uint64:
+			if !negative {
+				v.value = n
+			} else if n == 1<<63 {
+				v.value = int64(math.MinInt64)
+			} else {
+				return v, false
+			}
+		case float64:
+			if negative {
+				n = -n
+			}
+			v.value = n
+		default:
+			// Integers too large for 64 bits and malformed numbers
+			return v, false
+		}
+	default:
+		return v, false
+	}
+	return v, true
+}
+
+// plainValueNodes returns the plain values read so far in the current row
+// as the expressions parseExpression would have returned for them.
+func (p *Parser) plainValueNodes() []ast.Expression {
+	var exprs []ast.Expression
+	for _, v := range p.plainValues {
+		lit := &ast.Literal{
+			Position:    v.tok.Pos,
+			EndPosition: v.tok.End,
+		}
+		switch v.tok.Token {
+		case token.STRING:
+			lit.Type = ast.LiteralString
+			lit.Value = v.tok.Value
+		case token.NULL:
+			lit.Type = ast.LiteralNull
+		case token.TRUE, token.FALSE:
+			lit.Type = ast.LiteralBoolean
+			lit.Value = v.value
+		case token.NUMBER:
+			setNumberValue(lit, v.tok.Value)
+		}
+		if v.minus.Token != token.MINUS {
+			exprs = append(exprs, lit)
+			continue
+		}
+		exprs = append(exprs, &ast.UnaryExpr{
+			Position:    v.minus.Pos,
+			EndPosition: v.tok.End,
+			Op:          "-",
+			Operand:     lit,
+		})
+	}
+	return exprs
+}